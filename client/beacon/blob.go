@@ -0,0 +1,110 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"strings"
+)
+
+const (
+	// BlobSize bytes of a blob, 4096 field elements of 32 bytes
+	BlobSize = 131072
+
+	// FieldElementSize bytes of a field element, the first byte is zero to stay below the modulus
+	FieldElementSize = 32
+
+	// VersionedHashVersionKzg version byte of kzg commitment hashes, EIP-4844
+	VersionedHashVersionKzg = 0x01
+
+	// blobTerminator end of content marker, zeros padded after it
+	blobTerminator = 0x80
+)
+
+// VersionedHash versioned hash of the kzg commitment, 0x01 ++ sha256(commitment)[1:]
+func VersionedHash(commitment []byte) string {
+	sum := sha256.Sum256(commitment)
+	sum[0] = VersionedHashVersionKzg
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// VerifyBlob
+/***************************************
+ * verify the blob by the kzg commitment & the hex blob proof, EIP-4844
+ ***************************************/
+func VerifyBlob(blob, commitment []byte, proof string) error {
+	var (
+		b kzg4844.Blob
+		c kzg4844.Commitment
+		p kzg4844.Proof
+	)
+	if len(blob) != len(b) {
+		return fmt.Errorf("blob size[%d] invalid", len(blob))
+	}
+	if len(commitment) != len(c) {
+		return fmt.Errorf("commitment size[%d] invalid", len(commitment))
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(proof, "0x"))
+	if err != nil || len(data) != len(p) {
+		return fmt.Errorf("proof[%s] invalid", proof)
+	}
+
+	copy(b[:], blob)
+	copy(c[:], commitment)
+	copy(p[:], data)
+	return kzg4844.VerifyBlobProof(b, c, p)
+}
+
+// DecodeBlobs
+/***************************************
+ * decode content of blobs in order, 31 bytes of each field element
+ * zero paddings after the 0x80 terminator are trimmed, content kept as is without the terminator
+ ***************************************/
+func DecodeBlobs(blobs [][]byte) ([]byte, error) {
+	content := make([]byte, 0, len(blobs)*BlobSize)
+	for i, blob := range blobs {
+		if len(blob) != BlobSize {
+			return nil, fmt.Errorf("blob[%d] size[%d] invalid", i, len(blob))
+		}
+
+		for j := 0; j < BlobSize; j += FieldElementSize {
+			if blob[j] != 0 {
+				return nil, fmt.Errorf("blob[%d] field element[%d] invalid", i, j/FieldElementSize)
+			}
+			content = append(content, blob[j+1:j+FieldElementSize]...)
+		}
+	}
+
+	end := len(content)
+	for end > 0 && content[end-1] == 0 {
+		end--
+	}
+	if end > 0 && content[end-1] == blobTerminator {
+		return content[:end-1], nil
+	}
+	return content, nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/xylog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+// encodeBlobs encode content into blobs, 31 bytes per field element & 0x80 terminated
+func encodeBlobs(content []byte) [][]byte {
+	data := append(append([]byte{}, content...), blobTerminator)
+	blobs := make([][]byte, 0, 1)
+	for len(data) > 0 {
+		blob := make([]byte, BlobSize)
+		for j := 0; j < BlobSize && len(data) > 0; j += FieldElementSize {
+			n := copy(blob[j+1:j+FieldElementSize], data)
+			data = data[n:]
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs
+}
+
+// commit kzg commitment & hex proof of the blob
+func commit(t *testing.T, blob []byte) ([]byte, string) {
+	var b kzg4844.Blob
+	copy(b[:], blob)
+	commitment, err := kzg4844.BlobToCommitment(b)
+	assert.Nil(t, err)
+	proof, err := kzg4844.ComputeBlobProof(b, commitment)
+	assert.Nil(t, err)
+	return commitment[:], "0x" + hex.EncodeToString(proof[:])
+}
+
+func TestDecodeBlobs(t *testing.T) {
+	content := []byte(`data:,{"p":"brc-20","op":"mint","tick":"blob","amt":"1"}`)
+	blobs := encodeBlobs(content)
+	assert.Len(t, blobs, 1)
+	decoded, err := DecodeBlobs(blobs)
+	assert.Nil(t, err)
+	assert.Equal(t, content, decoded)
+
+	// content across blobs
+	large := bytes.Repeat([]byte{0xab}, BlobSize)
+	blobs = encodeBlobs(large)
+	assert.Len(t, blobs, 2)
+	decoded, err = DecodeBlobs(blobs)
+	assert.Nil(t, err)
+	assert.Equal(t, large, decoded)
+
+	// content without the terminator is kept as is, zeros included
+	raw := make([]byte, BlobSize)
+	raw[1], raw[2] = 'g', 'm'
+	decoded, err = DecodeBlobs([][]byte{raw})
+	assert.Nil(t, err)
+	assert.Len(t, decoded, BlobSize/FieldElementSize*(FieldElementSize-1))
+	assert.Equal(t, []byte("gm"), decoded[:2])
+
+	// field elements above the modulus & invalid sizes
+	blobs[1][0] = 0x01
+	_, err = DecodeBlobs(blobs)
+	assert.NotNil(t, err)
+	_, err = DecodeBlobs([][]byte{make([]byte, 10)})
+	assert.NotNil(t, err)
+}
+
+func TestVersionedHash(t *testing.T) {
+	// sha256 of empty commitment e3b0c442..., version byte replaced
+	assert.Equal(t, "0x01b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", VersionedHash(nil))
+}
+
+func TestVerifyBlob(t *testing.T) {
+	blob := encodeBlobs([]byte("gm"))[0]
+	commitment, proof := commit(t, blob)
+	assert.Nil(t, VerifyBlob(blob, commitment, proof))
+
+	// blobs of other commitments, invalid proofs & sizes
+	other := encodeBlobs([]byte("gn"))[0]
+	assert.NotNil(t, VerifyBlob(other, commitment, proof))
+	assert.NotNil(t, VerifyBlob(blob, commitment, "0x"))
+	assert.NotNil(t, VerifyBlob(blob, bytes.Repeat([]byte{0xc0}, 48), proof))
+	assert.NotNil(t, VerifyBlob(blob[:10], commitment, proof))
+}
+
+func TestBlobsAt(t *testing.T) {
+	blob := encodeBlobs([]byte("gm"))[0]
+	commitment, proof := commit(t, blob)
+	forged := encodeBlobs([]byte("gn"))[0]
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			fmt.Fprint(w, `{"data":{"genesis_time":"1000"}}`)
+		case "/eth/v1/config/spec":
+			fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"12"}}`)
+		case "/eth/v1/beacon/blob_sidecars/10":
+			fmt.Fprintf(w, `{"data":[{"index":"0","blob":"0x%s","kzg_commitment":"0x%s","kzg_proof":"%s"}]}`, hex.EncodeToString(blob), hex.EncodeToString(commitment), proof)
+		case "/eth/v1/beacon/blob_sidecars/11":
+			fmt.Fprintf(w, `{"data":[{"index":"0","blob":"0x%s","kzg_commitment":"0x%s","kzg_proof":"%s"}]}`, hex.EncodeToString(forged), hex.EncodeToString(commitment), proof)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"message":"not found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	rc := NewClient(srv.URL + "/")
+	slot, err := rc.SlotAt(context.Background(), 1125)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), slot)
+
+	blobs, err := rc.BlobsAt(context.Background(), 1120)
+	assert.Nil(t, err)
+	assert.Equal(t, blob, blobs[VersionedHash(commitment)])
+
+	// blobs not matching the commitments
+	_, err = rc.BlobsAt(context.Background(), 1132)
+	assert.NotNil(t, err)
+
+	// slots without sidecars
+	_, err = rc.BlobsAt(context.Background(), 1144)
+	assert.ErrorIs(t, err, ErrNoResult)
+	_, err = rc.SlotAt(context.Background(), 999)
+	assert.NotNil(t, err)
+
+	// sidecars retained for 4096 epochs
+	window := uint64(BlobRetentionEpochs * SlotsPerEpoch * 12)
+	retained, err := rc.Retained(context.Background(), 1120, 1120+window-12)
+	assert.Nil(t, err)
+	assert.True(t, retained)
+	retained, err = rc.Retained(context.Background(), 1120, 1120+window)
+	assert.Nil(t, err)
+	assert.False(t, retained)
+	_, err = rc.Retained(context.Background(), 999, 1120)
+	assert.NotNil(t, err)
+}
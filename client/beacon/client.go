@@ -0,0 +1,270 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/uxuycom/indexer/xylog"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSecondsPerSlot seconds per slot of ethereum networks, used if the spec has none
+const DefaultSecondsPerSlot = 12
+
+// SlotsPerEpoch slots per epoch of ethereum networks
+const SlotsPerEpoch = 32
+
+// BlobRetentionEpochs epochs blob sidecars retained by beacon nodes, MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS
+const BlobRetentionEpochs = 4096
+
+var ErrNoResult = errors.New("no result in beacon api response")
+
+// RawClient
+/***************************************
+ * client of beacon api compatible endpoints, fetching blob sidecars of execution blocks
+ * sidecars are pruned by beacon nodes after ~18 days, archival endpoints are required for history
+ ***************************************/
+type RawClient struct {
+	endpoint string
+	c        *http.Client
+
+	mu             sync.Mutex
+	genesisTime    uint64
+	secondsPerSlot uint64
+}
+
+// NewClient creates a client of the beacon api endpoint
+func NewClient(endpoint string) *RawClient {
+	return &RawClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		c:        &http.Client{Timeout: time.Second * 10},
+	}
+}
+
+func (rc *RawClient) doCallContext(ctx context.Context, retry int, result interface{}, path string) (err error) {
+	timeCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	t1 := time.Now()
+	defer func() {
+		msg := fmt.Sprintf("BEACON-CALL, path:%s, cost[%v]", path, time.Since(t1))
+		if retry > 0 {
+			msg += fmt.Sprintf(", retry[%d]", retry)
+		}
+
+		if err != nil {
+			msg += fmt.Sprintf(", err[%v]", err)
+		}
+		xylog.Logger.Debug(msg)
+	}()
+
+	req, err := http.NewRequestWithContext(timeCtx, http.MethodGet, rc.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := rc.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNoResult
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{Code: resp.StatusCode}
+		_ = json.Unmarshal(body, apiErr)
+		return apiErr
+	}
+
+	msg := &response{}
+	if err = json.Unmarshal(body, msg); err != nil {
+		return fmt.Errorf("response decode err:%v", err)
+	}
+
+	if len(msg.Data) <= 0 || string(msg.Data) == "null" {
+		return ErrNoResult
+	}
+	return json.Unmarshal(msg.Data, result)
+}
+
+func (rc *RawClient) CallContext(ctx context.Context, result interface{}, path string) (err error) {
+	retry := 3
+	for i := 0; i < retry; i++ {
+		//call
+		err = rc.doCallContext(ctx, i, result, path)
+		if err == nil {
+			return nil
+		}
+
+		if errors.Is(err, ErrNoResult) {
+			return ErrNoResult
+		}
+
+		select {
+		case <-time.After(time.Millisecond * 100):
+			//do nothing
+		case <-ctx.Done():
+			return errors.New("ctx done quit")
+		}
+	}
+	return err
+}
+
+// Genesis returns the genesis of the beacon chain
+func (rc *RawClient) Genesis(ctx context.Context) (*Genesis, error) {
+	var result Genesis
+	if err := rc.CallContext(ctx, &result, "/eth/v1/beacon/genesis"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SecondsPerSlot returns seconds per slot of the spec
+func (rc *RawClient) SecondsPerSlot(ctx context.Context) (uint64, error) {
+	var result map[string]interface{}
+	if err := rc.CallContext(ctx, &result, "/eth/v1/config/spec"); err != nil {
+		return 0, err
+	}
+
+	v, ok := result["SECONDS_PER_SLOT"].(string)
+	if !ok {
+		return DefaultSecondsPerSlot, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+// SlotAt
+/***************************************
+ * slot of the execution block by timestamp, genesis & spec loaded once
+ ***************************************/
+func (rc *RawClient) SlotAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.secondsPerSlot <= 0 {
+		genesis, err := rc.Genesis(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		genesisTime, err := genesis.Time()
+		if err != nil {
+			return 0, fmt.Errorf("genesis time[%s] invalid", genesis.GenesisTime)
+		}
+
+		secondsPerSlot, err := rc.SecondsPerSlot(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if secondsPerSlot <= 0 {
+			return 0, fmt.Errorf("seconds per slot[%d] invalid", secondsPerSlot)
+		}
+		rc.genesisTime, rc.secondsPerSlot = genesisTime, secondsPerSlot
+	}
+
+	if timestamp < rc.genesisTime {
+		return 0, fmt.Errorf("timestamp[%d] before genesis[%d]", timestamp, rc.genesisTime)
+	}
+	return (timestamp - rc.genesisTime) / rc.secondsPerSlot, nil
+}
+
+// Retained
+/***************************************
+ * whether sidecars of the execution block by timestamp are in the retention window at now
+ * sidecars out of the window may be pruned by beacon nodes, archival endpoints keep them
+ ***************************************/
+func (rc *RawClient) Retained(ctx context.Context, timestamp, now uint64) (bool, error) {
+	slot, err := rc.SlotAt(ctx, timestamp)
+	if err != nil {
+		return false, err
+	}
+
+	head, err := rc.SlotAt(ctx, now)
+	if err != nil {
+		return false, err
+	}
+	return head < slot+BlobRetentionEpochs*SlotsPerEpoch, nil
+}
+
+// BlobSidecars returns blob sidecars of the slot
+func (rc *RawClient) BlobSidecars(ctx context.Context, slot uint64) ([]*BlobSidecar, error) {
+	var result []*BlobSidecar
+	if err := rc.CallContext(ctx, &result, fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%d", slot)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlobsAt
+/***************************************
+ * blobs of the execution block by timestamp, versioned hash -> blob
+ * blobs are verified by the kzg commitments & proofs, sidecars failed are errors
+ ***************************************/
+func (rc *RawClient) BlobsAt(ctx context.Context, timestamp uint64) (map[string][]byte, error) {
+	slot, err := rc.SlotAt(ctx, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecars, err := rc.BlobSidecars(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make(map[string][]byte, len(sidecars))
+	for _, sc := range sidecars {
+		commitment, err := hex.DecodeString(strings.TrimPrefix(sc.KzgCommitment, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("slot[%d] blob[%s] commitment decode err:%v", slot, sc.Index, err)
+		}
+
+		blob, err := hex.DecodeString(strings.TrimPrefix(sc.Blob, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("slot[%d] blob[%s] decode err:%v", slot, sc.Index, err)
+		}
+
+		if err = VerifyBlob(blob, commitment, sc.KzgProof); err != nil {
+			return nil, fmt.Errorf("slot[%d] blob[%s] verified failed, err:%v", slot, sc.Index, err)
+		}
+		blobs[VersionedHash(commitment)] = blob
+	}
+	return blobs, nil
+}
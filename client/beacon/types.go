@@ -0,0 +1,62 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package beacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// response beacon api response envelope
+type response struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// apiError beacon api error response
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("beacon api err, code[%d], message[%s]", e.Code, e.Message)
+}
+
+// Genesis /eth/v1/beacon/genesis
+type Genesis struct {
+	GenesisTime string `json:"genesis_time"`
+}
+
+// Time genesis time in unix seconds
+func (g *Genesis) Time() (uint64, error) {
+	return strconv.ParseUint(g.GenesisTime, 10, 64)
+}
+
+// BlobSidecar /eth/v1/beacon/blob_sidecars/{block_id} item
+type BlobSidecar struct {
+	Index         string `json:"index"`
+	Blob          string `json:"blob"`
+	KzgCommitment string `json:"kzg_commitment"`
+	KzgProof      string `json:"kzg_proof"`
+}
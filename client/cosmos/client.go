@@ -0,0 +1,207 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/uxuycom/indexer/xylog"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxTxSearchPageSize max page size of tx_search
+const MaxTxSearchPageSize = 100
+
+var ErrNoResult = errors.New("no result in JSON-RPC response")
+
+// RawClient defines typed wrappers for the tendermint / cometbft RPC API.
+type RawClient struct {
+	endpoint string
+	c        *http.Client
+}
+
+// NewClient creates a client of the rpc endpoint, requests are sent by URI over HTTP.
+func NewClient(endpoint string) *RawClient {
+	return &RawClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		c:        &http.Client{Timeout: time.Second * 5},
+	}
+}
+
+func (rc *RawClient) doCallContext(ctx context.Context, retry int, result interface{}, method string, args url.Values) (err error) {
+	timeCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	t1 := time.Now()
+	defer func() {
+		msg := fmt.Sprintf("JSONRPC-CALL, method:%s, args[%v], cost[%v]", method, args.Encode(), time.Since(t1))
+		if retry > 0 {
+			msg += fmt.Sprintf(", retry[%d]", retry)
+		}
+
+		if err != nil {
+			msg += fmt.Sprintf(", err[%v]", err)
+		}
+		xylog.Logger.Debug(msg)
+	}()
+
+	req, err := http.NewRequestWithContext(timeCtx, http.MethodGet, fmt.Sprintf("%s/%s?%s", rc.endpoint, method, args.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rc.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	msg := &rpcResponse{}
+	if err = json.Unmarshal(body, msg); err != nil {
+		return fmt.Errorf("response decode err:%v, status[%d]", err, resp.StatusCode)
+	}
+
+	if msg.Error != nil {
+		return msg.Error
+	}
+
+	if len(msg.Result) <= 0 || string(msg.Result) == "null" {
+		return ErrNoResult
+	}
+	return json.Unmarshal(msg.Result, result)
+}
+
+func (rc *RawClient) CallContext(ctx context.Context, result interface{}, method string, args url.Values) (err error) {
+	retry := 10
+	for i := 0; i < retry; i++ {
+		//call
+		err = rc.doCallContext(ctx, i, result, method, args)
+		if err == nil {
+			return nil
+		}
+
+		if errors.Is(err, ErrNoResult) {
+			return ErrNoResult
+		}
+
+		// blocks & txs not produced / indexed yet
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) && (strings.Contains(rpcErr.Data, "must be less than or equal to the current blockchain height") || strings.Contains(rpcErr.Data, "not found")) {
+			return ErrNoResult
+		}
+
+		select {
+		case <-time.After(time.Millisecond * 100):
+			//do nothing
+		case <-ctx.Done():
+			return errors.New("ctx done quit")
+		}
+	}
+	return err
+}
+
+// BlockNumber returns the latest block height
+func (rc *RawClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var result StatusResult
+	if err := rc.CallContext(ctx, &result, "status", url.Values{}); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(result.SyncInfo.LatestBlockHeight, 10, 64)
+}
+
+// Block returns the block at the height, the latest block if nil
+func (rc *RawClient) Block(ctx context.Context, number *big.Int) (*BlockResult, error) {
+	args := url.Values{}
+	if number != nil {
+		args.Set("height", number.String())
+	}
+
+	var result BlockResult
+	if err := rc.CallContext(ctx, &result, "block", args); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TxSearch
+/***************************************
+ * search txs by events query, e.g. tx.height=1, page starts from 1
+ ***************************************/
+func (rc *RawClient) TxSearch(ctx context.Context, query string, page, perPage int) (*TxSearchResult, error) {
+	args := url.Values{}
+	args.Set("query", strconv.Quote(query))
+	args.Set("page", strconv.Itoa(page))
+	args.Set("per_page", strconv.Itoa(perPage))
+	args.Set("order_by", strconv.Quote("asc"))
+
+	var result TxSearchResult
+	if err := rc.CallContext(ctx, &result, "tx_search", args); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BlockTxs returns all txs of the block height in order
+func (rc *RawClient) BlockTxs(ctx context.Context, number *big.Int) ([]*TxResponse, error) {
+	items := make([]*TxResponse, 0, MaxTxSearchPageSize)
+	for page := 1; ; page++ {
+		result, err := rc.TxSearch(ctx, fmt.Sprintf("tx.height=%s", number.String()), page, MaxTxSearchPageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Txs...)
+
+		total, err := strconv.Atoi(result.TotalCount)
+		if err != nil {
+			return nil, fmt.Errorf("tx_search total count[%s] invalid", result.TotalCount)
+		}
+		if len(items) >= total || len(result.Txs) <= 0 {
+			return items, nil
+		}
+	}
+}
+
+// Tx returns the tx by hash
+func (rc *RawClient) Tx(ctx context.Context, hash string) (*TxResponse, error) {
+	args := url.Values{}
+	args.Set("hash", "0x"+strings.TrimPrefix(hash, "0x"))
+
+	var result TxResponse
+	if err := rc.CallContext(ctx, &result, "tx", args); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cosmos
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// MsgSendTypeURL bank send message, inscriptions are sent to self or receivers with memos
+	MsgSendTypeURL = "/cosmos.bank.v1beta1.MsgSend"
+
+	// blobTxTypeID celestia pay-for-blob txs are wrapped with blobs
+	blobTxTypeID = "BLOB"
+)
+
+var errProtoInvalid = errors.New("protobuf data invalid")
+
+type Tx struct {
+	Memo string
+	Msgs []*Msg
+}
+
+type Msg struct {
+	TypeURL string
+	Value   []byte
+}
+
+type Coin struct {
+	Denom  string
+	Amount *big.Int
+}
+
+type MsgSend struct {
+	From   string
+	To     string
+	Amount []*Coin
+}
+
+// DecodeTx
+/***************************************
+ * decode memo & messages of protobuf raw txs, only fields indexing needs are decoded
+ * TxRaw{body_bytes:1}, TxBody{messages:1, memo:2}, Any{type_url:1, value:2}
+ ***************************************/
+func DecodeTx(raw []byte) (*Tx, error) {
+	fields, err := protoFields(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// BlobTx{tx:1, blobs:2, type_id:3}
+	if typeID := fields.first(3); string(typeID) == blobTxTypeID {
+		if fields, err = protoFields(fields.first(1)); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := protoFields(fields.first(1))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{
+		Memo: string(body.first(2)),
+		Msgs: make([]*Msg, 0, len(body[1])),
+	}
+	for _, item := range body[1] {
+		msg, err := protoFields(item)
+		if err != nil {
+			return nil, err
+		}
+		tx.Msgs = append(tx.Msgs, &Msg{TypeURL: string(msg.first(1)), Value: msg.first(2)})
+	}
+	return tx, nil
+}
+
+// DecodeMsgSend MsgSend{from_address:1, to_address:2, amount:3}, Coin{denom:1, amount:2}
+func DecodeMsgSend(msg *Msg) (*MsgSend, error) {
+	if msg.TypeURL != MsgSendTypeURL {
+		return nil, fmt.Errorf("msg type[%s] is not %s", msg.TypeURL, MsgSendTypeURL)
+	}
+
+	fields, err := protoFields(msg.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	send := &MsgSend{
+		From:   string(fields.first(1)),
+		To:     string(fields.first(2)),
+		Amount: make([]*Coin, 0, len(fields[3])),
+	}
+	for _, item := range fields[3] {
+		coin, err := protoFields(item)
+		if err != nil {
+			return nil, err
+		}
+
+		amount, ok := new(big.Int).SetString(string(coin.first(2)), 10)
+		if !ok {
+			return nil, fmt.Errorf("coin amount[%s] invalid", coin.first(2))
+		}
+		send.Amount = append(send.Amount, &Coin{Denom: string(coin.first(1)), Amount: amount})
+	}
+	return send, nil
+}
+
+// fields length delimited fields of a protobuf message, field number -> values
+type fields map[uint64][][]byte
+
+func (f fields) first(num uint64) []byte {
+	if len(f[num]) <= 0 {
+		return nil
+	}
+	return f[num][0]
+}
+
+// protoFields
+/***************************************
+ * walk protobuf wire format, numeric fields are skipped
+ ***************************************/
+func protoFields(data []byte) (fields, error) {
+	items := make(fields)
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errProtoInvalid
+		}
+		data = data[n:]
+
+		num, wireType := key>>3, key&0x07
+		switch wireType {
+		case 0: // varint
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return nil, errProtoInvalid
+			}
+			data = data[n:]
+		case 1: // fixed64
+			if len(data) < 8 {
+				return nil, errProtoInvalid
+			}
+			data = data[8:]
+		case 2: // length delimited
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return nil, errProtoInvalid
+			}
+			items[num] = append(items[num], data[n:n+int(size)])
+			data = data[n+int(size):]
+		case 5: // fixed32
+			if len(data) < 4 {
+				return nil, errProtoInvalid
+			}
+			data = data[4:]
+		default:
+			return nil, fmt.Errorf("protobuf wire type[%d] unsupported", wireType)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cosmos
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"testing"
+)
+
+// field length delimited protobuf field
+func field(num uint64, value []byte) []byte {
+	data := binary.AppendUvarint(nil, num<<3|2)
+	data = binary.AppendUvarint(data, uint64(len(value)))
+	return append(data, value...)
+}
+
+func join(items ...[]byte) []byte {
+	data := make([]byte, 0)
+	for _, item := range items {
+		data = append(data, item...)
+	}
+	return data
+}
+
+func rawSendTx(from, to, memo string) []byte {
+	coin := join(field(1, []byte("utia")), field(2, []byte("1000")))
+	send := join(field(1, []byte(from)), field(2, []byte(to)), field(3, coin))
+	msg := join(field(1, []byte(MsgSendTypeURL)), field(2, send))
+
+	// timeout height varint field of body
+	body := join(field(1, msg), field(2, []byte(memo)), []byte{0x18, 0x96, 0x01})
+	return join(field(1, body), field(2, []byte{0x0a, 0x00}), field(3, []byte("sig")))
+}
+
+func TestDecodeTx(t *testing.T) {
+	memo := `data:,{"p":"cia-20","op":"mint","tick":"cias","amt":"1000"}`
+	raw := rawSendTx("celestia1from", "celestia1to", memo)
+
+	tx, err := DecodeTx(raw)
+	assert.Nil(t, err)
+	assert.Equal(t, memo, tx.Memo)
+	assert.Len(t, tx.Msgs, 1)
+
+	send, err := DecodeMsgSend(tx.Msgs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, "celestia1from", send.From)
+	assert.Equal(t, "celestia1to", send.To)
+	assert.Equal(t, "1000", send.Amount[0].Amount.String())
+
+	// celestia blob txs
+	tx, err = DecodeTx(join(field(1, raw), field(2, []byte("blob")), field(3, []byte(blobTxTypeID))))
+	assert.Nil(t, err)
+	assert.Equal(t, memo, tx.Memo)
+
+	_, err = DecodeTx(raw[:len(raw)-1])
+	assert.NotNil(t, err)
+}
+
+func TestConvertTx(t *testing.T) {
+	r := &TxResponse{
+		Hash:   "0A1B2C3D4E5F60718293A4B5C6D7E8F90A1B2C3D4E5F60718293A4B5C6D7E8F9",
+		Height: "100",
+		Index:  2,
+		Tx:     rawSendTx("celestia1from", "celestia1to", "data:,"),
+		TxResult: ExecTxResult{
+			Code:    0,
+			GasUsed: "65000",
+			Events: []Event{{Type: "message", Attributes: []EventAttribute{
+				{Key: base64.StdEncoding.EncodeToString([]byte("sender")), Value: base64.StdEncoding.EncodeToString([]byte("celestia1signer"))},
+			}}},
+		},
+	}
+
+	tx, receipt, err := convertTx(&xycommon.RpcBlock{Hash: "0xblock"}, r)
+	assert.Nil(t, err)
+	assert.Equal(t, "0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9", tx.Hash)
+	assert.Equal(t, "celestia1from", tx.From)
+	assert.Equal(t, "celestia1to", tx.To)
+	assert.Equal(t, "1000", tx.Value.String())
+	assert.Equal(t, "data:,", tx.Memo)
+	assert.Equal(t, int64(1), receipt.Status.Int64())
+	assert.Equal(t, int64(65000), receipt.GasUsed.Int64())
+
+	// signer by events of non send txs, attributes base64 encoded before v0.37
+	sender, ok := r.TxResult.Attribute("message", "sender")
+	assert.True(t, ok)
+	assert.Equal(t, "celestia1signer", sender)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cosmos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// rpcResponse tendermint / cometbft json-rpc response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error code[%d], msg[%s], data[%s]", e.Code, e.Message, e.Data)
+}
+
+type StatusResult struct {
+	SyncInfo struct {
+		LatestBlockHeight string `json:"latest_block_height"`
+	} `json:"sync_info"`
+}
+
+type BlockID struct {
+	Hash string `json:"hash"`
+}
+
+type Header struct {
+	ChainID         string    `json:"chain_id"`
+	Height          string    `json:"height"`
+	Time            time.Time `json:"time"`
+	LastBlockID     BlockID   `json:"last_block_id"`
+	DataHash        string    `json:"data_hash"`
+	ProposerAddress string    `json:"proposer_address"`
+}
+
+type BlockResult struct {
+	BlockID BlockID `json:"block_id"`
+	Block   struct {
+		Header Header `json:"header"`
+		Data   struct {
+			Txs [][]byte `json:"txs"` // base64 encoded raw txs
+		} `json:"data"`
+	} `json:"block"`
+}
+
+type TxSearchResult struct {
+	Txs        []*TxResponse `json:"txs"`
+	TotalCount string        `json:"total_count"`
+}
+
+type TxResponse struct {
+	Hash     string       `json:"hash"`
+	Height   string       `json:"height"`
+	Index    uint32       `json:"index"`
+	TxResult ExecTxResult `json:"tx_result"`
+	Tx       []byte       `json:"tx"` // base64 encoded raw tx
+}
+
+type ExecTxResult struct {
+	Code      uint32  `json:"code"`
+	Log       string  `json:"log"`
+	GasWanted string  `json:"gas_wanted"`
+	GasUsed   string  `json:"gas_used"`
+	Events    []Event `json:"events"`
+}
+
+type Event struct {
+	Type       string           `json:"type"`
+	Attributes []EventAttribute `json:"attributes"`
+}
+
+type EventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Attribute
+/***************************************
+ * value of the first event attribute by event type & key,
+ * attributes are base64 encoded before tendermint v0.37
+ ***************************************/
+func (r *ExecTxResult) Attribute(eventType, key string) (string, bool) {
+	encodedKey := base64.StdEncoding.EncodeToString([]byte(key))
+	for _, event := range r.Events {
+		if event.Type != eventType {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case key:
+				return attr.Value, true
+			case encodedKey:
+				value, err := base64.StdEncoding.DecodeString(attr.Value)
+				if err != nil {
+					return "", false
+				}
+				return string(value), true
+			}
+		}
+	}
+	return "", false
+}
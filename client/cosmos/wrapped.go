@@ -0,0 +1,240 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CClient adapts cosmos-sdk chains to the indexer rpc client, tx memos are the inscription data
+type CClient struct {
+	rawClient *RawClient
+}
+
+// Dial creates a client of the tendermint / cometbft rpc endpoint.
+func Dial(rawurl string) (*CClient, error) {
+	if !strings.HasPrefix(rawurl, "http://") && !strings.HasPrefix(rawurl, "https://") {
+		return nil, fmt.Errorf("rpc url[%s] must be http(s) endpoint", rawurl)
+	}
+	return &CClient{rawClient: NewClient(rawurl)}, nil
+}
+
+// BlockNumber returns the most recent block number
+func (cc *CClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return cc.rawClient.BlockNumber(ctx)
+}
+
+func (cc *CClient) HeaderByNumber(ctx context.Context, number *big.Int) (*xycommon.RpcHeader, error) {
+	block, err := cc.rawClient.Block(ctx, number)
+	if err != nil {
+		if errors.Is(err, ErrNoResult) {
+			return nil, xycommon.ErrNotFound
+		}
+		return nil, err
+	}
+	return convertHeader(&block.Block.Header)
+}
+
+// BlockByNumber
+/***************************************
+ * block header by block endpoint, txs with results by tx_search of the height
+ * receipts of txs are returned along with the block
+ ***************************************/
+func (cc *CClient) BlockByNumber(ctx context.Context, number *big.Int) (*xycommon.RpcBlock, error) {
+	block, err := cc.rawClient.Block(ctx, number)
+	if err != nil {
+		if errors.Is(err, ErrNoResult) {
+			return nil, xycommon.ErrNotFound
+		}
+		return nil, err
+	}
+
+	header, err := convertHeader(&block.Block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*TxResponse, 0)
+	if len(block.Block.Data.Txs) > 0 {
+		txs, err = cc.rawClient.BlockTxs(ctx, header.Number)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// txs of the block must be all indexed by the node
+	if len(txs) != len(block.Block.Data.Txs) {
+		return nil, fmt.Errorf("block[%s] txs[%d] <> indexed txs[%d]", header.Number, len(block.Block.Data.Txs), len(txs))
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Index < txs[j].Index
+	})
+
+	cBlock := &xycommon.RpcBlock{
+		ParentHash:   header.ParentHash,
+		Coinbase:     block.Block.Header.ProposerAddress,
+		Number:       header.Number,
+		GasLimit:     big.NewInt(0),
+		GasUsed:      big.NewInt(0),
+		Time:         header.Time,
+		TxHash:       header.TxHash,
+		Hash:         strings.ToLower(block.BlockID.Hash),
+		Transactions: make([]*xycommon.RpcTransaction, 0, len(txs)),
+	}
+	for _, item := range txs {
+		tx, receipt, err := convertTx(cBlock, item)
+		if err != nil {
+			return nil, err
+		}
+		tx.Receipt = []xycommon.RpcReceipt{*receipt}
+		cBlock.Transactions = append(cBlock.Transactions, tx)
+	}
+	return cBlock, nil
+}
+
+// TransactionSender returns the signer of the tx
+func (cc *CClient) TransactionSender(ctx context.Context, txHash, blockHash string, txIndex uint) (string, error) {
+	r, err := cc.rawClient.Tx(ctx, txHash)
+	if err != nil {
+		return "", err
+	}
+
+	tx, _, err := convertTx(&xycommon.RpcBlock{Hash: blockHash}, r)
+	if err != nil {
+		return "", err
+	}
+	return tx.From, nil
+}
+
+// TransactionReceipt returns the receipt of the tx queried by hash
+func (cc *CClient) TransactionReceipt(ctx context.Context, txHash string) (*xycommon.RpcReceipt, error) {
+	r, err := cc.rawClient.Tx(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, ErrNoResult) {
+			return nil, ethereum.NotFound
+		}
+		return nil, err
+	}
+
+	_, receipt, err := convertTx(&xycommon.RpcBlock{}, r)
+	return receipt, err
+}
+
+// FilterLogs cosmos chains have no evm logs
+func (cc *CClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]xycommon.RpcLog, error) {
+	return nil, nil
+}
+
+// IsContract memo inscriptions are sent to accounts
+func (cc *CClient) IsContract(ctx context.Context, address string, number *big.Int) (bool, error) {
+	return false, nil
+}
+
+func convertHeader(head *Header) (*xycommon.RpcHeader, error) {
+	number, ok := new(big.Int).SetString(head.Height, 10)
+	if !ok {
+		return nil, fmt.Errorf("block height[%s] invalid", head.Height)
+	}
+
+	return &xycommon.RpcHeader{
+		ParentHash: strings.ToLower(head.LastBlockID.Hash),
+		Number:     number,
+		Time:       uint64(head.Time.Unix()),
+		TxHash:     strings.ToLower(head.DataHash),
+	}, nil
+}
+
+// convertTx
+/***************************************
+ * sender & receiver by bank send message, the signer of message events if no send messages
+ * gas price is 0, fees of cosmos txs are paid in coins
+ ***************************************/
+func convertTx(block *xycommon.RpcBlock, r *TxResponse) (*xycommon.RpcTransaction, *xycommon.RpcReceipt, error) {
+	number, ok := new(big.Int).SetString(r.Height, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("tx[%s] height[%s] invalid", r.Hash, r.Height)
+	}
+
+	gasUsed, _ := strconv.ParseInt(r.TxResult.GasUsed, 10, 64)
+	status := int64(0)
+	if r.TxResult.Code == 0 {
+		status = 1
+	}
+
+	tx := &xycommon.RpcTransaction{
+		BlockHash:   block.Hash,
+		BlockNumber: number,
+		TxIndex:     big.NewInt(int64(r.Index)),
+		Hash:        strings.ToLower(r.Hash),
+		Value:       big.NewInt(0),
+		Gas:         big.NewInt(gasUsed),
+		GasPrice:    big.NewInt(0),
+		Status:      status,
+	}
+
+	decoded, err := DecodeTx(r.Tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tx[%s] decode err:%v", r.Hash, err)
+	}
+	tx.Memo = decoded.Memo
+
+	for _, msg := range decoded.Msgs {
+		if msg.TypeURL != MsgSendTypeURL {
+			continue
+		}
+
+		send, err := DecodeMsgSend(msg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tx[%s] msg send decode err:%v", r.Hash, err)
+		}
+		tx.From, tx.To = send.From, send.To
+		if len(send.Amount) == 1 {
+			tx.Value = send.Amount[0].Amount
+		}
+		break
+	}
+
+	if tx.From == "" {
+		tx.From, _ = r.TxResult.Attribute("message", "sender")
+	}
+
+	receipt := &xycommon.RpcReceipt{
+		Status:            big.NewInt(status),
+		CumulativeGasUsed: big.NewInt(gasUsed),
+		TxHash:            common.HexToHash(r.Hash),
+		GasUsed:           big.NewInt(gasUsed),
+		EffectiveGasPrice: big.NewInt(0),
+		BlockNumber:       number,
+		TransactionIndex:  big.NewInt(int64(r.Index)),
+	}
+	return tx, receipt, nil
+}
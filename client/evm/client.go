@@ -256,9 +256,10 @@ func (ec *RawClient) TransactionByHash(ctx context.Context, hash common.Hash) (t
 	return tx, tx.BlockNumber == nil, nil
 }
 
-func (ec *RawClient) IsContract(ctx context.Context, address common.Address) (ok bool, err error) {
+// IsContract code of the address as of the block, latest if number is nil
+func (ec *RawClient) IsContract(ctx context.Context, address common.Address, number *big.Int) (ok bool, err error) {
 	var result hexutil.Bytes
-	err = ec.CallContext(ctx, &result, "eth_getCode", address, "latest")
+	err = ec.CallContext(ctx, &result, "eth_getCode", address, toBlockNumArg(number))
 	if err == nil && result == nil {
 		return false, nil
 	}
@@ -72,6 +72,9 @@ type RpcTransaction struct {
 	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
 	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
 	MaxFeePerDataGas     *hexutil.Big   `json:"maxFeePerDataGas,omitempty"`
+
+	// BlobVersionedHashes blobs of type-3 txs, EIP-4844
+	BlobVersionedHashes []common.Hash `json:"blobVersionedHashes,omitempty"`
 }
 
 type RpcLog struct {
@@ -137,6 +137,11 @@ func (ec *EClient) convertTransaction(tx *RpcTransaction) *xycommon.RpcTransacti
 	if tx.To != nil {
 		toAddr = tx.To.String()
 	}
+
+	blobHashes := make([]string, 0, len(tx.BlobVersionedHashes))
+	for _, h := range tx.BlobVersionedHashes {
+		blobHashes = append(blobHashes, h.String())
+	}
 	return &xycommon.RpcTransaction{
 		BlockHash:   tx.BlockHash.String(),
 		BlockNumber: tx.BlockNumber.ToInt(),
@@ -150,6 +155,8 @@ func (ec *EClient) convertTransaction(tx *RpcTransaction) *xycommon.RpcTransacti
 		Value:       tx.Value.ToInt(),
 		Gas:         big.NewInt(0).SetUint64(uint64(tx.Gas)),
 		GasPrice:    tx.GasPrice.ToInt(),
+
+		BlobVersionedHashes: blobHashes,
 	}
 }
 
@@ -265,3 +272,8 @@ func (ec *EClient) TransactionReceipt(ctx context.Context, txHashStr string) (*x
 	}
 	return ec.convertReceipt(r), nil
 }
+
+// IsContract reports whether the address has contract code as of the block
+func (ec *EClient) IsContract(ctx context.Context, address string, number *big.Int) (bool, error) {
+	return ec.rawClient.IsContract(ctx, common.HexToAddress(address), number)
+}
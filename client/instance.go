@@ -23,11 +23,16 @@
 package client
 
 import (
+	"github.com/uxuycom/indexer/client/cosmos"
 	"github.com/uxuycom/indexer/client/evm"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/model"
 )
 
 func NewRPCClient(rpc string, proto model.ChainGroup) (xycommon.IRPCClient, error) {
+	switch proto {
+	case model.CosmosChainGroup:
+		return cosmos.Dial(rpc)
+	}
 	return evm.Dial(rpc)
 }
@@ -47,6 +47,9 @@ type IRPCClient interface {
 	TransactionReceipt(ctx context.Context, txHash string) (*RpcReceipt, error)
 
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]RpcLog, error)
+
+	// IsContract address code checking as of the block
+	IsContract(ctx context.Context, address string, number *big.Int) (bool, error)
 }
 
 type RpcHeader struct {
@@ -84,9 +87,23 @@ type RpcTransaction struct {
 	GasPrice    *big.Int       `json:"gasPrice"`
 	Vin         []btcjson.Vin  `json:"vin"`
 	Vout        []btcjson.Vout `json:"vout"`
+	Prevouts    []*RpcPrevout  `json:"prevouts,omitempty"` // outputs spent by the inputs in order, prevout of getblock verbosity 3
 	Events      []RpcLog       `json:"events"`
 	Receipt     []RpcReceipt   `json:"receipt"`
 	Status      int64          `json:"status"`
+	ToContract  bool           `json:"-"`              // tx to address is a contract, filled by indexer
+	Memo        string         `json:"memo,omitempty"` // tx memo of cosmos chains
+	Wrapper     string         `json:"-"`              // smart account wrapper the inner call unwrapped from, filled by indexer
+
+	// BlobVersionedHashes blobs of type-3 txs, content of blob inscriptions
+	BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
+}
+
+// RpcPrevout output spent by an input of btc txs
+type RpcPrevout struct {
+	Height       uint64                     `json:"height"` // block height of the output created
+	Value        float64                    `json:"value"`
+	ScriptPubKey btcjson.ScriptPubKeyResult `json:"scriptPubKey"`
 }
 
 type RpcLog struct {
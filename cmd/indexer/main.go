@@ -88,7 +88,7 @@ func main() {
 	dCache := dcache.NewManager(dbClient, cfg.Chain.ChainName)
 
 	// init protocols
-	protocol.InitProtocols(dCache)
+	protocol.InitProtocols(&cfg, dCache)
 
 	// Listen for SIGINT and SIGTERM signals
 	quit := make(chan os.Signal, 1)
@@ -26,7 +26,9 @@ import (
 	"flag"
 	"github.com/sirupsen/logrus"
 	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/jsonrpc"
+	"github.com/uxuycom/indexer/protocol"
 	"github.com/uxuycom/indexer/storage"
 	"github.com/uxuycom/indexer/xylog"
 	"log"
@@ -56,6 +58,9 @@ func main() {
 		log.Fatalf("initialize db client err:%v", err)
 		return
 	}
+	// rule profiles of protocols, ticks of queries normalized by them
+	protocol.InitProtocols(&config.Config{Chain: cfg.Chain, RuleProfiles: cfg.RuleProfiles}, dcache.NewManager(nil, cfg.Chain.ChainName))
+
 	//init server
 	server, err := jsonrpc.NewRPCServer(dbc, cfg.CacheStore)
 	if err != nil {
@@ -43,6 +43,114 @@ type ChainConfig struct {
 	UserName   string           `json:"username"`
 	PassWord   string           `json:"password"`
 	ChainGroup model.ChainGroup `json:"chain_group"`
+	Protocols  []string         `json:"protocols"` // enabled protocols, empty: all registered except opt-in ones
+
+	// Rules protocol -> rule profile name, protocol's built-in profile by default
+	Rules map[string]string `json:"rules"`
+
+	// Forks protocol -> rule profiles activated at block heights, replaces the rules above from the height
+	Forks map[string][]*RuleForkConfig `json:"forks"`
+
+	// Marketplaces marketplace contracts whose filled order events are indexed as exchanges
+	Marketplaces []*MarketplaceConfig `json:"marketplaces"`
+
+	// Bridges erc-20 wrapper contracts of ticks, wrapped balances indexed by the wrapper events
+	Bridges []*BridgeConfig `json:"bridges"`
+
+	// EntryPoints EntryPoint contracts whose user operation bundles are unwrapped, v0.6 & v0.7 ones if empty
+	EntryPoints []string `json:"entry_points"`
+
+	// BeaconEndpoint beacon api endpoint of blob sidecars, blob inscriptions not indexed if empty
+	BeaconEndpoint string `json:"beacon_endpoint"`
+}
+
+// RuleForkConfig rule profile activated from the block height
+type RuleForkConfig struct {
+	Height  uint64 `json:"height"`
+	Profile string `json:"profile"` // rule profile name, built-in or custom
+	Version string `json:"version"` // rule version recorded with indexed txs, profile name if empty
+}
+
+// MarketplaceConfig marketplace adapter, maps fields of a filled order event to an exchange
+type MarketplaceConfig struct {
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"` // protocol of the traded ticks
+	Contract string `json:"contract"`
+	AbiFile  string `json:"abi_file"`
+	Event    string `json:"event"`   // filled order event name in abi
+	Operate  string `json:"operate"` // exchange (default) | delist, orders of the event cancel listings
+
+	// Escrow listed tokens are held by the contract, debited from the contract instead of seller
+	Escrow bool `json:"escrow"`
+
+	// AmountDecimals decimals of the amount field, 0: token amount
+	AmountDecimals int32 `json:"amount_decimals"`
+
+	Fields MarketplaceFields `json:"fields"`
+}
+
+// MarketplaceFields event field names, one of tick & tick_hash is required without list_id
+type MarketplaceFields struct {
+	Seller   string `json:"seller"`
+	Buyer    string `json:"buyer"` // not used by delist events, listed tokens return to seller
+	Tick     string `json:"tick"`
+	TickHash string `json:"tick_hash"` // keccak256 of lower case tick
+	Amount   string `json:"amount"`
+	Price    string `json:"price"` // optional, total price of the order in native coin wei
+
+	// ListId optional, bytes32 listing id, orders of listed tokens are settled by listing records,
+	// seller, tick & amount are optional then & taken from the listing
+	ListId string `json:"list_id"`
+}
+
+// BridgeConfig erc-20 wrapper of a tick, inscriptions held by the bridge address are reported as wrapped supply
+type BridgeConfig struct {
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"`
+	Tick     string `json:"tick"`
+	Bridge   string `json:"bridge"`   // address receiving the wrapped inscriptions, the contract if empty
+	Contract string `json:"contract"` // erc-20 wrapper contract, Transfer events move wrapped balances
+
+	// AbiFile wrapper contract abi of the mint & burn events, required if the events are set
+	AbiFile string `json:"abi_file"`
+
+	// Mint / Burn wrap & unwrap events, Transfer from / to the zero address if nil
+	Mint *BridgeEventConfig `json:"mint"`
+	Burn *BridgeEventConfig `json:"burn"`
+
+	// AmountDecimals decimals of the wrapped token amount, 0: token amount
+	AmountDecimals int32 `json:"amount_decimals"`
+}
+
+// BridgeEventConfig wrap / unwrap event & its field names
+type BridgeEventConfig struct {
+	Event   string `json:"event"`
+	Account string `json:"account"` // receiver of mint events, holder of burn events
+	Amount  string `json:"amount"`
+}
+
+// ProtocolRules declarative rule profile of brc-20 like protocols
+type ProtocolRules struct {
+	TickMinLength    int    `json:"tick_min_length"`  // 0: unlimited
+	TickMaxLength    int    `json:"tick_max_length"`  // 0: unlimited
+	TickLengthUnit   string `json:"tick_length_unit"` // rune (default) | byte
+	Confusables      string `json:"confusables"`      // confusable deploys: "" no checking | reject | flag
+	MaxDecimals      int64  `json:"max_decimals"`
+	SelfMint         bool   `json:"self_mint"`         // mint must be self inscription, from == to, except paid mints sent to the fee recipient
+	ContractCalldata bool   `json:"contract_calldata"` // calldata sent to contracts counts
+	CaseSensitive    bool   `json:"case_sensitive"`
+	MaxDataSize      int    `json:"max_data_size"` // max decoded data uri payload size, 0: unlimited
+	PowHash          string `json:"pow_hash"`      // proof of work mints, hash meeting deploy workc: "" disabled | tx | calldata
+
+	// Strict spec-compliance parsing, string amounts of plain decimal grammar, amt precision <= tick decimals
+	// & duplicate json keys rejected. lenient decimal unmarshalling if false
+	Strict bool `json:"strict"`
+
+	// Burn burn operation enabled, brc-20 has no burn operation & burns are opt-in per profile
+	Burn bool `json:"burn"`
+
+	// BurnAddresses transfers to these addresses are burned instead of credited, e.g. 0x...dEaD
+	BurnAddresses []string `json:"burn_addresses"`
 }
 
 type IndexFilter struct {
@@ -73,6 +181,9 @@ type Config struct {
 	Filters  *IndexFilter   `json:"filters"`
 	Database DatabaseConfig `json:"database"`
 	Profile  *ProfileConfig `json:"profile"`
+
+	// RuleProfiles custom rule profiles, override built-in profiles with the same name
+	RuleProfiles map[string]*ProtocolRules `json:"rule_profiles"`
 }
 
 type JsonRcpConfig struct {
@@ -83,6 +194,10 @@ type JsonRcpConfig struct {
 	Database      DatabaseConfig `json:"database"`
 	Profile       *ProfileConfig `json:"profile"`
 	CacheStore    *CacheConfig   `json:"cache_store"`
+
+	// Chain & RuleProfiles rule profiles of the indexed chain, query ticks normalized as the indexer does
+	Chain        ChainConfig               `json:"chain"`
+	RuleProfiles map[string]*ProtocolRules `json:"rule_profiles"`
 }
 
 type CacheConfig struct {
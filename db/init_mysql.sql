@@ -11,7 +11,7 @@ CREATE TABLE `inscriptions`
     `chain`          varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL, -- chain code, eth / avax / btc / doge
     `protocol`       varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL, -- protocol code, POLS, ETHS, BRC20
     `tick`           varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL, -- ticker code
-    `name`           varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL, -- ticker name
+    `name`           varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL, -- ticker name
     `limit_per_mint` DECIMAL(38, 18)                                               NOT NULL, -- mint amount limit by per mint
     `deploy_by`      varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL, -- deployed address
     `total_supply`   DECIMAL(38, 18)                                               NOT NULL, -- total supply
@@ -19,6 +19,17 @@ CREATE TABLE `inscriptions`
     `deploy_hash`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL, -- deployed tx hash
     `deploy_time`    timestamp                                                     NOT NULL, -- deployed time
     `transfer_type`  tinyint(1)                                                    NOT NULL, -- transfer type
+    `wallet_limit`   DECIMAL(38, 18)                                               NOT NULL DEFAULT '0', -- mint amount limit per address
+    `start_block`    bigint unsigned                                               NOT NULL DEFAULT '0', -- mint start block
+    `end_block`      bigint unsigned                                               NOT NULL DEFAULT '0', -- mint end block
+    `block_mint_limit` bigint unsigned                                             NOT NULL DEFAULT '0', -- maximum mints per block
+    `merkle_root`    varchar(66)                                                   NOT NULL DEFAULT '', -- allowlist merkle root
+    `confusable_with` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin  NOT NULL DEFAULT '', -- flagged, deployed tick visually confusable with
+    `premine`        DECIMAL(38, 18)                                               NOT NULL DEFAULT '0', -- supply allocated to the deployer
+    `mint_price`     DECIMAL(38, 18)                                               NOT NULL DEFAULT '0', -- native coin price per token of paid mints
+    `fee_to`         varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '', -- mint fee recipient
+    `tick_id`        varchar(32)                                                   NOT NULL DEFAULT '', -- protocol tick id, runes: etching block:tx
+    `workc`          varchar(66)                                                   NOT NULL DEFAULT '', -- proof of work difficulty, hex prefix of mint hashes
     `created_at`     timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at`     timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
@@ -37,10 +48,13 @@ CREATE TABLE `inscriptions_stats`
     `protocol`            varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin   NOT NULL,             -- protocol code, POLS, ETHS, BRC20
     `tick`                varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin   NOT NULL,             -- ticker code
     `minted`              DECIMAL(38, 18) unsigned                                     NOT NULL DEFAULT '0', -- minted amount
+    `burned`              DECIMAL(38, 18) unsigned                                     NOT NULL DEFAULT '0', -- burned amount
+    `wrapped`             DECIMAL(38, 18) unsigned                                     NOT NULL DEFAULT '0', -- wrapped supply held by bridges
     `mint_completed_time` timestamp                                                    NULL,                 -- mint completed time
     `mint_first_block`    bigint unsigned                                              NOT NULL,             -- mint start block
     `mint_last_block`     bigint unsigned                                              NOT NULL,             -- mint completed block
     `last_sn`             int unsigned                                                 NOT NULL,             -- last sn
+    `fees`                DECIMAL(38, 18) unsigned                                     NOT NULL DEFAULT '0', -- native coin paid of paid mints
     `holders`             int unsigned                                                 NOT NULL,             -- total holders
     `tx_cnt`              bigint unsigned                                              NOT NULL,             -- total txs
     `created_at`          timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -70,6 +84,7 @@ CREATE TABLE `txs`
     `gas`               bigint          NOT NULL COMMENT 'gas, spend fee',
     `gas_price`         bigint          NOT NULL COMMENT 'gas price',
     `status`            tinyint(1)      NOT NULL COMMENT 'tx status',
+    `rule_version`      varchar(64)     NOT NULL DEFAULT '' COMMENT 'active protocol rule version',
     `created_at`        timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at`        timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
@@ -89,6 +104,7 @@ CREATE TABLE `balances`
     `tick`       varchar(32) COLLATE utf8mb4_0900_bin    NOT NULL COMMENT 'inscription code',
     `available`  DECIMAL(38, 18)                         NOT NULL COMMENT 'available',
     `balance`    DECIMAL(38, 18)                         NOT NULL COMMENT 'balance',
+    `parse_mode` varchar(16) COLLATE utf8mb4_general_ci  NOT NULL DEFAULT 'lenient' COMMENT 'parse mode of the last balance change',
     `created_at` timestamp                               NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at` timestamp                               NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
@@ -132,6 +148,7 @@ CREATE TABLE `balance_txn`
     `available`  DECIMAL(38, 18)                                               NOT NULL COMMENT 'available',
     `balance`    DECIMAL(38, 18)                                               NOT NULL,
     `tx_hash`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `parse_mode` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL DEFAULT 'lenient', -- lenient / strict
     `created_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
@@ -140,6 +157,216 @@ CREATE TABLE `balance_txn`
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_general_ci;
 
+-- address minted amount of capped ticks ---------
+CREATE TABLE `address_mints`
+(
+    `id`         bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `sid`        bigint unsigned                                               NOT NULL COMMENT 'sid',
+    `chain`      varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `protocol`   varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `address`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `tick`       varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `minted`     DECIMAL(38, 18)                                               NOT NULL COMMENT 'minted amount',
+    `mint_cnt`   bigint unsigned                                               NOT NULL COMMENT 'mint times',
+    `claimed`    DECIMAL(38, 18)                                               NOT NULL DEFAULT '0' COMMENT 'allowlist claimed amount',
+    `created_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_address` (`address`, `chain`, `protocol`, `tick`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- proof of work mint nonces ------------------------------
+CREATE TABLE `mint_nonces`
+(
+    `id`         bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `chain`      varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `protocol`   varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `tick`       varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `address`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `nonce`      varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL COMMENT 'proof of work nonce',
+    `tx_hash`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `created_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_nonce` (`chain`, `protocol`, `tick`, `address`, `nonce`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- marketplace listings ------------------------------
+CREATE TABLE `listings`
+(
+    `id`         bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `sid`        bigint unsigned                                               NOT NULL COMMENT 'sid',
+    `chain`      varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `protocol`   varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `tick`       varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `list_id`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'list tx hash',
+    `seller`     varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `market`     varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'marketplace contract',
+    `buyer`      varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+    `amount`     DECIMAL(38, 18)                                               NOT NULL,
+    `price`      DECIMAL(38, 18)                                               NOT NULL DEFAULT 0 COMMENT 'unit price in native coin',
+    `status`     tinyint(1)                                                    NOT NULL COMMENT '1:listed 2:delisted 3:filled',
+    `tx_hash`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'last transition tx hash',
+    `created_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_list_id` (`chain`, `list_id`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`),
+    KEY `idx_seller` (`seller`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- trades ------------------------------
+CREATE TABLE `trades`
+(
+    `id`           bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `chain`        varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `protocol`     varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `tick`         varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `list_id`      varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'empty if not a listing order',
+    `market`       varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'marketplace contract',
+    `seller`       varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `buyer`        varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `amount`       DECIMAL(38, 18)                                               NOT NULL,
+    `unit_price`   DECIMAL(38, 18)                                               NOT NULL COMMENT 'native coin per token',
+    `total`        DECIMAL(38, 18)                                               NOT NULL COMMENT 'native coin',
+    `block_height` bigint unsigned                                               NOT NULL,
+    `block_time`   timestamp                                                     NOT NULL,
+    `tx_hash`      varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `created_at`   timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_tick` (`chain`, `protocol`, `tick`),
+    KEY `idx_block_time` (`chain`, `block_time`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- market_stats ------------------------------
+CREATE TABLE `market_stats`
+(
+    `id`              int unsigned                                                 NOT NULL AUTO_INCREMENT,
+    `sid`             int unsigned                                                 NOT NULL COMMENT 'sid',
+    `chain`           varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `protocol`        varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin   NOT NULL,
+    `tick`            varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin   NOT NULL,
+    `volume`          DECIMAL(38, 18)                                              NOT NULL DEFAULT 0 COMMENT 'native coin',
+    `trade_cnt`       bigint unsigned                                              NOT NULL DEFAULT 0,
+    `last_price`      DECIMAL(38, 18)                                              NOT NULL DEFAULT 0,
+    `floor_price`     DECIMAL(38, 18)                                              NOT NULL DEFAULT 0 COMMENT 'lowest unit price of 24h',
+    `volume_24h`      DECIMAL(38, 18)                                              NOT NULL DEFAULT 0,
+    `trade_cnt_24h`   bigint unsigned                                              NOT NULL DEFAULT 0,
+    `last_trade_time` bigint unsigned                                              NOT NULL DEFAULT 0 COMMENT 'unix seconds',
+    `created_at`      timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`      timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_tick` (`chain`, `protocol`, `tick`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- ethscriptions ------------------------------
+CREATE TABLE `ethscriptions`
+(
+    `id`              bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `sid`             bigint unsigned                                               NOT NULL COMMENT 'sequence number',
+    `chain`           varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `ethscription_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'creation tx hash',
+    `creator`         varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `owner`           varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `content_type`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+    `content_hash`    varchar(66) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL COMMENT 'sha256 of data uri',
+    `content`         mediumblob                                                    NOT NULL,
+    `esip6`           tinyint(1)                                                    NOT NULL DEFAULT '0' COMMENT 'duplicate content allowed',
+    `block_height`    bigint unsigned                                               NOT NULL,
+    `tx_hash`         varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'last transfer tx hash',
+    `created_at`      timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`      timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_ethscription_id` (`chain`, `ethscription_id`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`),
+    KEY `idx_content_hash` (`content_hash`(16)),
+    KEY `idx_owner` (`owner`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- name service names ------------------------------
+CREATE TABLE `names`
+(
+    `id`           bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `sid`          bigint unsigned                                               NOT NULL,
+    `chain`        varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `protocol`     varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `name`         varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `owner`        varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `address`      varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'resolved address, owner if no address record',
+    `avatar`       varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+    `text`         varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+    `block_height` bigint unsigned                                               NOT NULL COMMENT 'registration block',
+    `tx_hash`      varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'last tx hash of the name',
+    `created_at`   timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_name` (`chain`, `name`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`),
+    KEY `idx_address` (`chain`, `address`),
+    KEY `idx_owner` (`owner`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- erc-20 wrapped balances of bridged ticks ------------------------------
+CREATE TABLE `wrapped_balances`
+(
+    `id`         bigint unsigned                                              NOT NULL AUTO_INCREMENT,
+    `sid`        bigint unsigned                                              NOT NULL,
+    `chain`      varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `protocol`   varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin   NOT NULL,
+    `tick`       varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin   NOT NULL,
+    `contract`   varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'erc-20 wrapper contract',
+    `address`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `amount`     DECIMAL(38, 18)                                              NOT NULL COMMENT 'wrapped amount in tick units',
+    `created_at` timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp                                                    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_address` (`address`, `chain`, `protocol`, `tick`, `contract`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+-- time-locked & vesting balances ------------------------------
+CREATE TABLE `locks`
+(
+    `id`            bigint unsigned                                               NOT NULL AUTO_INCREMENT,
+    `sid`           bigint unsigned                                               NOT NULL,
+    `chain`         varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci  NOT NULL,
+    `protocol`      varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `tick`          varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin    NOT NULL,
+    `lock_id`       varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'lock tx hash',
+    `address`       varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `amount`        DECIMAL(38, 18)                                               NOT NULL,
+    `released`      DECIMAL(38, 18)                                               NOT NULL DEFAULT 0,
+    `schedule`      text CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci         NOT NULL COMMENT 'json tranches of unlock heights',
+    `unlock_height` bigint unsigned                                               NOT NULL COMMENT 'height of the final tranche',
+    `tx_hash`       varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `block_height`  bigint unsigned                                               NOT NULL,
+    `created_at`    timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`    timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_lock_id` (`chain`, `lock_id`),
+    UNIQUE KEY `uq_chain_sid` (`chain`, `sid`),
+    KEY `idx_address` (`address`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
 
 -- address utxos ------------------------------
 CREATE TABLE `utxos`
@@ -153,11 +380,13 @@ CREATE TABLE `utxos`
     `amount`     DECIMAL(38, 18)                                               NOT NULL,
     `root_hash`  varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
     `tx_hash`    varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+    `outpoint`   varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'txid:vout of utxo held balances',
     `status`     tinyint(1)                                                    NOT NULL COMMENT 'tx status',
     `created_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at` timestamp                                                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
-    KEY `idx_address` (`address`)
+    KEY `idx_address` (`address`),
+    KEY `idx_outpoint` (`chain`, `protocol`, `outpoint`)
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_general_ci;
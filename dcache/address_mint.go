@@ -0,0 +1,165 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/utils"
+	"strings"
+	"sync"
+)
+
+// AddressMint
+/*****************************************************
+ * Build cache for ticks minted amount per address
+ * Mainly used for per wallet mint cap verification
+ ****************************************************/
+type AddressMint struct {
+	sid    uint64
+	items  *sync.Map
+	nonces *sync.Map // used proof of work nonces, protocol tick address nonce -> struct{}
+}
+
+type AddressMintItem struct {
+	SID     uint64
+	Minted  decimal.Decimal
+	MintCnt uint64
+	Claimed decimal.Decimal // allowlist claimed amount
+}
+
+func NewAddressMint() *AddressMint {
+	return &AddressMint{
+		items:  &sync.Map{},
+		nonces: &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define protocol tick address unique id
+ ***************************************/
+func (d *AddressMint) idx(protocol, tick, address string) string {
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick), strings.ToLower(address))
+}
+
+// Create
+/***************************************
+ * create addr tick's mint record
+ ***************************************/
+func (d *AddressMint) Create(protocol, tick string, addr string, item *AddressMintItem) *AddressMintItem {
+	if item.SID <= 0 {
+		d.sid++
+		item.SID = d.sid
+	}
+
+	idx := d.idx(protocol, tick, addr)
+	d.items.Store(idx, item)
+	return item
+}
+
+// Add
+/***************************************
+ * add addr tick's minted amount, returns true if the record created
+ ***************************************/
+func (d *AddressMint) Add(protocol, tick string, addr string, amount decimal.Decimal) (*AddressMintItem, bool) {
+	ok, item := d.Get(protocol, tick, addr)
+	if !ok {
+		return d.Create(protocol, tick, addr, &AddressMintItem{
+			Minted:  amount,
+			MintCnt: 1,
+		}), true
+	}
+
+	item.Minted = item.Minted.Add(amount)
+	item.MintCnt++
+	return item, false
+}
+
+// SetSid set auto_increment id
+func (d *AddressMint) SetSid(sid uint64) {
+	if sid > d.sid {
+		d.sid = sid
+	}
+}
+
+// Get
+/***************************************
+ * get addr tick's mint record
+ ***************************************/
+func (d *AddressMint) Get(protocol, tick string, addr string) (bool, *AddressMintItem) {
+	idx := d.idx(protocol, tick, addr)
+	item, ok := d.items.Load(idx)
+	if !ok {
+		return false, nil
+	}
+	return true, item.(*AddressMintItem)
+}
+
+// Minted returns addr tick's minted amount
+func (d *AddressMint) Minted(protocol, tick string, addr string) decimal.Decimal {
+	ok, item := d.Get(protocol, tick, addr)
+	if !ok {
+		return decimal.Zero
+	}
+	return item.Minted
+}
+
+// Claim
+/***************************************
+ * add addr tick's allowlist claimed amount, returns true if the record created
+ ***************************************/
+func (d *AddressMint) Claim(protocol, tick string, addr string, amount decimal.Decimal) (*AddressMintItem, bool) {
+	ok, item := d.Get(protocol, tick, addr)
+	if !ok {
+		return d.Create(protocol, tick, addr, &AddressMintItem{
+			Claimed: amount,
+		}), true
+	}
+
+	item.Claimed = item.Claimed.Add(amount)
+	return item, false
+}
+
+// Claimed returns addr tick's allowlist claimed amount
+func (d *AddressMint) Claimed(protocol, tick string, addr string) decimal.Decimal {
+	ok, item := d.Get(protocol, tick, addr)
+	if !ok {
+		return decimal.Zero
+	}
+	return item.Claimed
+}
+
+// UseNonce
+/***************************************
+ * mark addr's proof of work nonce of the tick used, returns false if used already
+ ***************************************/
+func (d *AddressMint) UseNonce(protocol, tick string, addr string, nonce string) bool {
+	_, loaded := d.nonces.LoadOrStore(fmt.Sprintf("%s_%s", d.idx(protocol, tick, addr), nonce), struct{}{})
+	return !loaded
+}
+
+// NonceUsed reports whether addr's proof of work nonce of the tick used
+func (d *AddressMint) NonceUsed(protocol, tick string, addr string, nonce string) bool {
+	_, ok := d.nonces.Load(fmt.Sprintf("%s_%s", d.idx(protocol, tick, addr), nonce))
+	return ok
+}
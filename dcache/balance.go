@@ -25,6 +25,7 @@ package dcache
 import (
 	"fmt"
 	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/utils"
 	"strings"
 	"sync"
 )
@@ -41,8 +42,9 @@ type Balance struct {
 
 type BalanceItem struct {
 	SID       uint64
-	Available decimal.Decimal
+	Available decimal.Decimal // available = overall - locked
 	Overall   decimal.Decimal
+	Locked    decimal.Decimal // locked by open listings, rebuilt on loading
 }
 
 func NewBalance() *Balance {
@@ -55,7 +57,7 @@ func NewBalance() *Balance {
  * idx define protocol tick unique id
  ***************************************/
 func (d *Balance) idx(protocol, tick, address string) string {
-	return fmt.Sprintf("%s_%s_%s", strings.ToLower(protocol), strings.ToLower(tick), strings.ToLower(address))
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick), strings.ToLower(address))
 }
 
 // Update
@@ -69,11 +71,34 @@ func (d *Balance) Update(protocol, tick string, addr string, b *BalanceItem) *Ba
 		return nil
 	}
 
-	balanceItem.Available = b.Available
 	balanceItem.Overall = b.Overall
+	balanceItem.Available = b.Overall.Sub(balanceItem.Locked)
 	return balanceItem
 }
 
+// Lock
+/***************************************
+ * lock amount out of addr's available balance
+ ***************************************/
+func (d *Balance) Lock(protocol, tick string, addr string, amount decimal.Decimal) *BalanceItem {
+	ok, balanceItem := d.Get(protocol, tick, addr)
+	if !ok {
+		return nil
+	}
+
+	balanceItem.Locked = balanceItem.Locked.Add(amount)
+	balanceItem.Available = balanceItem.Overall.Sub(balanceItem.Locked)
+	return balanceItem
+}
+
+// Unlock
+/***************************************
+ * release locked amount back to addr's available balance
+ ***************************************/
+func (d *Balance) Unlock(protocol, tick string, addr string, amount decimal.Decimal) *BalanceItem {
+	return d.Lock(protocol, tick, addr, amount.Neg())
+}
+
 // Create
 /***************************************
  * create addr tick's balance
@@ -86,8 +111,9 @@ func (d *Balance) Create(protocol, tick string, addr string, b *BalanceItem) *Ba
 
 	balanceItem := &BalanceItem{
 		SID:       b.SID,
-		Available: b.Available,
+		Available: b.Overall.Sub(b.Locked),
 		Overall:   b.Overall,
+		Locked:    b.Locked,
 	}
 
 	idx := d.idx(protocol, tick, addr)
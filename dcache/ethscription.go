@@ -0,0 +1,132 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"strings"
+	"sync"
+)
+
+// Ethscription
+/*****************************************************
+ * Build cache for non-fungible inscriptions & chain inscription sequence numbers
+ * Used for verifying ownership transfers & duplicate contents
+ ****************************************************/
+type Ethscription struct {
+	sn     uint64 // last inscription sequence number of the chain
+	lastTx string // tx of the last sequence number
+	items  *sync.Map
+	hashes *sync.Map // content hash -> ethscription id
+}
+
+type EthscriptionItem struct {
+	SID         uint64 // sequence number
+	Creator     string
+	Owner       string
+	ContentHash string
+}
+
+func NewEthscription() *Ethscription {
+	return &Ethscription{
+		items:  &sync.Map{},
+		hashes: &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define ethscription unique id
+ ***************************************/
+func (d *Ethscription) idx(id string) string {
+	return strings.ToLower(id)
+}
+
+// NextSN
+/***************************************
+ * sequence number of the inscription tx, one number per tx
+ ***************************************/
+func (d *Ethscription) NextSN(txHash string) uint64 {
+	if d.sn > 0 && strings.EqualFold(d.lastTx, txHash) {
+		return d.sn
+	}
+	d.sn++
+	d.lastTx = txHash
+	return d.sn
+}
+
+// SetSN set last sequence number
+func (d *Ethscription) SetSN(sn uint64) {
+	if sn > d.sn {
+		d.sn = sn
+	}
+}
+
+// LastSN returns the last sequence number of the chain
+func (d *Ethscription) LastSN() uint64 {
+	return d.sn
+}
+
+// Create
+/***************************************
+ * create ethscription, sequence number assigned by id if not set
+ ***************************************/
+func (d *Ethscription) Create(id string, item *EthscriptionItem) *EthscriptionItem {
+	if item.SID <= 0 {
+		item.SID = d.NextSN(id)
+	}
+
+	d.items.Store(d.idx(id), item)
+	d.hashes.LoadOrStore(d.idx(item.ContentHash), id)
+	return item
+}
+
+// Transfer
+/***************************************
+ * update ethscription owner
+ ***************************************/
+func (d *Ethscription) Transfer(id, owner string) *EthscriptionItem {
+	ok, item := d.Get(id)
+	if !ok {
+		return nil
+	}
+
+	item.Owner = owner
+	return item
+}
+
+// Get
+/***************************************
+ * get ethscription by id
+ ***************************************/
+func (d *Ethscription) Get(id string) (bool, *EthscriptionItem) {
+	val, ok := d.items.Load(d.idx(id))
+	if !ok {
+		return false, nil
+	}
+	return true, val.(*EthscriptionItem)
+}
+
+// ContentExists reports whether the content has been ethscribed
+func (d *Ethscription) ContentExists(contentHash string) bool {
+	_, ok := d.hashes.Load(d.idx(contentHash))
+	return ok
+}
@@ -38,7 +38,8 @@ import (
 type Inscription struct {
 	sid       uint32
 	ticks     *sync.Map
-	tickNames *sync.Map // used for asc20
+	tickNames *sync.Map // protocol tick hash -> tick name
+	skeletons *sync.Map // protocol tick skeleton -> first deployed tick name
 }
 
 type Tick struct {
@@ -47,12 +48,24 @@ type Tick struct {
 	LimitPerMint decimal.Decimal
 	TotalSupply  decimal.Decimal
 	Decimals     int8
+
+	WalletLimit    decimal.Decimal
+	StartBlock     uint64
+	EndBlock       uint64
+	BlockMintLimit uint64
+	MerkleRoot     string
+	Premine        decimal.Decimal
+	Price          decimal.Decimal // native coin price per token of paid mints
+	FeeTo          string
+	Id             string // protocol tick id, runes: etching block:tx
+	Workc          string // proof of work mints, hex prefix mint hashes must meet
 }
 
 func NewInscription() *Inscription {
 	return &Inscription{
 		ticks:     &sync.Map{},
 		tickNames: &sync.Map{},
+		skeletons: &sync.Map{},
 	}
 }
 
@@ -60,7 +73,7 @@ func NewInscription() *Inscription {
  * idx define protocol tick unique id
  ***************************************/
 func (d *Inscription) idx(protocol, tick string) string {
-	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), strings.ToLower(tick))
+	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick))
 }
 
 // Create
@@ -76,11 +89,15 @@ func (d *Inscription) Create(protocol, tick string, nt *Tick) {
 	idx := d.idx(protocol, tick)
 	d.ticks.Store(idx, nt)
 
-	// asc20 Add cache names
-	if protocol == "asc-20" {
-		key := utils.Keccak256(strings.ToLower(tick))
-		d.tickNames.Store(key, tick)
+	// Add cache names by tick hash, used by asc20 & marketplace events
+	key := utils.Keccak256(utils.NormalizeTick(tick, false))
+	d.tickNames.Store(d.idx(protocol, key), tick)
+	if nt.Id != "" {
+		d.tickNames.Store(d.idx(protocol, nt.Id), tick)
 	}
+
+	// Add visual skeleton, used by confusable deploys checking
+	d.skeletons.LoadOrStore(d.idx(protocol, utils.TickSkeleton(tick)), tick)
 }
 
 // SetSid set auto_increment id
@@ -119,13 +136,37 @@ func (d *Inscription) Get(protocol, tick string) (bool, *Tick) {
 	return true, t.(*Tick)
 }
 
-// GetNameByIdx
+// GetConfusable
+/***************************************
+ * get the deployed tick which is visually confusable with the tick
+ ***************************************/
+func (d *Inscription) GetConfusable(protocol, tick string) (bool, string) {
+	name, ok := d.skeletons.Load(d.idx(protocol, utils.TickSkeleton(tick)))
+	if !ok || name.(string) == utils.CanonicalTick(tick) {
+		return false, ""
+	}
+	return true, name.(string)
+}
+
+// GetNameById
+/***************************************
+ * get protocol's tick name by tick id
+ ***************************************/
+func (d *Inscription) GetNameById(protocol, id string) (bool, string) {
+	name, ok := d.tickNames.Load(d.idx(protocol, id))
+	if !ok {
+		return false, ""
+	}
+	return true, name.(string)
+}
+
+// GetNameByHash
 /***************************************
- * get tick name by idx
+ * get protocol's tick name by keccak256 hash of lower case tick
  ***************************************/
-func (d *Inscription) GetNameByIdx(key string) (bool, string) {
-	key = strings.TrimPrefix(key, "0x")
-	name, ok := d.tickNames.Load(key)
+func (d *Inscription) GetNameByHash(protocol, hash string) (bool, string) {
+	hash = strings.ToLower(strings.TrimPrefix(hash, "0x"))
+	name, ok := d.tickNames.Load(d.idx(protocol, hash))
 	if !ok {
 		return false, ""
 	}
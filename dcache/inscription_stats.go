@@ -25,6 +25,7 @@ package dcache
 import (
 	"fmt"
 	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/utils"
 	"github.com/uxuycom/indexer/xylog"
 	"strings"
 	"sync"
@@ -43,8 +44,15 @@ type InscriptionStats struct {
 type InsStats struct {
 	SID     uint32
 	Minted  decimal.Decimal
+	Burned  decimal.Decimal
 	Holders int64
 	TxCnt   uint64
+	LastSN  uint64          // sequence number of the tick's last inscription
+	Fees    decimal.Decimal // native coin paid of paid mints
+
+	// mints count of the last mint block, memory only
+	LastMintBlock uint64
+	BlockMints    uint64
 }
 
 func NewInscriptionStats() *InscriptionStats {
@@ -57,7 +65,7 @@ func NewInscriptionStats() *InscriptionStats {
  * idx define protocol tick unique id
  ***************************************/
 func (d *InscriptionStats) idx(protocol, tick string) string {
-	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), strings.ToLower(tick))
+	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick))
 }
 
 // Update
@@ -114,6 +122,61 @@ func (d *InscriptionStats) Mint(protocol, tick string, amount decimal.Decimal) *
 	return insStats
 }
 
+func (d *InscriptionStats) Burn(protocol, tick string, amount decimal.Decimal) *InsStats {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return nil
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return insStats
+	}
+
+	insStats.Burned = insStats.Burned.Add(amount)
+	return insStats
+}
+
+func (d *InscriptionStats) Fee(protocol, tick string, amount decimal.Decimal) *InsStats {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return nil
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return insStats
+	}
+
+	insStats.Fees = insStats.Fees.Add(amount)
+	return insStats
+}
+
+// BlockMint
+/***************************************
+ * count tick's mints in block
+ ***************************************/
+func (d *InscriptionStats) BlockMint(protocol, tick string, block uint64) *InsStats {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return nil
+	}
+
+	if insStats.LastMintBlock != block {
+		insStats.LastMintBlock = block
+		insStats.BlockMints = 0
+	}
+	insStats.BlockMints++
+	return insStats
+}
+
+// MintsInBlock returns tick's mints count in block
+func (d *InscriptionStats) MintsInBlock(protocol, tick string, block uint64) uint64 {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok || insStats.LastMintBlock != block {
+		return 0
+	}
+	return insStats.BlockMints
+}
+
 func (d *InscriptionStats) Holders(protocol, tick string, incr int64) *InsStats {
 	ok, insStats := d.Get(protocol, tick)
 	if !ok {
@@ -138,6 +201,22 @@ func (d *InscriptionStats) TxCnt(protocol, tick string, incr uint64) *InsStats {
 	return insStats
 }
 
+// SN
+/***************************************
+ * record sequence number of the tick's last inscription
+ ***************************************/
+func (d *InscriptionStats) SN(protocol, tick string, sn uint64) *InsStats {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return nil
+	}
+
+	if sn > insStats.LastSN {
+		insStats.LastSN = sn
+	}
+	return insStats
+}
+
 // SetSid set auto_increment id
 func (d *InscriptionStats) SetSid(sid uint32) {
 	if sid > d.sid {
@@ -0,0 +1,136 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/utils"
+	"strings"
+	"sync"
+)
+
+// Listing
+/*****************************************************
+ * Build cache for open marketplace listings
+ * Used for verifying listing state transitions
+ ****************************************************/
+type Listing struct {
+	sid   uint64
+	items *sync.Map
+}
+
+type ListingItem struct {
+	SID      uint64
+	Protocol string
+	Tick     string
+	Seller   string
+	Market   string
+	Buyer    string
+	Amount   decimal.Decimal
+	Price    decimal.Decimal // unit price in native coin, zero if not priced
+	Status   int8
+}
+
+func NewListing() *Listing {
+	return &Listing{
+		items: &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define listing unique id
+ ***************************************/
+func (d *Listing) idx(listId string) string {
+	return strings.ToLower(listId)
+}
+
+// Create
+/***************************************
+ * create listing
+ ***************************************/
+func (d *Listing) Create(listId string, item *ListingItem) *ListingItem {
+	if item.SID <= 0 {
+		d.sid++
+		item.SID = d.sid
+	}
+
+	d.items.Store(d.idx(listId), item)
+	return item
+}
+
+// Update
+/***************************************
+ * update listing status & buyer
+ ***************************************/
+func (d *Listing) Update(listId string, status int8, buyer string) *ListingItem {
+	ok, item := d.Get(listId)
+	if !ok {
+		return nil
+	}
+
+	item.Status = status
+	item.Buyer = buyer
+	return item
+}
+
+// SetSid set auto_increment id
+func (d *Listing) SetSid(sid uint64) {
+	if sid > d.sid {
+		d.sid = sid
+	}
+}
+
+// Floor
+/***************************************
+ * lowest unit price of the open priced listings of the tick, zero if none
+ ***************************************/
+func (d *Listing) Floor(protocol, tick string) decimal.Decimal {
+	floor := decimal.Zero
+	d.items.Range(func(key, value any) bool {
+		item := value.(*ListingItem)
+		if item.Status != model.ListingStatusListed || !item.Price.IsPositive() {
+			return true
+		}
+		if !strings.EqualFold(item.Protocol, protocol) || utils.CanonicalTick(item.Tick) != utils.CanonicalTick(tick) {
+			return true
+		}
+		if floor.IsZero() || item.Price.LessThan(floor) {
+			floor = item.Price
+		}
+		return true
+	})
+	return floor
+}
+
+// Get
+/***************************************
+ * get listing by list id
+ ***************************************/
+func (d *Listing) Get(listId string) (bool, *ListingItem) {
+	val, ok := d.items.Load(d.idx(listId))
+	if !ok {
+		return false, nil
+	}
+	return true, val.(*ListingItem)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Locks
+/*****************************************************
+ * Build cache for open time-locked & vesting balances
+ * Used for releasing due tranches by block height
+ ****************************************************/
+type Locks struct {
+	sid   uint64
+	items *sync.Map
+}
+
+type LockItem struct {
+	SID      uint64
+	LockId   string
+	Protocol string
+	Tick     string
+	Address  string
+	Amount   decimal.Decimal
+	Released decimal.Decimal
+	Tranches []*model.LockTranche // ascending heights
+}
+
+func NewLocks() *Locks {
+	return &Locks{
+		items: &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define lock unique id
+ ***************************************/
+func (d *Locks) idx(lockId string) string {
+	return strings.ToLower(lockId)
+}
+
+// Create
+/***************************************
+ * create lock, sid assigned if not set
+ ***************************************/
+func (d *Locks) Create(item *LockItem) *LockItem {
+	if item.SID <= 0 {
+		d.sid++
+		item.SID = d.sid
+	}
+
+	d.items.Store(d.idx(item.LockId), item)
+	return item
+}
+
+// Release
+/***************************************
+ * add released amount, fully released lock removed
+ ***************************************/
+func (d *Locks) Release(lockId string, amount decimal.Decimal) *LockItem {
+	ok, item := d.Get(lockId)
+	if !ok {
+		return nil
+	}
+
+	item.Released = item.Released.Add(amount)
+	if item.Released.GreaterThanOrEqual(item.Amount) {
+		d.items.Delete(d.idx(lockId))
+	}
+	return item
+}
+
+// SetSid set auto_increment id
+func (d *Locks) SetSid(sid uint64) {
+	if sid > d.sid {
+		d.sid = sid
+	}
+}
+
+// Get
+/***************************************
+ * get open lock by lock id
+ ***************************************/
+func (d *Locks) Get(lockId string) (bool, *LockItem) {
+	val, ok := d.items.Load(d.idx(lockId))
+	if !ok {
+		return false, nil
+	}
+	return true, val.(*LockItem)
+}
+
+// Due
+/***************************************
+ * open locks with tranches due at the height, ordered by sid
+ ***************************************/
+func (d *Locks) Due(height uint64) []*LockItem {
+	items := make([]*LockItem, 0)
+	d.items.Range(func(key, value any) bool {
+		item := value.(*LockItem)
+		if item.Due(height).IsPositive() {
+			items = append(items, item)
+		}
+		return true
+	})
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SID < items[j].SID
+	})
+	return items
+}
+
+// Unlocked amount of tranches reached by the height
+func (l *LockItem) Unlocked(height uint64) decimal.Decimal {
+	amount := decimal.Zero
+	for _, tranche := range l.Tranches {
+		if tranche.Height > height {
+			break
+		}
+		amount = amount.Add(tranche.Amount)
+	}
+	return amount
+}
+
+// Due unlocked amount not released yet
+func (l *LockItem) Due(height uint64) decimal.Decimal {
+	return l.Unlocked(height).Sub(l.Released)
+}
+
+// Remaining amount still locked
+func (l *LockItem) Remaining() decimal.Decimal {
+	return l.Amount.Sub(l.Released)
+}
@@ -23,6 +23,9 @@
 package dcache
 
 import (
+	"encoding/json"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/storage"
 	"github.com/uxuycom/indexer/xylog"
 	"time"
@@ -39,6 +42,13 @@ type Manager struct {
 	UTXO             *UTXO
 	Inscription      *Inscription
 	InscriptionStats *InscriptionStats
+	AddressMint      *AddressMint
+	Listing          *Listing
+	Locks            *Locks
+	Ethscription     *Ethscription
+	Names            *Names
+	Wrapped          *Wrapped
+	Market           *Market
 }
 
 func NewManager(db *storage.DBClient, chain string) *Manager {
@@ -54,6 +64,14 @@ func NewManager(db *storage.DBClient, chain string) *Manager {
 	e.initInscriptionCache(chain)
 	e.initInscriptionStatsCache(chain)
 	e.initBalanceCache(chain)
+	e.initAddressMintCache(chain)
+	e.initMintNonceCache(chain)
+	e.initListingCache(chain)
+	e.initLocksCache(chain)
+	e.initEthscriptionCache(chain)
+	e.initNameCache(chain)
+	e.initWrappedCache(chain)
+	e.initMarketCache(chain)
 	e.initUtxoCache()
 	return e
 }
@@ -86,6 +104,17 @@ func (h *Manager) initInscriptionCache(chain string) {
 				LimitPerMint: v.LimitPerMint,
 				TotalSupply:  v.TotalSupply,
 				Decimals:     v.Decimals,
+
+				WalletLimit:    v.WalletLimit,
+				StartBlock:     v.StartBlock,
+				EndBlock:       v.EndBlock,
+				BlockMintLimit: v.BlockMintLimit,
+				MerkleRoot:     v.MerkleRoot,
+				Premine:        v.Premine,
+				Price:          v.MintPrice,
+				FeeTo:          v.FeeTo,
+				Id:             v.TickId,
+				Workc:          v.Workc,
 			})
 
 			if v.SID > maxSid {
@@ -128,8 +157,11 @@ func (h *Manager) initInscriptionStatsCache(chain string) {
 			h.InscriptionStats.Create(v.Protocol, v.Tick, &InsStats{
 				SID:     v.SID,
 				Minted:  v.Minted,
+				Burned:  v.Burned,
 				Holders: int64(v.Holders),
 				TxCnt:   v.TxCnt,
+				LastSN:  v.LastSN,
+				Fees:    v.Fees,
 			})
 
 			if v.SID > maxSid {
@@ -169,10 +201,10 @@ func (h *Manager) initBalanceCache(chain string) {
 		}
 
 		for _, v := range balances {
+			// available balance is rebuilt by locks of open listings
 			h.Balance.Create(v.Protocol, v.Tick, v.Address, &BalanceItem{
-				SID:       v.SID,
-				Available: v.Available,
-				Overall:   v.Balance,
+				SID:     v.SID,
+				Overall: v.Balance,
 			})
 
 			if v.SID > maxSid {
@@ -190,6 +222,376 @@ func (h *Manager) initBalanceCache(chain string) {
 	xylog.Logger.Infof("load balances data finished, cost ts:%v", time.Since(startTs))
 }
 
+func (h *Manager) initAddressMintCache(chain string) {
+	h.AddressMint = NewAddressMint()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	maxSid := uint64(0)
+	xylog.Logger.Infof("load address mints data start...")
+	for {
+		items, err := h.db.GetAddressMintsByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize address mint cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load address mints ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.AddressMint.Create(v.Protocol, v.Tick, v.Address, &AddressMintItem{
+				SID:     v.SID,
+				Minted:  v.Minted,
+				MintCnt: v.MintCnt,
+				Claimed: v.Claimed,
+			})
+
+			if v.SID > maxSid {
+				maxSid = v.SID
+			}
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	//update sid
+	h.AddressMint.SetSid(maxSid)
+
+	xylog.Logger.Infof("load address mints data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initMintNonceCache(chain string) {
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	xylog.Logger.Infof("load mint nonces data start...")
+	for {
+		items, err := h.db.GetMintNoncesByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize mint nonce cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load mint nonces ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.AddressMint.UseNonce(v.Protocol, v.Tick, v.Address, v.Nonce)
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	xylog.Logger.Infof("load mint nonces data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initListingCache(chain string) {
+	h.Listing = NewListing()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	maxSid := uint64(0)
+	xylog.Logger.Infof("load listings data start...")
+	for {
+		items, err := h.db.GetListingsByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize listing cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load listings ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			if v.SID > maxSid {
+				maxSid = v.SID
+			}
+
+			// only open listings are needed for verifying
+			if v.Status != model.ListingStatusListed {
+				continue
+			}
+
+			h.Listing.Create(v.ListId, &ListingItem{
+				SID:      v.SID,
+				Protocol: v.Protocol,
+				Tick:     v.Tick,
+				Seller:   v.Seller,
+				Market:   v.Market,
+				Amount:   v.Amount,
+				Price:    v.Price,
+				Status:   v.Status,
+			})
+			h.Balance.Lock(v.Protocol, v.Tick, v.Seller, v.Amount)
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	//update sid
+	h.Listing.SetSid(maxSid)
+
+	xylog.Logger.Infof("load listings data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initLocksCache(chain string) {
+	h.Locks = NewLocks()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	maxSid := uint64(0)
+	xylog.Logger.Infof("load locks data start...")
+	for {
+		items, err := h.db.GetLocksByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize lock cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load locks ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			if v.SID > maxSid {
+				maxSid = v.SID
+			}
+
+			// only open locks are needed for releasing
+			remaining := v.Amount.Sub(v.Released)
+			if remaining.LessThanOrEqual(decimal.Zero) {
+				continue
+			}
+
+			tranches := make([]*model.LockTranche, 0)
+			if err := json.Unmarshal([]byte(v.Schedule), &tranches); err != nil {
+				xylog.Logger.Fatalf("failed to decode lock schedule. lock[%s], err:%v", v.LockId, err)
+			}
+
+			h.Locks.Create(&LockItem{
+				SID:      v.SID,
+				LockId:   v.LockId,
+				Protocol: v.Protocol,
+				Tick:     v.Tick,
+				Address:  v.Address,
+				Amount:   v.Amount,
+				Released: v.Released,
+				Tranches: tranches,
+			})
+			h.Balance.Lock(v.Protocol, v.Tick, v.Address, remaining)
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	//update sid
+	h.Locks.SetSid(maxSid)
+
+	xylog.Logger.Infof("load locks data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initMarketCache(chain string) {
+	h.Market = NewMarket()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	maxSid := uint32(0)
+	lastTime := uint64(0)
+	xylog.Logger.Infof("load market stats data start...")
+	for {
+		items, err := h.db.GetMarketStatsByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize market stats cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load market stats ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			if v.SID > maxSid {
+				maxSid = v.SID
+			}
+			if v.LastTradeTime > lastTime {
+				lastTime = v.LastTradeTime
+			}
+
+			h.Market.Create(v.Protocol, v.Tick, &MarketStats{
+				SID:         v.SID,
+				Volume:      v.Volume,
+				TradeCnt:    v.TradeCnt,
+				LastPrice:   v.LastPrice,
+				LastTime:    v.LastTradeTime,
+				Volume24h:   v.Volume24h,
+				TradeCnt24h: v.TradeCnt24h,
+				FloorPrice:  v.FloorPrice,
+			})
+		}
+
+		//update id index
+		start = uint64(items[len(items)-1].ID)
+	}
+
+	//update sid
+	h.Market.SetSid(maxSid)
+
+	// restore trades of the rolling window
+	since := uint64(0)
+	if lastTime > MarketWindow {
+		since = lastTime - MarketWindow
+	}
+	trades, err := h.db.GetTradesSince(chain, time.Unix(int64(since), 0))
+	if err != nil {
+		xylog.Logger.Fatalf("failed to initialize market trades cache data. err:%v", err)
+	}
+	for _, v := range trades {
+		h.Market.Restore(v.Protocol, v.Tick, &TradePoint{
+			Time:      uint64(v.BlockTime.Unix()),
+			UnitPrice: v.UnitPrice,
+			Total:     v.Total,
+		})
+	}
+
+	xylog.Logger.Infof("load market stats data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initEthscriptionCache(chain string) {
+	h.Ethscription = NewEthscription()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	xylog.Logger.Infof("load ethscriptions data start...")
+	for {
+		items, err := h.db.GetEthscriptionsByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize ethscription cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load ethscriptions ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.Ethscription.Create(v.EthscriptionId, &EthscriptionItem{
+				SID:         v.SID,
+				Creator:     v.Creator,
+				Owner:       v.Owner,
+				ContentHash: v.ContentHash,
+			})
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	// sequence numbers are shared by fungible inscriptions
+	sn, err := h.db.GetLastSN(chain)
+	if err != nil {
+		xylog.Logger.Fatalf("failed to initialize last sequence number. err:%v", err)
+	}
+	h.Ethscription.SetSN(sn)
+
+	xylog.Logger.Infof("load ethscriptions data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initNameCache(chain string) {
+	h.Names = NewNames()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	xylog.Logger.Infof("load names data start...")
+	for {
+		items, err := h.db.GetNamesByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize name cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load names ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.Names.Register(v.Name, &NameItem{
+				SID:     v.SID,
+				Owner:   v.Owner,
+				Address: v.Address,
+				Avatar:  v.Avatar,
+				Text:    v.Text,
+			})
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	xylog.Logger.Infof("load names data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initWrappedCache(chain string) {
+	h.Wrapped = NewWrapped()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	xylog.Logger.Infof("load wrapped balances data start...")
+	for {
+		items, err := h.db.GetWrappedBalancesByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize wrapped balances cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load wrapped balances ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.Wrapped.Create(v.Protocol, v.Tick, v.Contract, v.Address, &WrappedItem{
+				SID:    v.SID,
+				Amount: v.Amount,
+			})
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+
+	xylog.Logger.Infof("load wrapped balances data finished, cost ts:%v", time.Since(startTs))
+}
+
 func (h *Manager) initUtxoCache() {
 	h.UTXO = NewUTXO()
 
@@ -211,6 +613,10 @@ func (h *Manager) initUtxoCache() {
 		}
 
 		for _, v := range utxos {
+			if v.Outpoint != "" {
+				h.UTXO.AddOutpoint(v.Protocol, v.Tick, v.Outpoint, v.Address, v.Amount)
+				continue
+			}
 			h.UTXO.Add(v.Protocol, v.Tick, v.RootHash, v.Address, v.Amount, v.Sn)
 		}
 
@@ -0,0 +1,210 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/utils"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MarketWindow rolling window of market stats in seconds, 24 hours
+const MarketWindow uint64 = 24 * 60 * 60
+
+// Market
+/*****************************************************
+ * Build cache for per tick marketplace trade stats
+ * 24h values are rolling window as of the block time, refreshed by trades, listing changes & each block
+ * floor price is the lowest unit price of the open listings
+ ****************************************************/
+type Market struct {
+	sid   uint32
+	ticks *sync.Map
+}
+
+type MarketStats struct {
+	SID       uint32
+	Protocol  string
+	Tick      string
+	Volume    decimal.Decimal // total traded value in native coin
+	TradeCnt  uint64
+	LastPrice decimal.Decimal // unit price of the last priced trade
+	LastTime  uint64          // block time of the last trade
+
+	// rolling window stats
+	Volume24h   decimal.Decimal
+	TradeCnt24h uint64
+	FloorPrice  decimal.Decimal // lowest unit price of the open listings
+
+	window []*TradePoint
+}
+
+// TradePoint trade of the rolling window
+type TradePoint struct {
+	Time      uint64
+	UnitPrice decimal.Decimal
+	Total     decimal.Decimal
+}
+
+func NewMarket() *Market {
+	return &Market{
+		ticks: &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define market stats unique id
+ ***************************************/
+func (d *Market) idx(protocol, tick string) string {
+	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick))
+}
+
+// Create
+/***************************************
+ * create market stats of tick
+ ***************************************/
+func (d *Market) Create(protocol, tick string, stats *MarketStats) *MarketStats {
+	if stats.SID <= 0 {
+		d.sid++
+		stats.SID = d.sid
+	}
+
+	stats.Protocol, stats.Tick = protocol, tick
+	d.ticks.Store(d.idx(protocol, tick), stats)
+	return stats
+}
+
+// SetSid set auto_increment id
+func (d *Market) SetSid(sid uint32) {
+	if sid > d.sid {
+		d.sid = sid
+	}
+}
+
+// Get
+/***************************************
+ * get market stats by protocol & tick
+ ***************************************/
+func (d *Market) Get(protocol, tick string) (bool, *MarketStats) {
+	val, ok := d.ticks.Load(d.idx(protocol, tick))
+	if !ok {
+		return false, nil
+	}
+	return true, val.(*MarketStats)
+}
+
+// Trade
+/***************************************
+ * record a filled trade into the tick's market stats, created if not exist
+ * unpriced trades count without changing prices
+ ***************************************/
+func (d *Market) Trade(protocol, tick string, ts uint64, unitPrice, total decimal.Decimal) (*MarketStats, bool) {
+	ok, stats := d.Get(protocol, tick)
+	if !ok {
+		stats = d.Create(protocol, tick, &MarketStats{})
+	}
+
+	stats.Volume = stats.Volume.Add(total)
+	stats.TradeCnt++
+	if unitPrice.IsPositive() {
+		stats.LastPrice = unitPrice
+	}
+	if ts > stats.LastTime {
+		stats.LastTime = ts
+	}
+
+	stats.window = append(stats.window, &TradePoint{Time: ts, UnitPrice: unitPrice, Total: total})
+	stats.refresh(ts)
+	return stats, !ok
+}
+
+// Floor
+/***************************************
+ * update the floor price of the tick's market stats & prune the window by the block time
+ * ticks without trades have no market stats
+ ***************************************/
+func (d *Market) Floor(protocol, tick string, ts uint64, floor decimal.Decimal) (*MarketStats, bool) {
+	ok, stats := d.Get(protocol, tick)
+	if !ok {
+		return nil, false
+	}
+
+	stats.FloorPrice = floor
+	stats.refresh(ts)
+	return stats, true
+}
+
+// Restore
+/***************************************
+ * restore a persisted trade into the rolling window, totals & window stats unchanged
+ ***************************************/
+func (d *Market) Restore(protocol, tick string, point *TradePoint) {
+	ok, stats := d.Get(protocol, tick)
+	if !ok {
+		return
+	}
+
+	stats.window = append(stats.window, point)
+}
+
+// Expire
+/***************************************
+ * refresh the rolling window stats of all ticks as of the block time, by sid
+ * returns stats of ticks whose window stats changed, e.g. trades out of the window
+ ***************************************/
+func (d *Market) Expire(ts uint64) []*MarketStats {
+	items := make([]*MarketStats, 0)
+	d.ticks.Range(func(_, val interface{}) bool {
+		stats := val.(*MarketStats)
+		volume, cnt := stats.Volume24h, stats.TradeCnt24h
+		stats.refresh(ts)
+		if !stats.Volume24h.Equal(volume) || stats.TradeCnt24h != cnt {
+			items = append(items, stats)
+		}
+		return true
+	})
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SID < items[j].SID
+	})
+	return items
+}
+
+// refresh prune trades out of the window as of the block time & recalculate the window stats
+func (s *MarketStats) refresh(ts uint64) {
+	window := s.window[:0]
+	s.Volume24h, s.TradeCnt24h = decimal.Zero, 0
+	for _, item := range s.window {
+		if item.Time+MarketWindow <= ts {
+			continue
+		}
+		window = append(window, item)
+
+		s.Volume24h = s.Volume24h.Add(item.Total)
+		s.TradeCnt24h++
+	}
+	s.window = window
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"strings"
+	"sync"
+)
+
+// Names
+/*****************************************************
+ * Build cache for name service registrations & records
+ * Used for verifying registrations, ownership transfers & record updates
+ ****************************************************/
+type Names struct {
+	sid   uint64
+	items *sync.Map
+}
+
+type NameItem struct {
+	SID     uint64
+	Owner   string
+	Address string // resolved address, owner if no address record
+	Avatar  string
+	Text    string
+}
+
+func NewNames() *Names {
+	return &Names{
+		items: &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define name unique id
+ ***************************************/
+func (d *Names) idx(name string) string {
+	return strings.ToLower(name)
+}
+
+// Register
+/***************************************
+ * register name, sid assigned if not set
+ ***************************************/
+func (d *Names) Register(name string, item *NameItem) *NameItem {
+	if item.SID <= 0 {
+		d.sid++
+		item.SID = d.sid
+	} else if item.SID > d.sid {
+		d.sid = item.SID
+	}
+
+	d.items.Store(d.idx(name), item)
+	return item
+}
+
+// Update
+/***************************************
+ * update owner & records of the name
+ ***************************************/
+func (d *Names) Update(name string, owner, address, avatar, text string) *NameItem {
+	ok, item := d.Get(name)
+	if !ok {
+		return nil
+	}
+
+	item.Owner = owner
+	item.Address = address
+	item.Avatar = avatar
+	item.Text = text
+	return item
+}
+
+// Get
+/***************************************
+ * get registered name
+ ***************************************/
+func (d *Names) Get(name string) (bool, *NameItem) {
+	val, ok := d.items.Load(d.idx(name))
+	if !ok {
+		return false, nil
+	}
+	return true, val.(*NameItem)
+}
@@ -23,6 +23,7 @@
 package dcache
 
 import (
+	"fmt"
 	"github.com/shopspring/decimal"
 	"strings"
 	"sync"
@@ -34,7 +35,8 @@ import (
  * Mainly used for mint & transfer data checking
  ****************************************************/
 type UTXO struct {
-	hashes *sync.Map //record mint hash items
+	hashes    *sync.Map //record mint hash items
+	outpoints *sync.Map //record balances held by tx outputs, protocol outpoint -> items
 }
 
 type UTXOItem struct {
@@ -47,7 +49,8 @@ type UTXOItem struct {
 
 func NewUTXO() *UTXO {
 	return &UTXO{
-		hashes: &sync.Map{},
+		hashes:    &sync.Map{},
+		outpoints: &sync.Map{},
 	}
 }
 
@@ -85,3 +88,59 @@ func (d *UTXO) Get(txHash string) (bool, *UTXOItem) {
 	}
 	return true, item.(*UTXOItem)
 }
+
+/***************************************
+ * outpointIdx define outpoint unique id
+ ***************************************/
+func (d *UTXO) outpointIdx(protocol, outpoint string) string {
+	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), strings.ToLower(outpoint))
+}
+
+// AddOutpoint
+/***************************************
+ * add tick balance held by the outpoint (txid:vout)
+ ***************************************/
+func (d *UTXO) AddOutpoint(protocol, tick, outpoint, address string, amount decimal.Decimal) {
+	idx := d.outpointIdx(protocol, outpoint)
+	items := d.GetOutpoint(protocol, outpoint)
+	d.outpoints.Store(idx, append(items[:len(items):len(items)], &UTXOItem{
+		Protocol: protocol,
+		Tick:     tick,
+		Amount:   amount,
+		Owner:    address,
+	}))
+}
+
+// GetOutpoint
+/***************************************
+ * get tick balances held by the outpoint
+ ***************************************/
+func (d *UTXO) GetOutpoint(protocol, outpoint string) []*UTXOItem {
+	items, ok := d.outpoints.Load(d.outpointIdx(protocol, outpoint))
+	if !ok {
+		return nil
+	}
+	return items.([]*UTXOItem)
+}
+
+// SpendOutpoint
+/***************************************
+ * remove tick balance of the spent outpoint
+ ***************************************/
+func (d *UTXO) SpendOutpoint(protocol, tick, outpoint string) {
+	idx := d.outpointIdx(protocol, outpoint)
+	items := d.GetOutpoint(protocol, outpoint)
+
+	left := make([]*UTXOItem, 0, len(items))
+	for _, item := range items {
+		if item.Tick != tick {
+			left = append(left, item)
+		}
+	}
+
+	if len(left) <= 0 {
+		d.outpoints.Delete(idx)
+		return
+	}
+	d.outpoints.Store(idx, left)
+}
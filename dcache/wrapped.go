@@ -0,0 +1,160 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/utils"
+	"strings"
+	"sync"
+)
+
+// Wrapped
+/*****************************************************
+ * Build cache for erc-20 wrapped balances of bridged ticks & the bridge addresses
+ * Used for verifying wraps, unwraps & transfers of wrapped tokens
+ ****************************************************/
+type Wrapped struct {
+	sid      uint64
+	balances *sync.Map
+	supplies *sync.Map // protocol tick contract -> wrapped supply, sum of the balances
+	bridges  *sync.Map // protocol tick -> bridge addresses holding the wrapped inscriptions
+}
+
+type WrappedItem struct {
+	SID    uint64
+	Amount decimal.Decimal
+}
+
+func NewWrapped() *Wrapped {
+	return &Wrapped{
+		balances: &sync.Map{},
+		supplies: &sync.Map{},
+		bridges:  &sync.Map{},
+	}
+}
+
+/***************************************
+ * idx define wrapped balance unique id
+ ***************************************/
+func (d *Wrapped) idx(protocol, tick, contract, address string) string {
+	return fmt.Sprintf("%s_%s_%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick), strings.ToLower(contract), strings.ToLower(address))
+}
+
+func (d *Wrapped) tickIdx(protocol, tick string) string {
+	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick))
+}
+
+func (d *Wrapped) supplyIdx(protocol, tick, contract string) string {
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(protocol), utils.CanonicalTick(tick), strings.ToLower(contract))
+}
+
+// addSupply adds the balance change to the wrapped supply of the contract
+func (d *Wrapped) addSupply(protocol, tick, contract string, change decimal.Decimal) {
+	idx := d.supplyIdx(protocol, tick, contract)
+	d.supplies.Store(idx, d.Supply(protocol, tick, contract).Add(change))
+}
+
+// Create
+/***************************************
+ * create wrapped balance of the address, sid assigned if not set
+ ***************************************/
+func (d *Wrapped) Create(protocol, tick, contract, address string, item *WrappedItem) *WrappedItem {
+	if item.SID <= 0 {
+		d.sid++
+		item.SID = d.sid
+	} else if item.SID > d.sid {
+		d.sid = item.SID
+	}
+
+	if ok, exist := d.Get(protocol, tick, contract, address); ok {
+		d.addSupply(protocol, tick, contract, exist.Amount.Neg())
+	}
+	d.balances.Store(d.idx(protocol, tick, contract, address), item)
+	d.addSupply(protocol, tick, contract, item.Amount)
+	return item
+}
+
+// Update
+/***************************************
+ * update wrapped balance of the address
+ ***************************************/
+func (d *Wrapped) Update(protocol, tick, contract, address string, amount decimal.Decimal) *WrappedItem {
+	ok, item := d.Get(protocol, tick, contract, address)
+	if !ok {
+		return nil
+	}
+
+	d.addSupply(protocol, tick, contract, amount.Sub(item.Amount))
+	item.Amount = amount
+	return item
+}
+
+// Get
+/***************************************
+ * get wrapped balance of the address
+ ***************************************/
+func (d *Wrapped) Get(protocol, tick, contract, address string) (bool, *WrappedItem) {
+	val, ok := d.balances.Load(d.idx(protocol, tick, contract, address))
+	if !ok {
+		return false, nil
+	}
+	return true, val.(*WrappedItem)
+}
+
+// Supply
+/***************************************
+ * wrapped supply of the contract, the sum of wrapped balances
+ ***************************************/
+func (d *Wrapped) Supply(protocol, tick, contract string) decimal.Decimal {
+	val, ok := d.supplies.Load(d.supplyIdx(protocol, tick, contract))
+	if !ok {
+		return decimal.Zero
+	}
+	return val.(decimal.Decimal)
+}
+
+// AddBridge
+/***************************************
+ * register bridge address of the tick
+ ***************************************/
+func (d *Wrapped) AddBridge(protocol, tick, address string) {
+	idx := d.tickIdx(protocol, tick)
+	items := d.Bridges(protocol, tick)
+	for _, v := range items {
+		if strings.EqualFold(v, address) {
+			return
+		}
+	}
+	d.bridges.Store(idx, append(append([]string{}, items...), address))
+}
+
+// Bridges returns bridge addresses of the tick
+func (d *Wrapped) Bridges(protocol, tick string) []string {
+	val, ok := d.bridges.Load(d.tickIdx(protocol, tick))
+	if !ok {
+		return nil
+	}
+	return val.([]string)
+}
@@ -24,7 +24,9 @@ package devents
 
 import (
 	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/model"
 )
 
 type TxResultHandler struct {
@@ -38,6 +40,27 @@ func NewTxResultHandler(cache *dcache.Manager) *TxResultHandler {
 }
 
 func (tc *TxResultHandler) UpdateCache(r *TxResult) {
+	if r.Ethscription != nil {
+		tc.updateEthscriptionCache(r)
+		return
+	}
+
+	if r.Name != nil {
+		tc.updateNameCache(r)
+		return
+	}
+
+	if r.Wrap != nil {
+		tc.updateWrapCache(r)
+		return
+	}
+
+	// released by block height, no tx
+	if r.Unlock != nil {
+		tc.updateUnlockCache(r)
+		return
+	}
+
 	if r.Deploy != nil {
 		tc.updateDeployCache(r)
 	}
@@ -46,9 +69,90 @@ func (tc *TxResultHandler) UpdateCache(r *TxResult) {
 		tc.updateMintCache(r)
 	}
 
+	// listing locks are released before fill transfer
+	if r.Listing != nil {
+		tc.updateListingCache(r)
+	}
+
 	if r.Transfer != nil {
 		tc.updateTransferCache(r)
 	}
+
+	if r.Burn != nil {
+		tc.updateBurnCache(r)
+	}
+
+	// locks to other addresses are transferred before locking
+	if r.Lock != nil {
+		tc.updateLockCache(r)
+	}
+
+	if r.UTXO != nil {
+		tc.updateUTXOCache(r)
+	}
+
+	if r.Trade != nil {
+		_, r.Trade.Init = tc.cache.Market.Trade(r.MD.Protocol, r.MD.Tick, r.Block.Time, r.Trade.UnitPrice, r.Trade.Total)
+	}
+
+	// floor price by the open listings, updated after listings changed or traded
+	if r.Listing != nil || r.Trade != nil {
+		tc.cache.Market.Floor(r.MD.Protocol, r.MD.Tick, r.Block.Time, tc.cache.Listing.Floor(r.MD.Protocol, r.MD.Tick))
+	}
+
+	// calldata inscriptions share the chain sequence numbers with ethscriptions
+	if r.MD.Data != "" {
+		tc.cache.InscriptionStats.SN(r.MD.Protocol, r.MD.Tick, tc.cache.Ethscription.NextSN(r.Tx.Hash))
+	}
+}
+
+func (tc *TxResultHandler) updateEthscriptionCache(r *TxResult) {
+	e := r.Ethscription
+	if r.MD.Operate == OperateCreate {
+		tc.cache.Ethscription.Create(e.Id, &dcache.EthscriptionItem{
+			Creator:     e.Creator,
+			Owner:       e.Owner,
+			ContentHash: e.ContentHash,
+		})
+		return
+	}
+	tc.cache.Ethscription.Transfer(e.Id, e.Owner)
+}
+
+func (tc *TxResultHandler) updateNameCache(r *TxResult) {
+	n := r.Name
+	if r.MD.Operate == OperateRegister {
+		tc.cache.Names.Register(n.Name, &dcache.NameItem{
+			Owner:   n.Owner,
+			Address: n.Address,
+			Avatar:  n.Avatar,
+			Text:    n.Text,
+		})
+		return
+	}
+	tc.cache.Names.Update(n.Name, n.Owner, n.Address, n.Avatar, n.Text)
+}
+
+func (tc *TxResultHandler) updateWrapCache(r *TxResult) {
+	w := r.Wrap
+	if w.From != "" {
+		_, item := tc.cache.Wrapped.Get(r.MD.Protocol, r.MD.Tick, w.Contract, w.From)
+		tc.cache.Wrapped.Update(r.MD.Protocol, r.MD.Tick, w.Contract, w.From, item.Amount.Sub(w.Amount))
+	}
+
+	if w.To == "" {
+		return
+	}
+
+	ok, item := tc.cache.Wrapped.Get(r.MD.Protocol, r.MD.Tick, w.Contract, w.To)
+	if !ok {
+		tc.cache.Wrapped.Create(r.MD.Protocol, r.MD.Tick, w.Contract, w.To, &dcache.WrappedItem{
+			Amount: w.Amount,
+		})
+		w.Init = true
+		return
+	}
+	tc.cache.Wrapped.Update(r.MD.Protocol, r.MD.Tick, w.Contract, w.To, item.Amount.Add(w.Amount))
 }
 
 func (tc *TxResultHandler) updateDeployCache(r *TxResult) {
@@ -57,6 +161,17 @@ func (tc *TxResultHandler) updateDeployCache(r *TxResult) {
 		LimitPerMint: r.Deploy.MintLimit,
 		TotalSupply:  r.Deploy.MaxSupply,
 		Decimals:     r.Deploy.Decimal,
+
+		WalletLimit:    r.Deploy.WalletLimit,
+		StartBlock:     r.Deploy.StartBlock,
+		EndBlock:       r.Deploy.EndBlock,
+		BlockMintLimit: r.Deploy.BlockMintLimit,
+		MerkleRoot:     r.Deploy.MerkleRoot,
+		Premine:        r.Deploy.Premine,
+		Price:          r.Deploy.Price,
+		FeeTo:          r.Deploy.FeeTo,
+		Id:             r.Deploy.Id,
+		Workc:          r.Deploy.Workc,
 	}
 	tc.cache.Inscription.Create(r.MD.Protocol, r.MD.Tick, t)
 
@@ -65,12 +180,38 @@ func (tc *TxResultHandler) updateDeployCache(r *TxResult) {
 		TxCnt: 1,
 	}
 	tc.cache.InscriptionStats.Create(r.MD.Protocol, r.MD.Tick, ts)
+
+	//Credit premine to the deployer, premine of utxo based protocols allocated to outputs
+	if r.Deploy.Premine.IsPositive() && r.UTXO == nil {
+		tc.cache.InscriptionStats.Mint(r.MD.Protocol, r.MD.Tick, r.Deploy.Premine)
+		tc.cache.InscriptionStats.Holders(r.MD.Protocol, r.MD.Tick, 1)
+		tc.cache.Balance.Create(r.MD.Protocol, r.MD.Tick, r.Deploy.Deployer, &dcache.BalanceItem{
+			Overall: r.Deploy.Premine,
+		})
+	}
 }
 
 func (tc *TxResultHandler) updateMintCache(r *TxResult) {
 	//Update mint stats
 	tc.cache.InscriptionStats.Mint(r.MD.Protocol, r.MD.Tick, r.Mint.Amount)
 	tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+	tc.cache.InscriptionStats.BlockMint(r.MD.Protocol, r.MD.Tick, r.Block.Number.Uint64())
+	tc.cache.InscriptionStats.Fee(r.MD.Protocol, r.MD.Tick, r.Mint.Fee)
+
+	//Update minter minted amount
+	if tc.addressMintTracked(r.MD.Protocol, r.MD.Tick) {
+		_, r.Mint.RecordInit = tc.cache.AddressMint.Add(r.MD.Protocol, r.MD.Tick, r.Mint.Minter, r.Mint.Amount)
+	}
+
+	//Mark proof of work nonce used
+	if r.Mint.Nonce != "" {
+		tc.cache.AddressMint.UseNonce(r.MD.Protocol, r.MD.Tick, r.Mint.Minter, r.Mint.Nonce)
+	}
+
+	//Update allowlist claimed amount
+	if r.Mint.Claimer != "" {
+		_, r.Mint.ClaimInit = tc.cache.AddressMint.Claim(r.MD.Protocol, r.MD.Tick, r.Mint.Claimer, r.Mint.Amount)
+	}
 
 	//Update minter balances
 	ok, balance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, r.Mint.Minter)
@@ -94,6 +235,15 @@ func (tc *TxResultHandler) updateMintCache(r *TxResult) {
 	}
 }
 
+// addressMintTracked per address minted amount only tracked for capped ticks
+func (tc *TxResultHandler) addressMintTracked(protocol, tick string) bool {
+	ok, t := tc.cache.Inscription.Get(protocol, tick)
+	if !ok {
+		return false
+	}
+	return t.WalletLimit.GreaterThan(decimal.Zero)
+}
+
 func (tc *TxResultHandler) updateTransferCache(r *TxResult) {
 	//Update transfer stats
 	tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
@@ -143,3 +293,171 @@ func (tc *TxResultHandler) updateTransferCache(r *TxResult) {
 	}
 	tc.cache.InscriptionStats.Holders(r.MD.Protocol, r.MD.Tick, holders)
 }
+
+func (tc *TxResultHandler) updateBurnCache(r *TxResult) {
+	//Update burn stats, tx counted by transfer if exists
+	tc.cache.InscriptionStats.Burn(r.MD.Protocol, r.MD.Tick, r.Burn.Amount)
+	if r.Transfer == nil {
+		tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+	}
+
+	//Update sender balances
+	_, senderBalance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, r.Burn.Sender)
+	senderAmount := senderBalance.Overall.Sub(r.Burn.Amount)
+	if senderBalance.Overall.GreaterThan(decimal.Zero) && senderAmount.LessThanOrEqual(decimal.Zero) {
+		tc.cache.InscriptionStats.Holders(r.MD.Protocol, r.MD.Tick, -1)
+	}
+	tc.cache.Balance.Update(r.MD.Protocol, r.MD.Tick, r.Burn.Sender, &dcache.BalanceItem{
+		Overall: senderAmount,
+	})
+}
+
+func (tc *TxResultHandler) updateUTXOCache(r *TxResult) {
+	u := r.UTXO
+
+	//Update stats, tx of deploy counted by stats creation
+	if u.Minted.IsPositive() {
+		tc.cache.InscriptionStats.Mint(r.MD.Protocol, r.MD.Tick, u.Minted)
+	}
+	if u.Burned.IsPositive() {
+		tc.cache.InscriptionStats.Burn(r.MD.Protocol, r.MD.Tick, u.Burned)
+	}
+	if r.Deploy == nil {
+		tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+	}
+
+	//Update outpoints & net balance change of addresses
+	deltas := make(map[string]decimal.Decimal, len(u.Spent)+len(u.Created))
+	addresses := make([]string, 0, len(u.Spent)+len(u.Created))
+	for _, item := range u.Spent {
+		tc.cache.UTXO.SpendOutpoint(r.MD.Protocol, r.MD.Tick, item.Outpoint)
+		if _, ok := deltas[item.Address]; !ok {
+			addresses = append(addresses, item.Address)
+		}
+		deltas[item.Address] = deltas[item.Address].Sub(item.Amount)
+	}
+	for _, item := range u.Created {
+		tc.cache.UTXO.AddOutpoint(r.MD.Protocol, r.MD.Tick, item.Outpoint, item.Address, item.Amount)
+		if _, ok := deltas[item.Address]; !ok {
+			addresses = append(addresses, item.Address)
+		}
+		deltas[item.Address] = deltas[item.Address].Add(item.Amount)
+	}
+
+	holders := int64(0)
+	u.changes = make([]*Receive, 0, len(addresses))
+	for _, address := range addresses {
+		delta := deltas[address]
+		if delta.IsZero() {
+			continue
+		}
+
+		change := &Receive{Address: address, Amount: delta}
+		ok, balance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, address)
+		if !ok {
+			holders++
+			tc.cache.Balance.Create(r.MD.Protocol, r.MD.Tick, address, &dcache.BalanceItem{
+				Overall: delta,
+			})
+			change.Init = true
+		} else {
+			amount := balance.Overall.Add(delta)
+			if balance.Overall.LessThanOrEqual(decimal.Zero) && amount.GreaterThan(decimal.Zero) {
+				holders++
+			}
+			if balance.Overall.GreaterThan(decimal.Zero) && amount.LessThanOrEqual(decimal.Zero) {
+				holders--
+			}
+			tc.cache.Balance.Update(r.MD.Protocol, r.MD.Tick, address, &dcache.BalanceItem{
+				Overall: amount,
+			})
+		}
+		u.changes = append(u.changes, change)
+	}
+
+	if holders != 0 {
+		tc.cache.InscriptionStats.Holders(r.MD.Protocol, r.MD.Tick, holders)
+	}
+}
+
+func (tc *TxResultHandler) updateListingCache(r *TxResult) {
+	l := r.Listing
+	switch l.Status {
+	case model.ListingStatusListed:
+		tc.cache.Listing.Create(l.ListId, &dcache.ListingItem{
+			Protocol: r.MD.Protocol,
+			Tick:     r.MD.Tick,
+			Seller:   l.Seller,
+			Market:   l.Market,
+			Amount:   l.Amount,
+			Price:    l.Price,
+			Status:   l.Status,
+		})
+		tc.cache.Balance.Lock(r.MD.Protocol, r.MD.Tick, l.Seller, l.Amount)
+	default:
+		tc.cache.Listing.Update(l.ListId, l.Status, l.Buyer)
+		tc.cache.Balance.Unlock(r.MD.Protocol, r.MD.Tick, l.Seller, l.Amount)
+	}
+
+	//Update listing stats, tx of filled listing counted by transfer
+	if r.Transfer == nil {
+		tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+	}
+}
+
+func (tc *TxResultHandler) updateLockCache(r *TxResult) {
+	l := r.Lock
+	tc.cache.Locks.Create(&dcache.LockItem{
+		LockId:   l.LockId,
+		Protocol: r.MD.Protocol,
+		Tick:     r.MD.Tick,
+		Address:  l.Address,
+		Amount:   l.Amount,
+		Tranches: l.Tranches,
+	})
+	tc.cache.Balance.Lock(r.MD.Protocol, r.MD.Tick, l.Address, l.Amount)
+
+	//Update lock stats, tx of locks to other addresses counted by transfer
+	if r.Transfer == nil {
+		tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+	}
+}
+
+func (tc *TxResultHandler) updateUnlockCache(r *TxResult) {
+	u := r.Unlock
+	if item := tc.cache.Locks.Release(u.LockId, u.Amount); item != nil {
+		u.SID, u.Released = item.SID, item.Released
+	}
+	tc.cache.Balance.Unlock(r.MD.Protocol, r.MD.Tick, u.Address, u.Amount)
+}
+
+// BuildUnlocks
+/***************************************
+ * due tranches of open locks released at the block, applied before the txs of the block
+ ***************************************/
+func (tc *TxResultHandler) BuildUnlocks(chain string, block *xycommon.RpcBlock) []*TxResult {
+	if tc.cache.Locks == nil || block == nil || block.Number == nil {
+		return nil
+	}
+
+	height := block.Number.Uint64()
+	items := tc.cache.Locks.Due(height)
+	results := make([]*TxResult, 0, len(items))
+	for _, item := range items {
+		results = append(results, &TxResult{
+			MD: &MetaData{
+				Chain:    chain,
+				Protocol: item.Protocol,
+				Operate:  OperateUnlock,
+				Tick:     item.Tick,
+			},
+			Block: block,
+			Unlock: &Unlock{
+				LockId:  item.LockId,
+				Address: item.Address,
+				Amount:  item.Due(height),
+			},
+		})
+	}
+	return results
+}
@@ -244,6 +244,150 @@ func (h *DEvent) Sink(db *storage.DBClient) bool {
 			}
 		}
 
+		// insert address mints
+		if items := dm.AddressMints[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddAddressMints(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert address mints records. err=%s", err)
+				return err
+			}
+		}
+
+		// update address mints
+		if items := dm.AddressMints[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateAddressMints(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update address mints records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert used mint nonces
+		if len(dm.MintNonces) > 0 {
+			if err := db.BatchAddMintNonces(tx, dm.MintNonces); err != nil {
+				xylog.Logger.Errorf("failed insert mint nonces records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert listings
+		if items := dm.Listings[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddListings(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert listings records. err=%s", err)
+				return err
+			}
+		}
+
+		// update listings
+		if items := dm.Listings[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateListings(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update listings records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert locks
+		if items := dm.Locks[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddLocks(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert locks records. err=%s", err)
+				return err
+			}
+		}
+
+		// update locks
+		if items := dm.Locks[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateLocks(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update locks records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert ethscriptions
+		if items := dm.Ethscriptions[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddEthscriptions(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert ethscriptions records. err=%s", err)
+				return err
+			}
+		}
+
+		// update ethscriptions owners
+		if items := dm.Ethscriptions[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateEthscriptions(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update ethscriptions records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert names
+		if items := dm.Names[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddNames(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert names records. err=%s", err)
+				return err
+			}
+		}
+
+		// update names owners & records
+		if items := dm.Names[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateNames(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update names records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert wrapped balances
+		if items := dm.WrappedBalances[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddWrappedBalances(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert wrapped balances records. err=%s", err)
+				return err
+			}
+		}
+
+		// update wrapped balances
+		if items := dm.WrappedBalances[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateWrappedBalances(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update wrapped balances records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert trades
+		if len(dm.Trades) > 0 {
+			if err := db.BatchAddTrades(tx, dm.Trades); err != nil {
+				xylog.Logger.Errorf("failed insert trades records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert market stats
+		if items := dm.MarketStats[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddMarketStats(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert market stats records. err=%s", err)
+				return err
+			}
+		}
+
+		// update market stats
+		if items := dm.MarketStats[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchUpdateMarketStats(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update market stats records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert utxos held balances
+		if items := dm.UTXOs[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddUTXOs(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert utxos records. err=%s", err)
+				return err
+			}
+		}
+
+		// spend utxos held balances
+		if items := dm.UTXOs[DBActionUpdate]; len(items) > 0 {
+			if err := db.BatchSpendUTXOs(tx, chain, items); err != nil {
+				xylog.Logger.Errorf("failed update utxos records. err=%s", err)
+				return err
+			}
+		}
+
 		// record block status
 		if err := db.SaveLastBlock(tx, dm.BlockStatus); err != nil {
 			xylog.Logger.Errorf("failed to save block information. err=%s", err)
@@ -23,9 +23,12 @@
 package devents
 
 import (
+	"encoding/json"
 	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/xylog"
+	"strings"
 	"time"
 )
 
@@ -43,16 +46,57 @@ type DBModelEvent struct {
 	Balances         map[DBAction][]*model.Balances
 	AddressTxs       []*model.AddressTxs
 	BalanceTxs       []*model.BalanceTxn
+	AddressMints     map[DBAction][]*model.AddressMints
+	MintNonces       []*model.MintNonces
+	Listings         map[DBAction]*model.Listings
+	Locks            map[DBAction]*model.Locks
+	Ethscriptions    map[DBAction]*model.Ethscriptions
+	Names            map[DBAction]*model.Names
+	WrappedBalances  map[DBAction][]*model.WrappedBalances
+	Trades           []*model.Trades
+	MarketStats      map[DBAction]*model.MarketStats
+	UTXOs            map[DBAction][]*model.UTXO
 }
 
 func (tc *TxResultHandler) BuildModel(r *TxResult) *DBModelEvent {
 	dm := &DBModelEvent{}
 
+	// released by block height, no tx
+	if r.Unlock != nil {
+		dm.Locks = tc.BuildLock(r)
+		dm.BalanceTxs, dm.Balances = tc.BuildBalance(r)
+		return dm
+	}
+
 	dm.Tx = tc.BuildTx(r)
+
+	// non-fungible inscriptions have no tick stats & balances
+	if r.Ethscription != nil {
+		dm.Ethscriptions = tc.BuildEthscription(r)
+		return dm
+	}
+
+	if r.Name != nil {
+		dm.Names = tc.BuildName(r)
+		return dm
+	}
+
+	// wrapped tokens are held in wrapper contracts, inscription balances unchanged
+	if r.Wrap != nil {
+		dm.WrappedBalances = tc.BuildWrappedBalance(r)
+		return dm
+	}
+
 	dm.Inscriptions = tc.BuildInscription(r)
 	dm.InscriptionStats = tc.BuildInscriptionStat(r)
 	dm.BalanceTxs, dm.Balances = tc.BuildBalance(r)
 	dm.AddressTxs = tc.BuildAddressTxs(r)
+	dm.AddressMints = tc.BuildAddressMint(r)
+	dm.MintNonces = tc.BuildMintNonce(r)
+	dm.Listings = tc.BuildListing(r)
+	dm.Locks = tc.BuildLock(r)
+	dm.Trades, dm.MarketStats = tc.BuildTrade(r)
+	dm.UTXOs = tc.BuildUTXO(r)
 	return dm
 }
 
@@ -75,10 +119,392 @@ func (tc *TxResultHandler) BuildInscription(e *TxResult) map[DBAction]*model.Ins
 		DeployHash:   e.Tx.Hash,
 		DeployTime:   time.Unix(int64(e.Block.Time), 0),
 		Decimals:     e.Deploy.Decimal,
+
+		WalletLimit:    e.Deploy.WalletLimit,
+		StartBlock:     e.Deploy.StartBlock,
+		EndBlock:       e.Deploy.EndBlock,
+		BlockMintLimit: e.Deploy.BlockMintLimit,
+		MerkleRoot:     e.Deploy.MerkleRoot,
+		ConfusableWith: e.Deploy.Confusable,
+		Premine:        e.Deploy.Premine,
+		MintPrice:      e.Deploy.Price,
+		FeeTo:          e.Deploy.FeeTo,
+		TickId:         e.Deploy.Id,
+		Workc:          e.Deploy.Workc,
+	}
+	return ret
+}
+
+func (tc *TxResultHandler) BuildAddressMint(e *TxResult) map[DBAction][]*model.AddressMints {
+	if e.Mint == nil {
+		return nil
+	}
+
+	// minter & allowlist claimer records, the same record if minter is claimer
+	addresses := []string{e.Mint.Minter}
+	inits := map[string]bool{
+		strings.ToLower(e.Mint.Minter): e.Mint.RecordInit,
+	}
+	if e.Mint.Claimer != "" {
+		key := strings.ToLower(e.Mint.Claimer)
+		if _, ok := inits[key]; !ok {
+			addresses = append(addresses, e.Mint.Claimer)
+		}
+		inits[key] = inits[key] || e.Mint.ClaimInit
+	}
+
+	ret := make(map[DBAction][]*model.AddressMints, 2)
+	for _, address := range addresses {
+		init := inits[strings.ToLower(address)]
+		ok, item := tc.cache.AddressMint.Get(e.MD.Protocol, e.MD.Tick, address)
+		if !ok {
+			continue
+		}
+
+		action := DBActionUpdate
+		if init {
+			action = DBActionCreate
+		}
+		ret[action] = append(ret[action], &model.AddressMints{
+			SID:      item.SID,
+			Chain:    e.MD.Chain,
+			Protocol: e.MD.Protocol,
+			Address:  address,
+			Tick:     e.MD.Tick,
+			Minted:   item.Minted,
+			MintCnt:  item.MintCnt,
+			Claimed:  item.Claimed,
+		})
+	}
+	return ret
+}
+
+func (tc *TxResultHandler) BuildListing(e *TxResult) map[DBAction]*model.Listings {
+	if e.Listing == nil {
+		return nil
+	}
+
+	ok, item := tc.cache.Listing.Get(e.Listing.ListId)
+	if !ok {
+		return nil
+	}
+
+	action := DBActionUpdate
+	if e.Listing.Status == model.ListingStatusListed {
+		action = DBActionCreate
+	}
+	return map[DBAction]*model.Listings{
+		action: {
+			SID:      item.SID,
+			Chain:    e.MD.Chain,
+			Protocol: e.MD.Protocol,
+			Tick:     e.MD.Tick,
+			ListId:   e.Listing.ListId,
+			Seller:   item.Seller,
+			Market:   item.Market,
+			Buyer:    item.Buyer,
+			Amount:   item.Amount,
+			Price:    item.Price,
+			Status:   item.Status,
+			TxHash:   e.Tx.Hash,
+		},
+	}
+}
+
+func (tc *TxResultHandler) BuildLock(e *TxResult) map[DBAction]*model.Locks {
+	// fully released locks are removed from cache, states recorded by cache updating
+	if e.Unlock != nil {
+		return map[DBAction]*model.Locks{
+			DBActionUpdate: {
+				SID:      e.Unlock.SID,
+				Released: e.Unlock.Released,
+			},
+		}
+	}
+
+	if e.Lock == nil {
+		return nil
+	}
+
+	ok, item := tc.cache.Locks.Get(e.Lock.LockId)
+	if !ok {
+		return nil
+	}
+
+	schedule, err := json.Marshal(item.Tranches)
+	if err != nil {
+		xylog.Logger.Errorf("lock schedule json encode err:%v, lock[%s]", err, e.Lock.LockId)
+		return nil
+	}
+	return map[DBAction]*model.Locks{
+		DBActionCreate: {
+			SID:          item.SID,
+			Chain:        e.MD.Chain,
+			Protocol:     e.MD.Protocol,
+			Tick:         e.MD.Tick,
+			LockId:       e.Lock.LockId,
+			Address:      item.Address,
+			Amount:       item.Amount,
+			Released:     item.Released,
+			Schedule:     string(schedule),
+			UnlockHeight: item.Tranches[len(item.Tranches)-1].Height,
+			TxHash:       e.Tx.Hash,
+			BlockHeight:  e.Block.Number.Uint64(),
+		},
+	}
+}
+
+// BuildMintNonce used proof of work nonce of the minter
+func (tc *TxResultHandler) BuildMintNonce(e *TxResult) []*model.MintNonces {
+	if e.Mint == nil || e.Mint.Nonce == "" {
+		return nil
+	}
+
+	return []*model.MintNonces{
+		{
+			Chain:    e.MD.Chain,
+			Protocol: e.MD.Protocol,
+			Tick:     e.MD.Tick,
+			Address:  e.Mint.Minter,
+			Nonce:    e.Mint.Nonce,
+			TxHash:   e.Tx.Hash,
+		},
+	}
+}
+
+// BuildTrade filled trade & the market stats of the tick, stats of listing changes updated for the floor price
+func (tc *TxResultHandler) BuildTrade(e *TxResult) ([]*model.Trades, map[DBAction]*model.MarketStats) {
+	if e.Trade == nil && e.Listing == nil {
+		return nil, nil
+	}
+
+	ok, stats := tc.cache.Market.Get(e.MD.Protocol, e.MD.Tick)
+	if !ok {
+		return nil, nil
+	}
+
+	if e.Trade == nil {
+		return nil, map[DBAction]*model.MarketStats{
+			DBActionUpdate: tc.buildMarketStats(e, stats),
+		}
+	}
+
+	trades := []*model.Trades{
+		{
+			Chain:       e.MD.Chain,
+			Protocol:    e.MD.Protocol,
+			Tick:        e.MD.Tick,
+			ListId:      e.Trade.ListId,
+			Market:      e.Trade.Market,
+			Seller:      e.Trade.Seller,
+			Buyer:       e.Trade.Buyer,
+			Amount:      e.Trade.Amount,
+			UnitPrice:   e.Trade.UnitPrice,
+			Total:       e.Trade.Total,
+			BlockHeight: e.Block.Number.Uint64(),
+			BlockTime:   time.Unix(int64(e.Block.Time), 0),
+			TxHash:      e.Tx.Hash,
+		},
+	}
+
+	action := DBActionUpdate
+	if e.Trade.Init {
+		action = DBActionCreate
+	}
+	return trades, map[DBAction]*model.MarketStats{
+		action: tc.buildMarketStats(e, stats),
+	}
+}
+
+func (tc *TxResultHandler) buildMarketStats(e *TxResult, stats *dcache.MarketStats) *model.MarketStats {
+	return &model.MarketStats{
+		SID:           stats.SID,
+		Chain:         e.MD.Chain,
+		Protocol:      e.MD.Protocol,
+		Tick:          e.MD.Tick,
+		Volume:        stats.Volume,
+		TradeCnt:      stats.TradeCnt,
+		LastPrice:     stats.LastPrice,
+		FloorPrice:    stats.FloorPrice,
+		Volume24h:     stats.Volume24h,
+		TradeCnt24h:   stats.TradeCnt24h,
+		LastTradeTime: stats.LastTime,
+	}
+}
+
+// BuildMarketRefresh
+/***************************************
+ * market stats of ticks whose 24h window stats changed as of the block time, without trades or listing changes
+ ***************************************/
+func (tc *TxResultHandler) BuildMarketRefresh(chain string, ts uint64) []*DBModelEvent {
+	if tc.cache.Market == nil {
+		return nil
+	}
+
+	items := tc.cache.Market.Expire(ts)
+	events := make([]*DBModelEvent, 0, len(items))
+	for _, stats := range items {
+		r := &TxResult{MD: &MetaData{Chain: chain, Protocol: stats.Protocol, Tick: stats.Tick}}
+		events = append(events, &DBModelEvent{
+			MarketStats: map[DBAction]*model.MarketStats{
+				DBActionUpdate: tc.buildMarketStats(r, stats),
+			},
+		})
+	}
+	return events
+}
+
+// BuildUTXO created outpoints & spent outpoints of utxo based protocols
+func (tc *TxResultHandler) BuildUTXO(e *TxResult) map[DBAction][]*model.UTXO {
+	if e.UTXO == nil {
+		return nil
+	}
+
+	ret := map[DBAction][]*model.UTXO{
+		DBActionCreate: make([]*model.UTXO, 0, len(e.UTXO.Created)),
+		DBActionUpdate: make([]*model.UTXO, 0, len(e.UTXO.Spent)),
+	}
+	for _, item := range e.UTXO.Created {
+		ret[DBActionCreate] = append(ret[DBActionCreate], &model.UTXO{
+			Chain:    e.MD.Chain,
+			Protocol: e.MD.Protocol,
+			Address:  item.Address,
+			Tick:     e.MD.Tick,
+			Amount:   item.Amount,
+			TxHash:   e.Tx.Hash,
+			Outpoint: item.Outpoint,
+			Status:   model.UTXOStatusUnspent,
+		})
+	}
+	for _, item := range e.UTXO.Spent {
+		ret[DBActionUpdate] = append(ret[DBActionUpdate], &model.UTXO{
+			Chain:    e.MD.Chain,
+			Protocol: e.MD.Protocol,
+			Tick:     e.MD.Tick,
+			Outpoint: item.Outpoint,
+			Status:   model.UTXOStatusSpent,
+		})
+	}
+	return ret
+}
+
+func (tc *TxResultHandler) BuildEthscription(e *TxResult) map[DBAction]*model.Ethscriptions {
+	ok, item := tc.cache.Ethscription.Get(e.Ethscription.Id)
+	if !ok {
+		return nil
+	}
+
+	if e.MD.Operate != OperateCreate {
+		return map[DBAction]*model.Ethscriptions{
+			DBActionUpdate: {
+				SID:    item.SID,
+				Owner:  item.Owner,
+				TxHash: e.Tx.Hash,
+			},
+		}
+	}
+
+	return map[DBAction]*model.Ethscriptions{
+		DBActionCreate: {
+			SID:            item.SID,
+			Chain:          e.MD.Chain,
+			EthscriptionId: e.Ethscription.Id,
+			Creator:        item.Creator,
+			Owner:          item.Owner,
+			ContentType:    e.Ethscription.ContentType,
+			ContentHash:    e.Ethscription.ContentHash,
+			Content:        e.Ethscription.Content,
+			Esip6:          e.Ethscription.Esip6,
+			BlockHeight:    e.Block.Number.Uint64(),
+			TxHash:         e.Tx.Hash,
+		},
+	}
+}
+
+func (tc *TxResultHandler) BuildName(e *TxResult) map[DBAction]*model.Names {
+	ok, item := tc.cache.Names.Get(e.Name.Name)
+	if !ok {
+		return nil
+	}
+
+	if e.MD.Operate != OperateRegister {
+		return map[DBAction]*model.Names{
+			DBActionUpdate: {
+				SID:     item.SID,
+				Owner:   item.Owner,
+				Address: item.Address,
+				Avatar:  item.Avatar,
+				Text:    item.Text,
+				TxHash:  e.Tx.Hash,
+			},
+		}
+	}
+
+	return map[DBAction]*model.Names{
+		DBActionCreate: {
+			SID:         item.SID,
+			Chain:       e.MD.Chain,
+			Protocol:    e.MD.Protocol,
+			Name:        e.Name.Name,
+			Owner:       item.Owner,
+			Address:     item.Address,
+			Avatar:      item.Avatar,
+			Text:        item.Text,
+			BlockHeight: e.Block.Number.Uint64(),
+			TxHash:      e.Tx.Hash,
+		},
+	}
+}
+
+func (tc *TxResultHandler) BuildWrappedBalance(e *TxResult) map[DBAction][]*model.WrappedBalances {
+	w := e.Wrap
+	ret := make(map[DBAction][]*model.WrappedBalances, 2)
+	if w.From != "" {
+		_, item := tc.cache.Wrapped.Get(e.MD.Protocol, e.MD.Tick, w.Contract, w.From)
+		ret[DBActionUpdate] = append(ret[DBActionUpdate], &model.WrappedBalances{
+			SID:    item.SID,
+			Amount: item.Amount,
+		})
+	}
+
+	if w.To != "" {
+		_, item := tc.cache.Wrapped.Get(e.MD.Protocol, e.MD.Tick, w.Contract, w.To)
+		if !w.Init {
+			ret[DBActionUpdate] = append(ret[DBActionUpdate], &model.WrappedBalances{
+				SID:    item.SID,
+				Amount: item.Amount,
+			})
+			return ret
+		}
+
+		ret[DBActionCreate] = append(ret[DBActionCreate], &model.WrappedBalances{
+			SID:      item.SID,
+			Chain:    e.MD.Chain,
+			Protocol: e.MD.Protocol,
+			Tick:     e.MD.Tick,
+			Contract: w.Contract,
+			Address:  w.To,
+			Amount:   item.Amount,
+		})
 	}
 	return ret
 }
 
+// wrappedSupply inscriptions held by the bridges of the tick
+func (tc *TxResultHandler) wrappedSupply(protocol, tick string) decimal.Decimal {
+	supply := decimal.Zero
+	if tc.cache.Wrapped == nil {
+		return supply
+	}
+
+	for _, address := range tc.cache.Wrapped.Bridges(protocol, tick) {
+		if ok, balance := tc.cache.Balance.Get(protocol, tick, address); ok {
+			supply = supply.Add(balance.Overall)
+		}
+	}
+	return supply
+}
+
 func (tc *TxResultHandler) BuildInscriptionStat(e *TxResult) map[DBAction]*model.InscriptionsStats {
 	_, d := tc.cache.InscriptionStats.Get(e.MD.Protocol, e.MD.Tick)
 
@@ -88,19 +514,23 @@ func (tc *TxResultHandler) BuildInscriptionStat(e *TxResult) map[DBAction]*model
 		Protocol: e.MD.Protocol,
 		Tick:     e.MD.Tick,
 		Minted:   d.Minted,
+		Burned:   d.Burned,
+		Wrapped:  tc.wrappedSupply(e.MD.Protocol, e.MD.Tick),
 		Holders:  uint64(d.Holders),
 		TxCnt:    d.TxCnt,
+		LastSN:   d.LastSN,
+		Fees:     d.Fees,
 	}
 
 	// update mint stats
 	if e.Mint != nil {
-		// first mint block record
-		if d.Minted.Equal(e.Mint.Amount) {
+		// first mint block record, premine excluded
+		_, inscription := tc.cache.Inscription.Get(e.MD.Protocol, e.MD.Tick)
+		if d.Minted.Equal(inscription.Premine.Add(e.Mint.Amount)) {
 			data.MintFirstBlock = e.Block.Number.Uint64()
 		}
 
 		// final mint block record
-		_, inscription := tc.cache.Inscription.Get(e.MD.Protocol, e.MD.Tick)
 		if inscription.TotalSupply.LessThanOrEqual(d.Minted) {
 			data.MintLastBlock = e.Block.Number.Uint64()
 
@@ -110,6 +540,14 @@ func (tc *TxResultHandler) BuildInscriptionStat(e *TxResult) map[DBAction]*model
 	}
 
 	if e.Deploy != nil {
+		// fully premined tick, minting completed at deploy
+		if e.Deploy.Premine.IsPositive() && e.Deploy.MaxSupply.LessThanOrEqual(d.Minted) {
+			data.MintFirstBlock = e.Block.Number.Uint64()
+			data.MintLastBlock = e.Block.Number.Uint64()
+
+			ts := time.Unix(int64(e.Block.Time), 0)
+			data.MintCompletedTime = &ts
+		}
 		return map[DBAction]*model.InscriptionsStats{
 			DBActionCreate: data,
 		}
@@ -127,10 +565,10 @@ type AddressTxEvent struct {
 
 func (tc *TxResultHandler) BuildAddressTxEvents(e *TxResult) []*AddressTxEvent {
 	items := make([]*AddressTxEvent, 0, 10)
-	if e.Deploy != nil {
+	if e.Deploy != nil && e.UTXO == nil {
 		items = append(items, &AddressTxEvent{
 			Address: e.Tx.From,
-			Amount:  decimal.Zero,
+			Amount:  e.Deploy.Premine,
 		})
 	}
 
@@ -147,6 +585,11 @@ func (tc *TxResultHandler) BuildAddressTxEvents(e *TxResult) []*AddressTxEvent {
 			sendTotalAmount = sendTotalAmount.Add(item.Amount)
 		}
 
+		// burns of a mixed transfer are merged into the sender's record
+		if e.mixedBurn() {
+			sendTotalAmount = sendTotalAmount.Add(e.Burn.Amount)
+		}
+
 		items = append(items, &AddressTxEvent{
 			Address: e.Transfer.Sender,
 			Amount:  sendTotalAmount,
@@ -159,6 +602,38 @@ func (tc *TxResultHandler) BuildAddressTxEvents(e *TxResult) []*AddressTxEvent {
 			})
 		}
 	}
+
+	if e.Burn != nil && !e.mixedBurn() {
+		items = append(items, &AddressTxEvent{
+			Address: e.Burn.Sender,
+			Amount:  e.Burn.Amount,
+		})
+	}
+
+	// filled listings are recorded by transfer
+	if e.Listing != nil && e.Transfer == nil {
+		items = append(items, &AddressTxEvent{
+			Address: e.Listing.Seller,
+			Amount:  e.Listing.Amount,
+		})
+	}
+
+	// locks to other addresses are recorded by transfer
+	if e.Lock != nil && e.Transfer == nil {
+		items = append(items, &AddressTxEvent{
+			Address: e.Lock.Address,
+			Amount:  e.Lock.Amount,
+		})
+	}
+
+	if e.UTXO != nil {
+		for _, item := range e.UTXO.changes {
+			items = append(items, &AddressTxEvent{
+				Address: item.Address,
+				Amount:  item.Amount.Abs(),
+			})
+		}
+	}
 	return items
 }
 
@@ -195,11 +670,20 @@ func (tc *TxResultHandler) getEventByOperate(operate string) model.TxEvent {
 		return model.TransactionEventDelist
 	case OperateExchange:
 		return model.TransactionEventExchange
+	case OperateBurn:
+		return model.TransactionEventBurn
+	case OperateLock:
+		return model.TransactionEventLock
+	case OperateVest:
+		return model.TransactionEventVest
+	case OperateUnlock:
+		return model.TransactionEventUnlock
 	}
 	return model.TxEvent(0)
 }
 
 type BalanceTxEvent struct {
+	Event            model.TxEvent // overrides the operate event if set
 	Action           DBAction
 	SID              uint64
 	Address          string
@@ -210,6 +694,19 @@ type BalanceTxEvent struct {
 
 func (tc *TxResultHandler) BuildBalanceTxEvents(e *TxResult) []BalanceTxEvent {
 	items := make([]BalanceTxEvent, 0, 10)
+	if e.Deploy != nil && e.Deploy.Premine.IsPositive() && e.UTXO == nil {
+		_, balance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Deploy.Deployer)
+		items = append(items, BalanceTxEvent{
+			Event:            model.TransactionEventPremine,
+			Action:           DBActionCreate,
+			SID:              balance.SID,
+			Address:          e.Deploy.Deployer,
+			Amount:           e.Deploy.Premine,
+			AvailableBalance: balance.Available,
+			OverallBalance:   balance.Overall,
+		})
+	}
+
 	if e.Mint != nil {
 		_, balance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Mint.Minter)
 		action := DBActionUpdate
@@ -232,6 +729,11 @@ func (tc *TxResultHandler) BuildBalanceTxEvents(e *TxResult) []BalanceTxEvent {
 			sendTotalAmount = sendTotalAmount.Add(item.Amount)
 		}
 
+		// burns of a mixed transfer are merged into the sender's event
+		if e.mixedBurn() {
+			sendTotalAmount = sendTotalAmount.Add(e.Burn.Amount)
+		}
+
 		_, senderBalance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Transfer.Sender)
 		items = append(items, BalanceTxEvent{
 			Action:           DBActionUpdate,
@@ -258,6 +760,74 @@ func (tc *TxResultHandler) BuildBalanceTxEvents(e *TxResult) []BalanceTxEvent {
 			})
 		}
 	}
+
+	if e.Burn != nil && !e.mixedBurn() {
+		_, senderBalance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Burn.Sender)
+		items = append(items, BalanceTxEvent{
+			Action:           DBActionUpdate,
+			SID:              senderBalance.SID,
+			Address:          e.Burn.Sender,
+			Amount:           e.Burn.Amount.Neg(),
+			AvailableBalance: senderBalance.Available,
+			OverallBalance:   senderBalance.Overall,
+		})
+	}
+
+	// list & delist only change seller's available balance
+	if e.Listing != nil && e.Transfer == nil {
+		_, sellerBalance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Listing.Seller)
+		items = append(items, BalanceTxEvent{
+			Action:           DBActionUpdate,
+			SID:              sellerBalance.SID,
+			Address:          e.Listing.Seller,
+			Amount:           decimal.Zero,
+			AvailableBalance: sellerBalance.Available,
+			OverallBalance:   sellerBalance.Overall,
+		})
+	}
+
+	// lock & unlock only change available balance, locks to other addresses recorded by transfer
+	if e.Lock != nil && e.Transfer == nil {
+		_, balance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Lock.Address)
+		items = append(items, BalanceTxEvent{
+			Action:           DBActionUpdate,
+			SID:              balance.SID,
+			Address:          e.Lock.Address,
+			Amount:           decimal.Zero,
+			AvailableBalance: balance.Available,
+			OverallBalance:   balance.Overall,
+		})
+	}
+
+	if e.Unlock != nil {
+		_, balance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Unlock.Address)
+		items = append(items, BalanceTxEvent{
+			Action:           DBActionUpdate,
+			SID:              balance.SID,
+			Address:          e.Unlock.Address,
+			Amount:           decimal.Zero,
+			AvailableBalance: balance.Available,
+			OverallBalance:   balance.Overall,
+		})
+	}
+
+	if e.UTXO != nil {
+		for _, item := range e.UTXO.changes {
+			_, balance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, item.Address)
+			action := DBActionUpdate
+			if item.Init {
+				action = DBActionCreate
+			}
+			items = append(items, BalanceTxEvent{
+				Action:           action,
+				SID:              balance.SID,
+				Address:          item.Address,
+				Amount:           item.Amount,
+				AvailableBalance: balance.Available,
+				OverallBalance:   balance.Overall,
+			})
+		}
+	}
 	return items
 }
 
@@ -266,16 +836,21 @@ func (tc *TxResultHandler) BuildBalance(e *TxResult) (txns []*model.BalanceTxn,
 	txns = make([]*model.BalanceTxn, 0, len(balanceTxEvents))
 	balances = make(map[DBAction][]*model.Balances, 2)
 	for _, event := range balanceTxEvents {
+		txEvent := event.Event
+		if txEvent == 0 {
+			txEvent = tc.getEventByOperate(e.MD.Operate)
+		}
 		txns = append(txns, &model.BalanceTxn{
 			Chain:     e.MD.Chain,
 			Protocol:  e.MD.Protocol,
-			Event:     tc.getEventByOperate(e.MD.Operate),
+			Event:     txEvent,
 			Address:   event.Address,
 			Tick:      e.MD.Tick,
 			Amount:    event.Amount,
 			Balance:   event.OverallBalance,
 			Available: event.AvailableBalance,
-			TxHash:    e.Tx.Hash,
+			TxHash:    tc.balanceTxHash(e),
+			ParseMode: e.MD.ParseMode(),
 			CreatedAt: time.Unix(int64(e.Block.Time), 0),
 		})
 
@@ -290,11 +865,20 @@ func (tc *TxResultHandler) BuildBalance(e *TxResult) (txns []*model.BalanceTxn,
 			Tick:      e.MD.Tick,
 			Balance:   event.OverallBalance,
 			Available: event.AvailableBalance,
+			ParseMode: e.MD.ParseMode(),
 		})
 	}
 	return txns, balances
 }
 
+// balanceTxHash releases of locks are recorded by the lock tx
+func (tc *TxResultHandler) balanceTxHash(e *TxResult) string {
+	if e.Tx == nil && e.Unlock != nil {
+		return e.Unlock.LockId
+	}
+	return e.Tx.Hash
+}
+
 func (tc *TxResultHandler) BuildTx(e *TxResult) *model.Transaction {
 	return &model.Transaction{
 		Chain:           e.MD.Chain,
@@ -309,6 +893,7 @@ func (tc *TxResultHandler) BuildTx(e *TxResult) *model.Transaction {
 		Tick:            e.MD.Tick,
 		Gas:             e.Tx.Gas.Int64(),
 		GasPrice:        e.Tx.GasPrice.Int64(),
+		RuleVersion:     e.MD.RuleVersion,
 	}
 }
 
@@ -319,6 +904,16 @@ type DBModelsFattened struct {
 	Txs              []*model.Transaction
 	AddressTxs       []*model.AddressTxs
 	BalanceTxs       []*model.BalanceTxn
+	AddressMints     map[DBAction][]*model.AddressMints
+	MintNonces       []*model.MintNonces
+	Listings         map[DBAction][]*model.Listings
+	Locks            map[DBAction][]*model.Locks
+	Ethscriptions    map[DBAction][]*model.Ethscriptions
+	Names            map[DBAction][]*model.Names
+	WrappedBalances  map[DBAction][]*model.WrappedBalances
+	Trades           []*model.Trades
+	MarketStats      map[DBAction][]*model.MarketStats
+	UTXOs            map[DBAction][]*model.UTXO
 	BlockStatus      *model.BlockStatus
 }
 
@@ -329,6 +924,16 @@ type DBModels struct {
 	Txs              map[string]*model.Transaction
 	AddressTxs       []*model.AddressTxs
 	BalanceTxs       []*model.BalanceTxn
+	AddressMints     map[DBAction]map[uint64]*model.AddressMints
+	MintNonces       []*model.MintNonces
+	Listings         map[DBAction]map[uint64]*model.Listings
+	Locks            map[DBAction]map[uint64]*model.Locks
+	Ethscriptions    map[DBAction]map[uint64]*model.Ethscriptions
+	Names            map[DBAction]map[uint64]*model.Names
+	WrappedBalances  map[DBAction]map[uint64]*model.WrappedBalances
+	Trades           []*model.Trades
+	MarketStats      map[DBAction]map[uint32]*model.MarketStats
+	UTXOs            map[DBAction][]*model.UTXO
 }
 
 func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
@@ -345,9 +950,43 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 			DBActionCreate: make(map[uint64]*model.Balances, 100),
 			DBActionUpdate: make(map[uint64]*model.Balances, 100),
 		},
+		AddressMints: map[DBAction]map[uint64]*model.AddressMints{
+			DBActionCreate: make(map[uint64]*model.AddressMints, 100),
+			DBActionUpdate: make(map[uint64]*model.AddressMints, 100),
+		},
+		Listings: map[DBAction]map[uint64]*model.Listings{
+			DBActionCreate: make(map[uint64]*model.Listings, 100),
+			DBActionUpdate: make(map[uint64]*model.Listings, 100),
+		},
+		Locks: map[DBAction]map[uint64]*model.Locks{
+			DBActionCreate: make(map[uint64]*model.Locks, 100),
+			DBActionUpdate: make(map[uint64]*model.Locks, 100),
+		},
+		Ethscriptions: map[DBAction]map[uint64]*model.Ethscriptions{
+			DBActionCreate: make(map[uint64]*model.Ethscriptions, 100),
+			DBActionUpdate: make(map[uint64]*model.Ethscriptions, 100),
+		},
+		Names: map[DBAction]map[uint64]*model.Names{
+			DBActionCreate: make(map[uint64]*model.Names, 100),
+			DBActionUpdate: make(map[uint64]*model.Names, 100),
+		},
+		WrappedBalances: map[DBAction]map[uint64]*model.WrappedBalances{
+			DBActionCreate: make(map[uint64]*model.WrappedBalances, 100),
+			DBActionUpdate: make(map[uint64]*model.WrappedBalances, 100),
+		},
+		MarketStats: map[DBAction]map[uint32]*model.MarketStats{
+			DBActionCreate: make(map[uint32]*model.MarketStats, 100),
+			DBActionUpdate: make(map[uint32]*model.MarketStats, 100),
+		},
 		Txs:        make(map[string]*model.Transaction, len(blocksEvents)*2),
 		AddressTxs: make([]*model.AddressTxs, 0, len(blocksEvents)*2),
 		BalanceTxs: make([]*model.BalanceTxn, 0, len(blocksEvents)*2),
+		MintNonces: make([]*model.MintNonces, 0, 100),
+		Trades:     make([]*model.Trades, 0, 100),
+		UTXOs: map[DBAction][]*model.UTXO{
+			DBActionCreate: make([]*model.UTXO, 0, 100),
+			DBActionUpdate: make([]*model.UTXO, 0, 100),
+		},
 	}
 	for _, blockEvent := range blocksEvents {
 		for _, event := range blockEvent.Items {
@@ -376,11 +1015,14 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 				dm.InscriptionStats[action][item.SID] = item
 			}
 
-			txIdx := event.Tx.TxHash
-			if _, ok := dm.Txs[txIdx]; ok {
-				xylog.Logger.Debugf("tx[%s] exist & force update", txIdx)
+			// releases of locks have no tx
+			if event.Tx != nil {
+				txIdx := event.Tx.TxHash
+				if _, ok := dm.Txs[txIdx]; ok {
+					xylog.Logger.Debugf("tx[%s] exist & force update", txIdx)
+				}
+				dm.Txs[txIdx] = event.Tx
 			}
-			dm.Txs[txIdx] = event.Tx
 
 			if len(event.AddressTxs) > 0 {
 				dm.AddressTxs = append(dm.AddressTxs, event.AddressTxs...)
@@ -398,6 +1040,50 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 					dm.Balances[action][item.SID] = item
 				}
 			}
+
+			for action, items := range event.AddressMints {
+				for _, item := range items {
+					dm.AddressMints[action][item.SID] = item
+				}
+			}
+
+			for action, item := range event.Listings {
+				dm.Listings[action][item.SID] = item
+			}
+
+			for action, item := range event.Locks {
+				dm.Locks[action][item.SID] = item
+			}
+
+			for action, item := range event.Ethscriptions {
+				dm.Ethscriptions[action][item.SID] = item
+			}
+
+			for action, item := range event.Names {
+				dm.Names[action][item.SID] = item
+			}
+
+			for action, items := range event.WrappedBalances {
+				for _, item := range items {
+					dm.WrappedBalances[action][item.SID] = item
+				}
+			}
+
+			if len(event.MintNonces) > 0 {
+				dm.MintNonces = append(dm.MintNonces, event.MintNonces...)
+			}
+
+			if len(event.Trades) > 0 {
+				dm.Trades = append(dm.Trades, event.Trades...)
+			}
+
+			for action, item := range event.MarketStats {
+				dm.MarketStats[action][item.SID] = item
+			}
+
+			for action, items := range event.UTXOs {
+				dm.UTXOs[action] = append(dm.UTXOs[action], items...)
+			}
 		}
 	}
 
@@ -422,6 +1108,37 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 			DBActionCreate: make([]*model.Balances, 0, 100),
 			DBActionUpdate: make([]*model.Balances, 0, 100),
 		},
+		AddressMints: map[DBAction][]*model.AddressMints{
+			DBActionCreate: make([]*model.AddressMints, 0, len(dm.AddressMints[DBActionCreate])),
+			DBActionUpdate: make([]*model.AddressMints, 0, len(dm.AddressMints[DBActionUpdate])),
+		},
+		Listings: map[DBAction][]*model.Listings{
+			DBActionCreate: make([]*model.Listings, 0, len(dm.Listings[DBActionCreate])),
+			DBActionUpdate: make([]*model.Listings, 0, len(dm.Listings[DBActionUpdate])),
+		},
+		Locks: map[DBAction][]*model.Locks{
+			DBActionCreate: make([]*model.Locks, 0, len(dm.Locks[DBActionCreate])),
+			DBActionUpdate: make([]*model.Locks, 0, len(dm.Locks[DBActionUpdate])),
+		},
+		Ethscriptions: map[DBAction][]*model.Ethscriptions{
+			DBActionCreate: make([]*model.Ethscriptions, 0, len(dm.Ethscriptions[DBActionCreate])),
+			DBActionUpdate: make([]*model.Ethscriptions, 0, len(dm.Ethscriptions[DBActionUpdate])),
+		},
+		Names: map[DBAction][]*model.Names{
+			DBActionCreate: make([]*model.Names, 0, len(dm.Names[DBActionCreate])),
+			DBActionUpdate: make([]*model.Names, 0, len(dm.Names[DBActionUpdate])),
+		},
+		WrappedBalances: map[DBAction][]*model.WrappedBalances{
+			DBActionCreate: make([]*model.WrappedBalances, 0, len(dm.WrappedBalances[DBActionCreate])),
+			DBActionUpdate: make([]*model.WrappedBalances, 0, len(dm.WrappedBalances[DBActionUpdate])),
+		},
+		MarketStats: map[DBAction][]*model.MarketStats{
+			DBActionCreate: make([]*model.MarketStats, 0, len(dm.MarketStats[DBActionCreate])),
+			DBActionUpdate: make([]*model.MarketStats, 0, len(dm.MarketStats[DBActionUpdate])),
+		},
+		MintNonces:  dm.MintNonces,
+		Trades:      dm.Trades,
+		UTXOs:       dm.UTXOs,
 		Txs:         make([]*model.Transaction, 0, len(dm.Txs)),
 		AddressTxs:  dm.AddressTxs,
 		BalanceTxs:  dm.BalanceTxs,
@@ -456,5 +1173,61 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 	for _, item := range dm.Balances[DBActionUpdate] {
 		dmf.Balances[DBActionUpdate] = append(dmf.Balances[DBActionUpdate], item)
 	}
+
+	// flatten address mints records
+	for _, item := range dm.AddressMints[DBActionCreate] {
+		dmf.AddressMints[DBActionCreate] = append(dmf.AddressMints[DBActionCreate], item)
+	}
+	for _, item := range dm.AddressMints[DBActionUpdate] {
+		dmf.AddressMints[DBActionUpdate] = append(dmf.AddressMints[DBActionUpdate], item)
+	}
+
+	// flatten listings records
+	for _, item := range dm.Listings[DBActionCreate] {
+		dmf.Listings[DBActionCreate] = append(dmf.Listings[DBActionCreate], item)
+	}
+	for _, item := range dm.Listings[DBActionUpdate] {
+		dmf.Listings[DBActionUpdate] = append(dmf.Listings[DBActionUpdate], item)
+	}
+
+	// flatten locks records
+	for _, item := range dm.Locks[DBActionCreate] {
+		dmf.Locks[DBActionCreate] = append(dmf.Locks[DBActionCreate], item)
+	}
+	for _, item := range dm.Locks[DBActionUpdate] {
+		dmf.Locks[DBActionUpdate] = append(dmf.Locks[DBActionUpdate], item)
+	}
+
+	// flatten ethscriptions records
+	for _, item := range dm.Ethscriptions[DBActionCreate] {
+		dmf.Ethscriptions[DBActionCreate] = append(dmf.Ethscriptions[DBActionCreate], item)
+	}
+	for _, item := range dm.Ethscriptions[DBActionUpdate] {
+		dmf.Ethscriptions[DBActionUpdate] = append(dmf.Ethscriptions[DBActionUpdate], item)
+	}
+
+	// flatten names records
+	for _, item := range dm.Names[DBActionCreate] {
+		dmf.Names[DBActionCreate] = append(dmf.Names[DBActionCreate], item)
+	}
+	for _, item := range dm.Names[DBActionUpdate] {
+		dmf.Names[DBActionUpdate] = append(dmf.Names[DBActionUpdate], item)
+	}
+
+	// flatten wrapped balances records
+	for _, item := range dm.WrappedBalances[DBActionCreate] {
+		dmf.WrappedBalances[DBActionCreate] = append(dmf.WrappedBalances[DBActionCreate], item)
+	}
+	for _, item := range dm.WrappedBalances[DBActionUpdate] {
+		dmf.WrappedBalances[DBActionUpdate] = append(dmf.WrappedBalances[DBActionUpdate], item)
+	}
+
+	// flatten market stats records
+	for _, item := range dm.MarketStats[DBActionCreate] {
+		dmf.MarketStats[DBActionCreate] = append(dmf.MarketStats[DBActionCreate], item)
+	}
+	for _, item := range dm.MarketStats[DBActionUpdate] {
+		dmf.MarketStats[DBActionUpdate] = append(dmf.MarketStats[DBActionUpdate], item)
+	}
 	return dmf
 }
@@ -25,6 +25,8 @@ package devents
 import (
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/model"
 )
 
 const (
@@ -34,6 +36,14 @@ const (
 	OperateList     string = "list"
 	OperateDelist   string = "delist"
 	OperateExchange string = "exchange"
+	OperateBurn     string = "burn"
+	OperateCreate   string = "create"
+	OperateRegister string = "reg"
+	OperateUpdate   string = "update"
+	OperateWrap     string = "wrap"
+	OperateLock     string = "lock"
+	OperateVest     string = "vest"
+	OperateUnlock   string = "unlock" // released by block height, no tx
 )
 
 type MetaData struct {
@@ -42,6 +52,10 @@ type MetaData struct {
 	Operate  string `json:"op"`
 	Tick     string `json:"tick"`
 	Data     string
+
+	// Rules as of the tx block resolved by the protocol fork schedule, RuleVersion recorded with the tx
+	Rules       *config.ProtocolRules `json:"-"`
+	RuleVersion string                `json:"-"`
 }
 
 func (original *MetaData) Copy() *MetaData {
@@ -51,7 +65,18 @@ func (original *MetaData) Copy() *MetaData {
 		Operate:  original.Operate,
 		Tick:     original.Tick,
 		Data:     original.Data,
+
+		Rules:       original.Rules,
+		RuleVersion: original.RuleVersion,
+	}
+}
+
+// ParseMode parse mode of the resolved rules, lenient by default
+func (original *MetaData) ParseMode() string {
+	if original.Rules != nil && original.Rules.Strict {
+		return model.ParseModeStrict
 	}
+	return model.ParseModeLenient
 }
 
 type Deploy struct {
@@ -59,12 +84,42 @@ type Deploy struct {
 	MaxSupply decimal.Decimal
 	MintLimit decimal.Decimal
 	Decimal   int8
+
+	WalletLimit    decimal.Decimal
+	StartBlock     uint64
+	EndBlock       uint64
+	BlockMintLimit uint64
+	MerkleRoot     string
+	Confusable     string // flagged, deployed tick this tick is visually confusable with
+
+	// Premine supply credited to Deployer at deploy time
+	Premine  decimal.Decimal
+	Deployer string
+
+	// paid mints, native coin price per token & fee recipient
+	Price decimal.Decimal
+	FeeTo string
+
+	Id string // protocol tick id, runes: etching block:tx
+
+	// Workc proof of work difficulty, hex prefix mint hashes must meet
+	Workc string
 }
 
 type Mint struct {
-	Minter string
-	Amount decimal.Decimal
-	Init   bool
+	Minter     string
+	Amount     decimal.Decimal
+	Init       bool
+	RecordInit bool // minter's address mint record init
+
+	// Claimer allowlist address of the mint, claimed amount tracked
+	Claimer   string
+	ClaimInit bool // claimer's address mint record init
+
+	Fee decimal.Decimal // native coin paid of paid mints
+
+	// Nonce proof of work nonce of the minter, used once per address
+	Nonce string
 }
 
 type Receive struct {
@@ -78,6 +133,118 @@ type Transfer struct {
 	Receives []*Receive
 }
 
+type Burn struct {
+	Sender string
+	Amount decimal.Decimal
+}
+
+// Outpoint tick balance held by a tx output
+type Outpoint struct {
+	Outpoint string // txid:vout
+	Address  string
+	Amount   decimal.Decimal
+}
+
+// UTXOTransfer balance changes of a tick by utxo based protocols, balances are held by tx outputs
+type UTXOTransfer struct {
+	Spent   []*Outpoint
+	Created []*Outpoint
+	Minted  decimal.Decimal // issued by the tx, allocated to created outputs or burned
+	Burned  decimal.Decimal // not allocated to spendable outputs
+
+	// net balance change per address, built by cache updating
+	changes []*Receive
+}
+
+// Listing marketplace listing state transition
+type Listing struct {
+	ListId string
+	Seller string
+	Market string
+	Buyer  string
+	Amount decimal.Decimal
+	Price  decimal.Decimal // unit price in native coin, zero if not priced
+	Status int8
+}
+
+// Trade filled marketplace order, prices in native coin
+type Trade struct {
+	ListId    string // empty if not a listing order
+	Market    string
+	Seller    string
+	Buyer     string
+	Amount    decimal.Decimal
+	UnitPrice decimal.Decimal
+	Total     decimal.Decimal
+	Init      bool // first trade of the tick, market stats created
+}
+
+// NewTrade build a trade by the total price of the order, unit price derived by amount
+func NewTrade(listId, market, seller, buyer string, amount, total decimal.Decimal) *Trade {
+	trade := &Trade{
+		ListId: listId,
+		Market: market,
+		Seller: seller,
+		Buyer:  buyer,
+		Amount: amount,
+		Total:  total,
+	}
+	if amount.IsPositive() {
+		trade.UnitPrice = total.DivRound(amount, 18)
+	}
+	return trade
+}
+
+// Ethscription non-fungible inscription creation / ownership transfer
+type Ethscription struct {
+	Id          string // creation tx hash
+	Creator     string
+	Owner       string // owner after the tx
+	Previous    string // previous owner of transfers
+	ContentType string
+	ContentHash string // sha256 of the data uri
+	Content     []byte
+	Esip6       bool // duplicate content allowed
+}
+
+// Name name registration / ownership transfer / records update, states after the tx
+type Name struct {
+	Name     string
+	Owner    string
+	Previous string // previous owner of transfers
+	Address  string // resolved address, owner if no address record
+	Avatar   string
+	Text     string
+}
+
+// Wrap wrapped token movement of a bridge wrapper contract, mint: From empty, burn: To empty
+type Wrap struct {
+	Contract string
+	From     string
+	To       string
+	Amount   decimal.Decimal
+	Init     bool // receiver's wrapped balance created
+}
+
+// Lock time lock / vesting schedule of the address, locked out of available balance until released
+type Lock struct {
+	LockId   string
+	Address  string
+	Amount   decimal.Decimal
+	Tranches []*model.LockTranche
+}
+
+// Unlock due tranches of a lock released at the block
+type Unlock struct {
+	LockId  string
+	Address string
+	Amount  decimal.Decimal
+
+	// lock states after the release, built by cache updating
+	SID      uint64
+	Released decimal.Decimal
+}
+
 type TxResult struct {
 	MD       *MetaData
 	Block    *xycommon.RpcBlock
@@ -85,4 +252,19 @@ type TxResult struct {
 	Mint     *Mint
 	Deploy   *Deploy
 	Transfer *Transfer
+	Burn     *Burn
+	Listing  *Listing
+	Trade    *Trade
+	UTXO     *UTXOTransfer
+
+	Ethscription *Ethscription
+	Name         *Name
+	Wrap         *Wrap
+	Lock         *Lock
+	Unlock       *Unlock
+}
+
+// mixedBurn burns of transfers to burn addresses, recorded together with the transfer of the same sender
+func (r *TxResult) mixedBurn() bool {
+	return r.Burn != nil && r.Transfer != nil && r.Burn.Sender == r.Transfer.Sender
 }
@@ -0,0 +1,135 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/uxuycom/indexer/client/beacon"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"strings"
+	"time"
+)
+
+// BlobRetries retries of blocks with blobs missing in sidecars, blobs unavailable after
+const BlobRetries = 30
+
+// attachBlobs
+/***************************************
+ * blob inscriptions, type-3 txs of data uri markers carry the content in blobs
+ * calldata of the tx replaced with the data uri of the blob content, attributed to the tx
+ * blobs missing in sidecars are retried, unavailable once out of the retention window or BlobRetries exceeded
+ * calldata of txs with blobs unavailable kept, endpoint failures & undecodable blobs are internal errors retried
+ * calldata replaced after all blobs of the block attached, retries see the original calldata
+ ***************************************/
+func (e *Explorer) attachBlobs(block *xycommon.RpcBlock, txs []*xycommon.RpcTransaction, retry int) *xyerrors.InsError {
+	if e.beacon == nil {
+		return nil
+	}
+
+	var blobs map[string][]byte
+	inputs := make(map[*xycommon.RpcTransaction]string)
+	for _, tx := range txs {
+		if len(tx.BlobVersionedHashes) <= 0 {
+			continue
+		}
+
+		marker, ok := blobMarker(tx.Input)
+		if !ok {
+			continue
+		}
+
+		// sidecars of the block fetched once, verified by the kzg commitments
+		if blobs == nil {
+			items, err := e.beacon.BlobsAt(e.ctx, block.Time)
+			if err != nil && !errors.Is(err, beacon.ErrNoResult) {
+				return xyerrors.NewInsError(-100, fmt.Sprintf("get block[%d] blob sidecars err:%v", block.Number, err))
+			}
+
+			// slots without sidecars, blobs of the block all missing
+			if items == nil {
+				items = make(map[string][]byte)
+			}
+			blobs = items
+		}
+
+		items := make([][]byte, 0, len(tx.BlobVersionedHashes))
+		for _, h := range tx.BlobVersionedHashes {
+			blob, ok := blobs[strings.ToLower(h)]
+			if !ok {
+				break
+			}
+			items = append(items, blob)
+		}
+
+		if len(items) < len(tx.BlobVersionedHashes) {
+			if !e.blobsUnavailable(block, retry) {
+				return xyerrors.NewInsError(-100, fmt.Sprintf("tx[%s] blobs not found in sidecars of block[%d]", tx.Hash, block.Number))
+			}
+			xylog.Logger.Warnf("tx[%s] blobs unavailable in sidecars of block[%d], retry[%d] & calldata kept", tx.Hash, block.Number, retry)
+			continue
+		}
+
+		content, err := beacon.DecodeBlobs(items)
+		if err != nil {
+			return xyerrors.NewInsError(-100, fmt.Sprintf("tx[%s] blobs decode err:%v", tx.Hash, err))
+		}
+		if len(content) <= 0 {
+			continue
+		}
+		inputs[tx] = "0x" + hex.EncodeToString(utils.AttachDataURI(marker, content))
+	}
+
+	for tx, input := range inputs {
+		tx.Input = input
+		xylog.Logger.Infof("tx[%s] blob content attached, size[%d]", tx.Hash, len(input)/2-1)
+	}
+	return nil
+}
+
+// blobsUnavailable blobs of the block missing are unavailable, retries exceeded or out of the retention window
+func (e *Explorer) blobsUnavailable(block *xycommon.RpcBlock, retry int) bool {
+	if retry >= BlobRetries {
+		return true
+	}
+
+	retained, err := e.beacon.Retained(e.ctx, block.Time, uint64(time.Now().Unix()))
+	return err == nil && !retained
+}
+
+// blobMarker data uri marker of hex calldata
+func blobMarker(input string) (string, bool) {
+	if !strings.HasPrefix(input, "0x") {
+		return "", false
+	}
+
+	data, err := hex.DecodeString(input[2:])
+	if err != nil || !utils.IsDataURIMarker(string(data)) {
+		return "", false
+	}
+	return string(data), true
+}
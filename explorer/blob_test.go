@@ -0,0 +1,169 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/beacon"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+// testBlob encode content into a blob, 31 bytes per field element & 0x80 terminated
+func testBlob(content []byte) []byte {
+	data := append(append([]byte{}, content...), 0x80)
+	blob := make([]byte, beacon.BlobSize)
+	for j := 0; j < beacon.BlobSize && len(data) > 0; j += beacon.FieldElementSize {
+		n := copy(blob[j+1:j+beacon.FieldElementSize], data)
+		data = data[n:]
+	}
+	return blob
+}
+
+// testSidecar sidecar json of the blob & the versioned hash, kzg commitment & proof computed
+func testSidecar(t *testing.T, index int, blob []byte) (string, string) {
+	var b kzg4844.Blob
+	copy(b[:], blob)
+	commitment, err := kzg4844.BlobToCommitment(b)
+	assert.Nil(t, err)
+	proof, err := kzg4844.ComputeBlobProof(b, commitment)
+	assert.Nil(t, err)
+
+	sidecar := fmt.Sprintf(`{"index":"%d","blob":"0x%s","kzg_commitment":"0x%s","kzg_proof":"0x%s"}`, index, hex.EncodeToString(blob), hex.EncodeToString(commitment[:]), hex.EncodeToString(proof[:]))
+	return sidecar, beacon.VersionedHash(commitment[:])
+}
+
+func TestAttachBlobs(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	contents := [][]byte{
+		[]byte(`{"p":"brc-20","op":"mint","tick":"blob","amt":"1"}`),
+		png,
+		[]byte(`data:text/plain,gm`),
+	}
+
+	sidecars := make([]string, 0, len(contents)+1)
+	hashes := make([]string, 0, len(contents)+1)
+	for i, content := range contents {
+		sidecar, h := testSidecar(t, i, testBlob(content))
+		sidecars = append(sidecars, sidecar)
+		hashes = append(hashes, h)
+	}
+
+	// field elements of non-zero first bytes are not blob encoded content
+	undecodable := testBlob([]byte("gm"))
+	undecodable[0] = 0x01
+	sidecar, h := testSidecar(t, len(contents), undecodable)
+	sidecars = append(sidecars, sidecar)
+	hashes = append(hashes, h)
+
+	// genesis out of the retention window, sidecars of a recent slot served
+	now := uint64(time.Now().Unix())
+	genesis := now - beacon.BlobRetentionEpochs*beacon.SlotsPerEpoch*12 - 1200
+	slot := (now-genesis)/12 - 5
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			fmt.Fprintf(w, `{"data":{"genesis_time":"%d"}}`, genesis)
+		case "/eth/v1/config/spec":
+			fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"12"}}`)
+		case fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%d", slot):
+			fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(sidecars, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	e := &Explorer{ctx: context.Background(), beacon: beacon.NewClient(srv.URL)}
+	block := &xycommon.RpcBlock{Number: big.NewInt(1), Time: genesis + slot*12}
+	hexOf := func(data string) string {
+		return "0x" + hex.EncodeToString([]byte(data))
+	}
+
+	txs := []*xycommon.RpcTransaction{
+		{Hash: "0x01", Input: hexOf("data:,"), BlobVersionedHashes: hashes[:1]},
+		{Hash: "0x02", Input: hexOf("data:image/png;base64,"), BlobVersionedHashes: hashes[1:2]},
+		{Hash: "0x03", Input: hexOf("data:"), BlobVersionedHashes: hashes[2:3]},
+		{Hash: "0x04", Input: hexOf("data:,gm"), BlobVersionedHashes: hashes[:1]},
+		{Hash: "0x05", Input: hexOf("data:,")},
+	}
+	assert.Nil(t, e.attachBlobs(block, txs, 0))
+	assert.Equal(t, hexOf(`data:,{"p":"brc-20","op":"mint","tick":"blob","amt":"1"}`), txs[0].Input)
+	assert.Equal(t, hexOf("data:image/png;base64,"+base64.StdEncoding.EncodeToString(png)), txs[1].Input)
+	assert.Equal(t, hexOf("data:text/plain,gm"), txs[2].Input)
+
+	// calldata with data & txs without blobs are kept
+	assert.Equal(t, hexOf("data:,gm"), txs[3].Input)
+	assert.Equal(t, hexOf("data:,"), txs[4].Input)
+
+	// blobs not found & undecodable blobs are retried, calldata of the block kept
+	tx := &xycommon.RpcTransaction{Hash: "0x06", Input: hexOf("data:,"), BlobVersionedHashes: hashes[:1]}
+	missing := &xycommon.RpcTransaction{Hash: "0x07", Input: hexOf("data:,"), BlobVersionedHashes: []string{beacon.VersionedHash(nil)}}
+	assert.NotNil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx, missing}, 0))
+	assert.Equal(t, hexOf("data:,"), tx.Input)
+	broken := &xycommon.RpcTransaction{Hash: "0x08", Input: hexOf("data:,"), BlobVersionedHashes: hashes[len(contents):]}
+	assert.NotNil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx, broken}, BlobRetries))
+	assert.Equal(t, hexOf("data:,"), tx.Input)
+
+	// blobs still missing after retries are unavailable, calldata kept & other txs attached
+	assert.Nil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx, missing}, BlobRetries))
+	assert.Equal(t, hexOf(`data:,{"p":"brc-20","op":"mint","tick":"blob","amt":"1"}`), tx.Input)
+	assert.Equal(t, hexOf("data:,"), missing.Input)
+
+	// slots without sidecars are retried in the retention window
+	tx.Input = hexOf("data:,")
+	block.Time -= 12
+	assert.NotNil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx}, 0))
+	assert.Nil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx}, BlobRetries))
+	assert.Equal(t, hexOf("data:,"), tx.Input)
+
+	// out of the retention window, e.g. pruned, unavailable without retries
+	block.Time = genesis + 1200
+	assert.Nil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx}, 0))
+	assert.Equal(t, hexOf("data:,"), tx.Input)
+
+	// endpoint failures are retried
+	srv.Close()
+	e.beacon = beacon.NewClient(srv.URL)
+	assert.NotNil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx}, BlobRetries))
+
+	// disabled without endpoint
+	e.beacon = nil
+	assert.Nil(t, e.attachBlobs(block, []*xycommon.RpcTransaction{tx}, 0))
+}
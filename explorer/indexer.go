@@ -29,7 +29,6 @@ import (
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/protocol"
-	"github.com/uxuycom/indexer/protocol/common"
 	"github.com/uxuycom/indexer/xyerrors"
 	"github.com/uxuycom/indexer/xylog"
 	"math/big"
@@ -44,15 +43,31 @@ func (e *Explorer) validReceiptTxs(items []*xycommon.RpcTransaction) ([]*xycommo
 		xylog.Logger.Infof("handle txs, fetch receipt data cost[%v], items[%d]", time.Since(startTs), len(items))
 	}()
 
+	receiptsMap := &sync.Map{}
+	codesMap := &sync.Map{}
 	txHashList := make(map[string]struct{}, len(items))
+	toList := make(map[string]*xycommon.RpcTransaction, len(items))
+	contractCheck := protocol.ContractCheckRequired()
 	for _, item := range items {
-		txHashList[item.Hash] = struct{}{}
+		// receipts returned along with the block are not fetched
+		if len(item.Receipt) > 0 {
+			receiptsMap.Store(item.Hash, &item.Receipt[0])
+		} else {
+			txHashList[item.Hash] = struct{}{}
+		}
+
+		// address code checking is cached
+		if !contractCheck || item.To == "" {
+			continue
+		}
+		key := contractKey(item)
+		if _, ok := e.contracts.Get(key); !ok {
+			toList[key] = item
+		}
 	}
 
 	workers := int(e.config.Scan.TxBatchWorkers)
 	pool := pond.New(workers, 0, pond.MinWorkers(workers))
-
-	receiptsMap := &sync.Map{}
 	for txHash := range txHashList {
 		hash := txHash
 		pool.Submit(func() {
@@ -70,6 +85,23 @@ func (e *Explorer) validReceiptTxs(items []*xycommon.RpcTransaction) ([]*xycommo
 		})
 	}
 
+	for key, item := range toList {
+		key, tx := key, item
+		pool.Submit(func() {
+			ok, err := e.node.IsContract(e.ctx, tx.To, tx.BlockNumber)
+			if err != nil {
+				xylog.Logger.Errorf("get address code err:%v, address:%s", err, tx.To)
+				return
+			}
+			codesMap.Store(key, ok)
+
+			// code is kept since EIP-6780, addresses without code checked again as code may be deployed later
+			if ok {
+				e.contracts.Add(key, ok)
+			}
+		})
+	}
+
 	// Stop the pool and wait for all submitted tasks to complete
 	pool.StopAndWait()
 
@@ -95,37 +127,65 @@ func (e *Explorer) validReceiptTxs(items []*xycommon.RpcTransaction) ([]*xycommo
 		if r.GasUsed.Cmp(big.NewInt(0)) > 0 {
 			item.Gas = r.GasUsed
 		}
+
+		if contractCheck && item.To != "" {
+			key := contractKey(item)
+			cv, ok1 := e.contracts.Get(key)
+			if v, ok2 := codesMap.Load(key); ok2 {
+				cv, ok1 = v.(bool), true
+			}
+			if !ok1 {
+				return nil, xyerrors.NewInsError(-100, fmt.Sprintf("get address[%s] code failed", item.To))
+			}
+			item.ToContract = cv
+		}
 		results = append(results, item)
 	}
 	return results, nil
 }
 
+// contractKey address code checking is cached by address
+func contractKey(tx *xycommon.RpcTransaction) string {
+	return strings.ToLower(tx.To)
+}
+
 func (e *Explorer) tryFilterTxs(txs []*xycommon.RpcTransaction) []*xycommon.RpcTransaction {
 	validTxs := make([]*xycommon.RpcTransaction, 0, len(txs))
 	for _, tx := range txs {
-		pt, md := protocol.GetProtocol(e.config, tx)
-		if pt == nil {
-			continue
+		for _, part := range protocol.GetProtocols(e.config, tx) {
+			if e.partEnabled(tx, part.MD) {
+				validTxs = append(validTxs, tx)
+				break
+			}
 		}
+	}
+	return validTxs
+}
 
-		// Add protocol whitelist
-		if !e.protocolEnabled(md.Protocol) {
-			continue
-		}
+// partEnabled reports whether the data of the tx is indexed by filters & strategies
+func (e *Explorer) partEnabled(tx *xycommon.RpcTransaction, md *devents.MetaData) bool {
+	// Add protocol whitelist
+	if !e.protocolEnabled(md.Protocol) {
+		return false
+	}
 
-		// Add protocol whitelist
-		if !e.tickEnabled(md.Tick) {
-			continue
-		}
+	// Add protocol whitelist
+	if !e.tickEnabled(md.Tick) {
+		return false
+	}
 
-		// Add mint completed filter
-		if e.filterMintCompleted(md) {
-			xylog.Logger.Infof("tx hit mint completed strategy & ignore. tx[%s]", tx.Hash)
-			continue
-		}
-		validTxs = append(validTxs, tx)
+	// operate must be declared by the protocol
+	if !protocol.OperateSupported(md) {
+		xylog.Logger.Infof("tx operate[%s] not supported by protocol[%s] & ignore. tx[%s]", md.Operate, md.Protocol, tx.Hash)
+		return false
 	}
-	return validTxs
+
+	// Add mint completed filter
+	if e.filterMintCompleted(md) {
+		xylog.Logger.Infof("tx hit mint completed strategy & ignore. tx[%s]", tx.Hash)
+		return false
+	}
+	return true
 }
 
 func (e *Explorer) filterMintCompleted(md *devents.MetaData) bool {
@@ -153,52 +213,97 @@ func (e *Explorer) filterMintCompleted(md *devents.MetaData) bool {
 	return false
 }
 
-func (e *Explorer) handleTxs(block *xycommon.RpcBlock, txs []*xycommon.RpcTransaction) *xyerrors.InsError {
+func (e *Explorer) handleTxs(block *xycommon.RpcBlock, released []*devents.DBModelEvent, txs []*xycommon.RpcTransaction) *xyerrors.InsError {
 	startTs := time.Now()
 	defer func() {
 		xylog.Logger.Infof("handle txs, parse & async sink cost[%v], txs[%d]", time.Since(startTs), len(txs))
 	}()
 
-	blockTxResults := make([]*devents.DBModelEvent, 0, len(txs))
+	blockTxResults := make([]*devents.DBModelEvent, 0, len(released)+len(txs))
+	blockTxResults = append(blockTxResults, released...)
 	for _, tx := range txs {
-		pt, md := protocol.GetProtocol(e.config, tx)
-		if pt == nil {
-			continue
+		// data of several protocols in order, the cache updated by each part before the next
+		for _, part := range protocol.GetProtocols(e.config, tx) {
+			items, err := e.handlePart(block, tx, part)
+			if err != nil {
+				return err
+			}
+			blockTxResults = append(blockTxResults, items...)
 		}
+	}
+	e.writeDBAsync(block, blockTxResults)
+	return nil
+}
 
-		// Add protocol whitelist
-		if !e.protocolEnabled(md.Protocol) {
-			continue
-		}
+func (e *Explorer) handlePart(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, part *protocol.Part) ([]*devents.DBModelEvent, *xyerrors.InsError) {
+	md := part.MD
 
-		// Add protocol whitelist
-		if !e.tickEnabled(md.Tick) {
-			continue
-		}
+	// Add protocol whitelist
+	if !e.protocolEnabled(md.Protocol) {
+		return nil, nil
+	}
 
-		txResults, err := pt.Parse(block, tx, md)
-		if err != nil && errors.Is(err, xyerrors.ErrInternal) {
-			return err
-		}
-		if err != nil {
-			xylog.Logger.Infof("tx data parsed failed. md[%v], tx[%s], err[%v]", md, tx.Hash, err)
-			continue
-		}
-		xylog.Logger.Infof("tx data parsed success. md[%v], tx[%s]", md, tx.Hash)
+	// Add protocol whitelist
+	if !e.tickEnabled(md.Tick) {
+		return nil, nil
+	}
+
+	txResults, err := part.Protocol.Parse(block, tx, md)
+	if err != nil && errors.Is(err, xyerrors.ErrInternal) {
+		return nil, err
+	}
+	if err != nil {
+		xylog.Logger.Infof("tx data parsed failed. md[%v], tx[%s], err[%v]", md, tx.Hash, err)
+		return nil, nil
+	}
+	xylog.Logger.Infof("tx data parsed success. md[%v], tx[%s]", md, tx.Hash)
+
+	if len(txResults) < 1 {
+		xylog.Logger.Warnf("tx data parsed result nil. md[%v], tx[%s]", md, tx.Hash)
+		return nil, nil
+	}
 
-		if len(txResults) < 1 {
-			xylog.Logger.Warnf("tx data parsed result nil. md[%v], tx[%s]", md, tx.Hash)
+	// update cache, ticks of event orders resolved by parsing
+	items := make([]*devents.DBModelEvent, 0, len(txResults))
+	for _, txResult := range txResults {
+		if !e.tickEnabled(txResult.MD.Tick) {
 			continue
 		}
+		e.txResultHandler.UpdateCache(txResult)
+		items = append(items, e.txResultHandler.BuildModel(txResult))
+	}
+	return items, nil
+}
 
-		// update cache
-		for _, txResult := range txResults {
-			e.txResultHandler.UpdateCache(txResult)
-			blockTxResults = append(blockTxResults, e.txResultHandler.BuildModel(txResult))
-		}
+// releaseLocks
+/***************************************
+ * release due tranches of locks at the block, applied before the txs of the block
+ ***************************************/
+func (e *Explorer) releaseLocks(block *xycommon.RpcBlock) []*devents.DBModelEvent {
+	results := e.txResultHandler.BuildUnlocks(e.config.Chain.ChainName, block)
+	items := make([]*devents.DBModelEvent, 0, len(results))
+	for _, r := range results {
+		e.txResultHandler.UpdateCache(r)
+		items = append(items, e.txResultHandler.BuildModel(r))
+		xylog.Logger.Infof("lock released. lock[%s], address[%s], amount[%v]", r.Unlock.LockId, r.Unlock.Address, r.Unlock.Amount)
 	}
-	e.writeDBAsync(block, blockTxResults)
-	return nil
+	return items
+}
+
+// refreshMarkets
+/***************************************
+ * refresh 24h market stats as of the block time, applied before the txs of the block
+ ***************************************/
+func (e *Explorer) refreshMarkets(block *xycommon.RpcBlock) []*devents.DBModelEvent {
+	if block == nil {
+		return nil
+	}
+
+	items := e.txResultHandler.BuildMarketRefresh(e.config.Chain.ChainName, block.Time)
+	if len(items) > 0 {
+		xylog.Logger.Infof("market stats refreshed at block[%d], ticks[%d]", block.Number, len(items))
+	}
+	return items
 }
 
 func (e *Explorer) extractTxsFromBlock(block *xycommon.RpcBlock) []*xycommon.RpcTransaction {
@@ -208,11 +313,23 @@ func (e *Explorer) extractTxsFromBlock(block *xycommon.RpcBlock) []*xycommon.Rpc
 
 	txs := make([]*xycommon.RpcTransaction, 0, len(block.Transactions))
 	for _, tx := range block.Transactions {
-		// fast check & filter invalid txs
-		if !e.fastChecking(tx) {
-			continue
+		// payloads of smart accounts, the inner call of each executed op is indexed
+		items := []*xycommon.RpcTransaction{tx}
+		if calls := protocol.UnwrapAccountCalls(tx); len(calls) > 0 {
+			items = calls
+		}
+
+		for _, item := range items {
+			if item.Wrapper != "" {
+				xylog.Logger.Infof("tx[%s] unwrapped from %s wrapper, account[%s]", item.Hash, item.Wrapper, item.From)
+			}
+
+			// fast check & filter invalid txs
+			if !e.fastChecking(item) {
+				continue
+			}
+			txs = append(txs, item)
 		}
-		txs = append(txs, tx)
 	}
 	return txs
 }
@@ -255,7 +372,11 @@ func (e *Explorer) handleBlock(block *xycommon.RpcBlock) {
 		xylog.Logger.Infof("handle block finished, cost:%v", time.Since(st))
 	}()
 
-	retry := 0
+	// released once, not released again by retries of the block
+	released := e.releaseLocks(block)
+	released = append(released, e.refreshMarkets(block)...)
+
+	retry, blobRetry := 0, 0
 	for {
 		if block == nil || block.Number.Uint64() <= 0 {
 			xylog.Logger.Infof("block nil or number[%d] <= 0", block.Number.Uint64())
@@ -265,6 +386,14 @@ func (e *Explorer) handleBlock(block *xycommon.RpcBlock) {
 		// extract txs from block & fast checking invalid tx
 		txs := e.extractTxsFromBlock(block)
 
+		// Add blob content of blob inscriptions
+		if err := e.attachBlobs(block, txs, blobRetry); err != nil {
+			xylog.Logger.Errorf("fetch blob data internal err:%v & retry later[%d]", err, blobRetry)
+			blobRetry++
+			<-time.After(time.Millisecond * 100)
+			continue
+		}
+
 		// try filter invalid txs
 		txs = e.tryFilterTxs(txs)
 
@@ -278,7 +407,7 @@ func (e *Explorer) handleBlock(block *xycommon.RpcBlock) {
 		}
 
 		// Handle: parse txs & sync cache / db
-		err = e.handleTxs(block, txs)
+		err = e.handleTxs(block, released, txs)
 		if err != nil {
 			xylog.Logger.Errorf("parse internal err:%v & retry later[%d]", err, retry)
 			retry++
@@ -310,24 +439,8 @@ func (e *Explorer) writeDBAsync(block *xycommon.RpcBlock, txResults []*devents.D
 }
 
 func (e *Explorer) fastChecking(tx *xycommon.RpcTransaction) bool {
-	// events log checking
-	if len(tx.Events) > 0 {
-		return true
-	}
-
-	// input dmt format checking
-	trxContent := tx.Input
-
-	// 0x prefix checking
-	if !strings.HasPrefix(trxContent, "0x") {
-		return false
-	}
-
-	// data prefix checking
-	if strings.HasPrefix(trxContent, common.DataPrefix) {
-		return true
-	}
-	return false
+	// enabled protocols checking
+	return protocol.FastCheck(tx)
 }
 
 func (e *Explorer) protocolEnabled(protocol string) bool {
@@ -0,0 +1,88 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+// testNode address code checking of the node, other calls unimplemented
+type testNode struct {
+	xycommon.IRPCClient
+
+	mu     sync.Mutex
+	codes  map[string]bool
+	checks map[string]int
+}
+
+func (n *testNode) IsContract(_ context.Context, address string, _ *big.Int) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.checks[address]++
+	return n.codes[address], nil
+}
+
+func TestContractCheckCache(t *testing.T) {
+	// bsc-20 ignores calldata sent to contracts, code of receivers checked
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: "polygon", Protocols: []string{"bsc-20"}}}
+	cfg.Scan.TxBatchWorkers = 2
+	testutil.NewHarness(t, cfg)
+
+	contract, account := "0x00000000000000000000000000000000000000c1", "0x00000000000000000000000000000000000000a1"
+	node := &testNode{codes: map[string]bool{contract: true}, checks: map[string]int{}}
+	e := &Explorer{ctx: context.Background(), config: cfg, node: node, contracts: lru.NewCache[string, bool](ContractCacheSize)}
+
+	receipt := xycommon.RpcReceipt{Status: big.NewInt(1), EffectiveGasPrice: big.NewInt(0), GasUsed: big.NewInt(0)}
+	txsAt := func(height int64) []*xycommon.RpcTransaction {
+		return []*xycommon.RpcTransaction{
+			{Hash: "0x01", BlockNumber: big.NewInt(height), To: contract, Receipt: []xycommon.RpcReceipt{receipt}},
+			{Hash: "0x02", BlockNumber: big.NewInt(height), To: account, Receipt: []xycommon.RpcReceipt{receipt}},
+		}
+	}
+
+	// contracts checked once across blocks, addresses without code checked per block
+	for height := int64(1); height <= 3; height++ {
+		txs, err := e.validReceiptTxs(txsAt(height))
+		assert.Nil(t, err)
+		assert.Len(t, txs, 2)
+		assert.True(t, txs[0].ToContract)
+		assert.False(t, txs[1].ToContract)
+	}
+	assert.Equal(t, 1, node.checks[contract])
+	assert.Equal(t, 3, node.checks[account])
+
+	// code deployed to a checked address
+	node.codes[account] = true
+	txs, err := e.validReceiptTxs(txsAt(4))
+	assert.Nil(t, err)
+	assert.True(t, txs[1].ToContract)
+	assert.Equal(t, 4, node.checks[account])
+}
@@ -28,10 +28,13 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/uxuycom/indexer/client/beacon"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
 	"github.com/uxuycom/indexer/storage"
 	"github.com/uxuycom/indexer/xylog"
 	"golang.org/x/sync/errgroup"
@@ -43,6 +46,9 @@ import (
 	"time"
 )
 
+// ContractCacheSize maximum cached address code checking results
+const ContractCacheSize = 100000
+
 type Explorer struct {
 	config          *config.Config
 	node            xycommon.IRPCClient
@@ -56,6 +62,8 @@ type Explorer struct {
 	dEvent          *devents.DEvent
 	latestBlockNum  atomic.Uint64
 	currentBlockNum atomic.Uint64
+	contracts       *lru.Cache[string, bool] // contract addresses
+	beacon          *beacon.RawClient        // blob sidecars of blob inscriptions, nil if not configured
 }
 
 func NewExplorer(rpcClient xycommon.IRPCClient, dbc *storage.DBClient, cfg *config.Config, dCache *dcache.Manager, dEvent *devents.DEvent, quit chan os.Signal) *Explorer {
@@ -73,9 +81,14 @@ func NewExplorer(rpcClient xycommon.IRPCClient, dbc *storage.DBClient, cfg *conf
 		dCache:          dCache,
 		blocks:          make(chan *xycommon.RpcBlock, 100),
 		txResultHandler: txResultHandler,
+		contracts:       lru.NewCache[string, bool](ContractCacheSize),
 
 		dEvent: dEvent,
 	}
+
+	if cfg.Chain.BeaconEndpoint != "" {
+		exp.beacon = beacon.NewClient(cfg.Chain.BeaconEndpoint)
+	}
 	return exp
 }
 
@@ -189,18 +202,34 @@ func (e *Explorer) syncLatestBlockNumber() error {
 	return nil
 }
 
+func (e *Explorer) eventTopics() []common.Hash {
+	items := protocol.EventTopics()
+	if e.config.Filters != nil {
+		items = append(items, e.config.Filters.EventTopics...)
+	}
+
+	exists := make(map[common.Hash]struct{}, len(items))
+	hashes := make([]common.Hash, 0, len(items))
+	for _, ts := range items {
+		hash := common.HexToHash(ts)
+		if _, ok := exists[hash]; ok {
+			continue
+		}
+		exists[hash] = struct{}{}
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
 func (e *Explorer) scanLogs(startBlock, endBlock uint64, result chan map[string][]xycommon.RpcLog) {
-	if e.config.Filters == nil || len(e.config.Filters.EventTopics) <= 0 {
+	eventTopics := e.eventTopics()
+	if len(eventTopics) <= 0 {
 		result <- nil
 		return
 	}
 
 	// filter Logs
-	topics := [][]common.Hash{{}}
-	topics[0] = make([]common.Hash, 0, len(e.config.Filters.EventTopics))
-	for _, ts := range e.config.Filters.EventTopics {
-		topics[0] = append(topics[0], common.HexToHash(ts))
-	}
+	topics := [][]common.Hash{eventTopics}
 
 	retry := 0
 DoFilter:
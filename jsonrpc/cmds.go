@@ -56,6 +56,18 @@ type InscriptionInfo struct {
 	CreatedAt    uint32 `json:"created_at"`
 	UpdatedAt    uint32 `json:"updated_at"`
 	Decimals     int8   `json:"decimals"`
+	Minted       string `json:"minted"`
+	Burned       string `json:"burned"`
+	Circulating  string `json:"circulating_supply"`
+	Wrapped      string `json:"wrapped_supply"`  // held by the bridges of the tick
+	MerkleRoot   string `json:"merkle_root"`     // allowlist merkle root, empty if public mint
+	Confusable   string `json:"confusable_with"` // flagged, deployed tick visually confusable with
+	Premine      string `json:"premine"`         // supply allocated to the deployer
+	MintPrice    string `json:"mint_price"`      // native coin price per token, 0: free mint
+	FeeTo        string `json:"fee_to"`          // mint fee recipient
+	Fees         string `json:"fees"`            // native coin paid of paid mints
+	TickId       string `json:"tick_id"`         // protocol tick id, runes: etching block:tx
+	Workc        string `json:"workc"`           // proof of work difficulty of mints
 }
 
 // FindInscriptionTickCmd defines the inscription JSON-RPC command.
@@ -136,13 +148,17 @@ type BalanceInfo struct {
 	Tick         string `json:"tick"`
 	Address      string `json:"address"`
 	Balance      string `json:"balance"`
+	Available    string `json:"available"` // balance not locked by listings & locks
+	Locked       string `json:"locked"`
 	DeployHash   string `json:"deploy_hash"`
 	TransferType int8   `json:"transfer_type"`
+	ParseMode    string `json:"parse_mode,omitempty"` // lenient / strict, parse mode of the last balance change
 }
 
 type BalanceBrief struct {
 	Tick         string       `json:"tick"`
 	Balance      string       `json:"balance"`
+	ParseMode    string       `json:"parse_mode"`
 	TransferType int8         `json:"transfer_type"`
 	Utxos        []*UTXOBrief `json:"utxos,omitempty"`
 	DeployHash   string       `json:"deploy_hash"`
@@ -237,6 +253,8 @@ type TransactionInfo struct {
 	From       string `json:"from"`
 	To         string `json:"to"`
 	Amount     string `json:"amount"`
+
+	RuleVersion string `json:"rule_version"` // protocol rule version the tx indexed by
 }
 
 type GetTxByHashResponse struct {
@@ -244,6 +262,187 @@ type GetTxByHashResponse struct {
 	Transaction   *TransactionInfo `json:"transaction,omitempty"`
 }
 
+// GetEthscriptionCmd id is the creation tx hash or the sequence number
+type GetEthscriptionCmd struct {
+	Chain string
+	Id    string
+}
+
+type EthscriptionInfo struct {
+	SN          uint64 `json:"sn"`
+	Id          string `json:"id"`
+	Creator     string `json:"creator"`
+	Owner       string `json:"owner"`
+	ContentType string `json:"content_type"`
+	ContentHash string `json:"content_hash"`
+	ContentUrl  string `json:"content_url"` // raw content endpoint
+	Esip6       bool   `json:"esip6"`
+	BlockHeight uint64 `json:"block_height"`
+	TxHash      string `json:"tx_hash"` // last transfer tx hash
+	CreatedAt   uint32 `json:"created_at"`
+}
+
+type LastSNCmd struct {
+	Chain string
+}
+
+type LastSNResponse struct {
+	Chain  string `json:"chain"`
+	LastSN uint64 `json:"last_sn"`
+}
+
+type GetTradesCmd struct {
+	Limit    int
+	Offset   int
+	Chain    string
+	Protocol string
+	Tick     string
+}
+
+// TradeInfo prices in native coin
+type TradeInfo struct {
+	ListId      string `json:"list_id,omitempty"`
+	Market      string `json:"market"`
+	Seller      string `json:"seller"`
+	Buyer       string `json:"buyer"`
+	Amount      string `json:"amount"`
+	UnitPrice   string `json:"unit_price"`
+	Total       string `json:"total"`
+	BlockHeight uint64 `json:"block_height"`
+	BlockTime   uint32 `json:"block_time"`
+	TxHash      string `json:"tx_hash"`
+}
+
+type GetTradesResponse struct {
+	Trades interface{} `json:"trades"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+type GetMarketStatsCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+}
+
+// MarketStatsInfo 24h values are rolling window as of the last indexed block
+type MarketStatsInfo struct {
+	Chain         string `json:"chain"`
+	Protocol      string `json:"protocol"`
+	Tick          string `json:"tick"`
+	Volume        string `json:"volume"`
+	TradeCnt      uint64 `json:"trade_cnt"`
+	LastPrice     string `json:"last_price"`
+	FloorPrice    string `json:"floor_price"`
+	Volume24h     string `json:"volume_24h"`
+	TradeCnt24h   uint64 `json:"trade_cnt_24h"`
+	LastTradeTime uint64 `json:"last_trade_time"`
+}
+
+// IndsResolveNameCmd forward resolution of a name
+type IndsResolveNameCmd struct {
+	Chain string
+	Name  string
+}
+
+type NameInfo struct {
+	Chain       string `json:"chain"`
+	Protocol    string `json:"protocol"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner"`
+	Address     string `json:"address"` // resolved address, owner if no address record
+	Avatar      string `json:"avatar"`
+	Text        string `json:"text"`
+	BlockHeight uint64 `json:"block_height"` // registration block
+	TxHash      string `json:"tx_hash"`      // last tx hash of the name
+	CreatedAt   uint32 `json:"created_at"`
+}
+
+// IndsReverseResolveNameCmd reverse resolution, names resolved to & owned by the address
+type IndsReverseResolveNameCmd struct {
+	Limit   int
+	Offset  int
+	Chain   string
+	Address string
+}
+
+// ReverseResolveNameResponse primary name is the earliest registered name resolved to the address
+type ReverseResolveNameResponse struct {
+	Address string      `json:"address"`
+	Name    string      `json:"name"`
+	Names   []*NameInfo `json:"names"`
+	Total   int64       `json:"total"`
+	Limit   int         `json:"limit"`
+	Offset  int         `json:"offset"`
+}
+
+// IndsGetTotalBalanceByAddressCmd balance of the tick across inscription & erc-20 wrapped forms
+type IndsGetTotalBalanceByAddressCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+	Address  string
+}
+
+// TotalBalanceInfo total = inscription balance + wrapped balances
+type TotalBalanceInfo struct {
+	Chain    string                `json:"chain"`
+	Protocol string                `json:"protocol"`
+	Tick     string                `json:"tick"`
+	Address  string                `json:"address"`
+	Balance  string                `json:"balance"` // inscription balance
+	Wrapped  string                `json:"wrapped"` // held in wrapper contracts
+	Total    string                `json:"total"`
+	Wrappers []*WrappedBalanceInfo `json:"wrappers"`
+}
+
+type WrappedBalanceInfo struct {
+	Contract string `json:"contract"`
+	Amount   string `json:"amount"`
+}
+
+// IndsGetLocksByAddressCmd time locks & vesting schedules of the address, open locks only if not All
+type IndsGetLocksByAddressCmd struct {
+	Limit    int
+	Offset   int
+	Chain    string
+	Protocol string
+	Tick     string
+	Address  string
+	All      bool
+}
+
+type LockInfo struct {
+	Chain        string             `json:"chain"`
+	Protocol     string             `json:"protocol"`
+	Tick         string             `json:"tick"`
+	LockId       string             `json:"lock_id"`
+	Address      string             `json:"address"`
+	Amount       string             `json:"amount"`
+	Released     string             `json:"released"`
+	Locked       string             `json:"locked"` // amount not released yet
+	Schedule     []*LockTrancheInfo `json:"schedule"`
+	UnlockHeight uint64             `json:"unlock_height"` // height of the final tranche
+	BlockHeight  uint64             `json:"block_height"`
+	TxHash       string             `json:"tx_hash"`
+	CreatedAt    uint32             `json:"created_at"`
+}
+
+type LockTrancheInfo struct {
+	Height uint64 `json:"height"`
+	Amount string `json:"amount"`
+}
+
+type FindLocksResponse struct {
+	Address string      `json:"address"`
+	Locked  string      `json:"locked"` // total locked amount of the listed locks
+	Locks   []*LockInfo `json:"locks"`
+	Total   int64       `json:"total"`
+	Limit   int         `json:"limit"`
+	Offset  int         `json:"offset"`
+}
+
 func init() {
 	// No special flags for commands in this file.
 	flags := UsageFlag(0)
@@ -258,6 +457,10 @@ func init() {
 	MustRegisterCmd("tool.InscriptionTxOperate", (*TxOperateCmd)(nil), flags)
 	MustRegisterCmd("transaction.Info", (*GetTxByHashCmd)(nil), flags)
 	MustRegisterCmd("tick.GetBriefs", (*GetTickBriefsCmd)(nil), flags)
+	MustRegisterCmd("ethscription.Info", (*GetEthscriptionCmd)(nil), flags)
+	MustRegisterCmd("ethscription.LastSN", (*LastSNCmd)(nil), flags)
+	MustRegisterCmd("market.Trades", (*GetTradesCmd)(nil), flags)
+	MustRegisterCmd("market.Stats", (*GetMarketStatsCmd)(nil), flags)
 
 	//v2
 	MustRegisterCmd("inds_getTicks", (*IndsGetTicksCmd)(nil), flags)
@@ -267,4 +470,8 @@ func init() {
 	MustRegisterCmd("inds_getLastBlockNumberIndexed", (*LastBlockNumberCmd)(nil), flags)
 	MustRegisterCmd("inds_getTickByCallData", (*TxOperateCmd)(nil), flags)
 	MustRegisterCmd("inds_getTransactionByHash", (*GetTxByHashCmd)(nil), flags)
+	MustRegisterCmd("inds_resolveName", (*IndsResolveNameCmd)(nil), flags)
+	MustRegisterCmd("inds_reverseResolveName", (*IndsReverseResolveNameCmd)(nil), flags)
+	MustRegisterCmd("inds_getTotalBalanceByAddress", (*IndsGetTotalBalanceByAddressCmd)(nil), flags)
+	MustRegisterCmd("inds_getLocksByAddress", (*IndsGetLocksByAddressCmd)(nil), flags)
 }
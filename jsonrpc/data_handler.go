@@ -9,7 +9,7 @@ import (
 
 func findAddressBalances(s *RpcServer, limit, offset int, address, chain, protocol, tick string, sort int) (interface{}, error) {
 	protocol = strings.ToLower(protocol)
-	tick = strings.ToLower(tick)
+	tick = normalizeTick(protocol, tick)
 	cacheKey := fmt.Sprintf("addr_balances_%d_%d_%s_%s_%s_%s_%d", limit, offset, address, chain, protocol, tick, sort)
 	if ins, ok := s.cacheStore.Get(cacheKey); ok {
 		if allIns, ok := ins.(*FindUserBalancesResponse); ok {
@@ -30,6 +30,8 @@ func findAddressBalances(s *RpcServer, limit, offset int, address, chain, protoc
 			Tick:         b.Tick,
 			Address:      b.Address,
 			Balance:      b.Balance.String(),
+			Available:    b.Available.String(),
+			Locked:       b.Balance.Sub(b.Available).String(),
 			DeployHash:   b.DeployHash,
 			TransferType: b.TransferType,
 		}
@@ -48,7 +50,7 @@ func findAddressBalances(s *RpcServer, limit, offset int, address, chain, protoc
 
 func findInsciptions(s *RpcServer, limit, offset int, chain, protocol, tick, deployBy string, sort, sortMode int) (interface{}, error) {
 	protocol = strings.ToLower(protocol)
-	tick = strings.ToLower(tick)
+	tick = normalizeTick(protocol, tick)
 	cacheKey := fmt.Sprintf("all_ins_%d_%d_%s_%s_%s_%s_%d_%d", limit, offset, chain, protocol, tick, deployBy, sort, sortMode)
 	if ins, ok := s.cacheStore.Get(cacheKey); ok {
 		if allIns, ok := ins.(*FindAllInscriptionsResponse); ok {
@@ -72,6 +74,9 @@ func findInsciptions(s *RpcServer, limit, offset int, chain, protocol, tick, dep
 			TotalSupply:  ins.TotalSupply.String(),
 			Holders:      ins.Holders,
 			Minted:       ins.Minted.String(),
+			Burned:       ins.Burned.String(),
+			Circulating:  ins.Minted.Sub(ins.Burned).String(),
+			Wrapped:      ins.Wrapped.String(),
 			LimitPerMint: ins.LimitPerMint.String(),
 			TransferType: ins.TransferType,
 			Status:       model.MintStatusProcessing,
@@ -109,7 +114,7 @@ func findInsciptions(s *RpcServer, limit, offset int, chain, protocol, tick, dep
 
 func findTickHolders(s *RpcServer, limit int, offset int, chain, protocol, tick string, sortMode int) (interface{}, error) {
 	protocol = strings.ToLower(protocol)
-	tick = strings.ToLower(tick)
+	tick = normalizeTick(protocol, tick)
 	cacheKey := fmt.Sprintf("all_ins_%d_%d_%s_%s_%s_%d", limit, offset, chain, protocol, tick, sortMode)
 	if ins, ok := s.cacheStore.Get(cacheKey); ok {
 		if allIns, ok := ins.(*FindTickHoldersResponse); ok {
@@ -125,11 +130,14 @@ func findTickHolders(s *RpcServer, limit int, offset int, chain, protocol, tick
 	list := make([]*BalanceInfo, 0, len(holders))
 	for _, b := range holders {
 		balance := &BalanceInfo{
-			Chain:    b.Chain,
-			Protocol: b.Protocol,
-			Tick:     b.Tick,
-			Address:  b.Address,
-			Balance:  b.Balance.String(),
+			Chain:     b.Chain,
+			Protocol:  b.Protocol,
+			Tick:      b.Tick,
+			Address:   b.Address,
+			Balance:   b.Balance.String(),
+			Available: b.Available.String(),
+			Locked:    b.Balance.Sub(b.Available).String(),
+			ParseMode: b.ParseMode,
 		}
 		list = append(list, balance)
 	}
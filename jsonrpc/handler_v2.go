@@ -1,8 +1,13 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/evm/nameservice"
 	"github.com/uxuycom/indexer/xylog"
+	"strings"
 )
 
 var rpcHandlersBeforeInitV2 = map[string]commandHandler{
@@ -13,6 +18,10 @@ var rpcHandlersBeforeInitV2 = map[string]commandHandler{
 	"inds_getLastBlockNumberIndexed": handleGetLastBlockNumber,
 	"inds_getTickByCallData":         handleGetTxOperate,
 	"inds_getTransactionByHash":      handleGetTxByHash,
+	"inds_resolveName":               indsResolveName,
+	"inds_reverseResolveName":        indsReverseResolveName,
+	"inds_getTotalBalanceByAddress":  indsGetTotalBalanceByAddress,
+	"inds_getLocksByAddress":         indsGetLocksByAddress,
 	//"inscription.Tick":          handleFindInscriptionTick,
 	//"address.Balance": handleFindAddressBalance,
 }
@@ -46,3 +55,177 @@ func indsGetHoldersByTick(s *RpcServer, cmd interface{}, closeChan <-chan struct
 
 	return findTickHolders(s, req.Limit, req.Offset, req.Chain, req.Protocol, req.Tick, req.SortMode)
 }
+
+func indsResolveName(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsResolveNameCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("resolve name cmd params:%v", req)
+
+	item, err := s.dbc.FindName(req.Chain, nameservice.NormalizeName(req.Name))
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if item == nil {
+		return nil, errors.New("Record not found")
+	}
+	return buildNameInfo(item), nil
+}
+
+func indsReverseResolveName(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsReverseResolveNameCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("reverse resolve name cmd params:%v", req)
+
+	address := strings.ToLower(req.Address)
+	items, total, err := s.dbc.FindNamesByAddress(req.Chain, address, req.Limit, req.Offset)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	resp := &ReverseResolveNameResponse{
+		Address: address,
+		Names:   make([]*NameInfo, 0, len(items)),
+		Total:   total,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}
+	for _, item := range items {
+		resp.Names = append(resp.Names, buildNameInfo(item))
+	}
+
+	// primary name, the earliest registered one
+	if req.Offset == 0 && len(items) > 0 {
+		resp.Name = items[0].Name
+	}
+	return resp, nil
+}
+
+func indsGetTotalBalanceByAddress(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetTotalBalanceByAddressCmd)
+	if !ok || req.Tick == "" || req.Address == "" {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("find total balance cmd params:%v", req)
+
+	protocol := strings.ToLower(req.Protocol)
+	tick := normalizeTick(protocol, req.Tick)
+	balances, _, err := s.dbc.GetBalancesByAddress(1, 0, req.Address, req.Chain, protocol, tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	wrapped, err := s.dbc.FindWrappedBalances(req.Chain, protocol, tick, req.Address)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	balance := decimal.Zero
+	if len(balances) > 0 {
+		balance = balances[0].Balance
+	}
+
+	resp := &TotalBalanceInfo{
+		Chain:    req.Chain,
+		Protocol: protocol,
+		Tick:     tick,
+		Address:  req.Address,
+		Balance:  balance.String(),
+		Wrappers: make([]*WrappedBalanceInfo, 0, len(wrapped)),
+	}
+
+	total := decimal.Zero
+	for _, item := range wrapped {
+		total = total.Add(item.Amount)
+		resp.Wrappers = append(resp.Wrappers, &WrappedBalanceInfo{
+			Contract: item.Contract,
+			Amount:   item.Amount.String(),
+		})
+	}
+	resp.Wrapped = total.String()
+	resp.Total = balance.Add(total).String()
+	return resp, nil
+}
+
+func indsGetLocksByAddress(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetLocksByAddressCmd)
+	if !ok || req.Address == "" {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("find locks cmd params:%v", req)
+
+	protocol := strings.ToLower(req.Protocol)
+	tick := normalizeTick(protocol, req.Tick)
+	items, total, err := s.dbc.FindLocksByAddress(req.Chain, protocol, tick, req.Address, req.All, req.Limit, req.Offset)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	resp := &FindLocksResponse{
+		Address: req.Address,
+		Locks:   make([]*LockInfo, 0, len(items)),
+		Total:   total,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}
+
+	locked := decimal.Zero
+	for _, item := range items {
+		info, err := buildLockInfo(item)
+		if err != nil {
+			return ErrRPCInternal, err
+		}
+		locked = locked.Add(item.Amount.Sub(item.Released))
+		resp.Locks = append(resp.Locks, info)
+	}
+	resp.Locked = locked.String()
+	return resp, nil
+}
+
+func buildLockInfo(item *model.Locks) (*LockInfo, error) {
+	tranches := make([]*model.LockTranche, 0)
+	if err := json.Unmarshal([]byte(item.Schedule), &tranches); err != nil {
+		return nil, err
+	}
+
+	info := &LockInfo{
+		Chain:        item.Chain,
+		Protocol:     item.Protocol,
+		Tick:         item.Tick,
+		LockId:       item.LockId,
+		Address:      item.Address,
+		Amount:       item.Amount.String(),
+		Released:     item.Released.String(),
+		Locked:       item.Amount.Sub(item.Released).String(),
+		Schedule:     make([]*LockTrancheInfo, 0, len(tranches)),
+		UnlockHeight: item.UnlockHeight,
+		BlockHeight:  item.BlockHeight,
+		TxHash:       item.TxHash,
+		CreatedAt:    uint32(item.CreatedAt.Unix()),
+	}
+	for _, tranche := range tranches {
+		info.Schedule = append(info.Schedule, &LockTrancheInfo{
+			Height: tranche.Height,
+			Amount: tranche.Amount.String(),
+		})
+	}
+	return info, nil
+}
+
+func buildNameInfo(item *model.Names) *NameInfo {
+	return &NameInfo{
+		Chain:       item.Chain,
+		Protocol:    item.Protocol,
+		Name:        item.Name,
+		Owner:       item.Owner,
+		Address:     item.Address,
+		Avatar:      item.Avatar,
+		Text:        item.Text,
+		BlockHeight: item.BlockHeight,
+		TxHash:      item.TxHash,
+		CreatedAt:   uint32(item.CreatedAt.Unix()),
+	}
+}
@@ -25,13 +25,20 @@ package jsonrpc
 import (
 	"errors"
 	"fmt"
+	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol"
 	"github.com/uxuycom/indexer/storage"
+	"github.com/uxuycom/indexer/utils"
 	"github.com/uxuycom/indexer/xylog"
+	"net/http"
+	"strconv"
 	"strings"
 )
 
+// EthscriptionContentPath raw ethscription content endpoint
+const EthscriptionContentPath = "/v1/ethscriptions/content/"
+
 var rpcHandlersBeforeInit = map[string]commandHandler{
 	"inscription.All":           handleFindAllInscriptions,
 	"inscription.Tick":          handleFindInscriptionTick,
@@ -43,6 +50,10 @@ var rpcHandlersBeforeInit = map[string]commandHandler{
 	"tool.InscriptionTxOperate": handleGetTxOperate,
 	"transaction.Info":          handleGetTxByHash,
 	"tick.GetBriefs":            handleGetTickBriefs,
+	"ethscription.Info":         handleGetEthscription,
+	"ethscription.LastSN":       handleGetLastSN,
+	"market.Trades":             handleGetTrades,
+	"market.Stats":              handleGetMarketStats,
 }
 
 func handleFindAllInscriptions(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
@@ -62,7 +73,7 @@ func handleFindInscriptionTick(s *RpcServer, cmd interface{}, closeChan <-chan s
 	xylog.Logger.Infof("find inscriptions tick cmd params:%v", req)
 
 	req.Protocol = strings.ToLower(req.Protocol)
-	req.Tick = strings.ToLower(req.Tick)
+	req.Tick = normalizeTick(req.Protocol, req.Tick)
 
 	cacheKey := fmt.Sprintf("tick_%s_%s_%s", req.Chain, req.Protocol, req.Tick)
 	if ins, ok := s.cacheStore.Get(cacheKey); ok {
@@ -93,6 +104,27 @@ func handleFindInscriptionTick(s *RpcServer, cmd interface{}, closeChan <-chan s
 		CreatedAt:    uint32(data.CreatedAt.Unix()),
 		UpdatedAt:    uint32(data.UpdatedAt.Unix()),
 		Decimals:     data.Decimals,
+		MerkleRoot:   data.MerkleRoot,
+		Confusable:   data.ConfusableWith,
+		Premine:      data.Premine.String(),
+		MintPrice:    data.MintPrice.String(),
+		FeeTo:        data.FeeTo,
+		TickId:       data.TickId,
+		Workc:        data.Workc,
+		Fees:         decimal.Zero.String(),
+		Minted:       decimal.Zero.String(),
+		Burned:       decimal.Zero.String(),
+		Circulating:  decimal.Zero.String(),
+		Wrapped:      decimal.Zero.String(),
+	}
+
+	stat, _ := s.dbc.FindInscriptionsStatsByTick(data.Chain, data.Protocol, data.Tick)
+	if stat != nil {
+		resp.Minted = stat.Minted.String()
+		resp.Burned = stat.Burned.String()
+		resp.Circulating = stat.Minted.Sub(stat.Burned).String()
+		resp.Wrapped = stat.Wrapped.String()
+		resp.Fees = stat.Fees.String()
 	}
 	s.cacheStore.Set(cacheKey, resp)
 	return resp, nil
@@ -106,7 +138,7 @@ func handleFindAddressTransactions(s *RpcServer, cmd interface{}, closeChan <-ch
 	xylog.Logger.Infof("find user transactions cmd params:%v", req)
 
 	req.Protocol = strings.ToLower(req.Protocol)
-	req.Tick = strings.ToLower(req.Tick)
+	req.Tick = normalizeTick(req.Protocol, req.Tick)
 
 	cacheKey := fmt.Sprintf("addr_txs_%d_%d_%s_%s_%s_%s_%d", req.Limit, req.Offset, req.Address, req.Chain, req.Protocol, req.Tick, req.Event)
 	if ins, ok := s.cacheStore.Get(cacheKey); ok {
@@ -196,7 +228,7 @@ func handleFindAddressBalance(s *RpcServer, cmd interface{}, closeChan <-chan st
 	xylog.Logger.Infof("find user balance cmd params:%v", req)
 
 	req.Protocol = strings.ToLower(req.Protocol)
-	req.Tick = strings.ToLower(req.Tick)
+	req.Tick = normalizeTick(req.Protocol, req.Tick)
 	cacheKey := fmt.Sprintf("addr_balance_%s_%s_%s", req.Chain, req.Protocol, req.Tick)
 	if ins, ok := s.cacheStore.Get(cacheKey); ok {
 		if allIns, ok := ins.(*BalanceBrief); ok {
@@ -226,6 +258,7 @@ func handleFindAddressBalance(s *RpcServer, cmd interface{}, closeChan <-chan st
 		return nil, errors.New("Record not found")
 	}
 	resp.Balance = balance.Balance.String()
+	resp.ParseMode = balance.ParseMode
 
 	switch inscription.TransferType {
 	case model.TransferTypeHash:
@@ -305,7 +338,7 @@ func handleGetTxOperate(s *RpcServer, cmd interface{}, closeChan <-chan struct{}
 	}
 	var deployHash string
 	if operate.Protocol != "" && operate.Tick != "" {
-		inscription, err := s.dbc.FindInscriptionByTick(strings.ToLower(req.Chain), strings.ToLower(string(operate.Protocol)), strings.ToLower(operate.Tick))
+		inscription, err := s.dbc.FindInscriptionByTick(strings.ToLower(req.Chain), strings.ToLower(string(operate.Protocol)), normalizeTick(string(operate.Protocol), operate.Tick))
 		if err != nil {
 			xylog.Logger.Errorf("the query for the inscription failed. chain:%s protocol:%s tick:%s err=%s", req.Chain, string(operate.Protocol), operate.Tick, err)
 		}
@@ -359,6 +392,8 @@ func handleGetTxByHash(s *RpcServer, cmd interface{}, closeChan <-chan struct{})
 		Tick:     tx.Tick,
 		From:     tx.From,
 		To:       tx.To,
+
+		RuleVersion: tx.RuleVersion,
 	}
 
 	inscription, err := s.dbc.FindInscriptionByTick(tx.Chain, tx.Protocol, tx.Tick)
@@ -415,24 +450,34 @@ func handleGetTickBriefs(s *RpcServer, cmd interface{}, closeChan <-chan struct{
 		}
 		for _, dbTick := range dbTicks {
 			overview := &model.InscriptionOverView{
-				Chain:        dbTick.Chain,
-				Protocol:     dbTick.Protocol,
-				Tick:         dbTick.Tick,
-				Name:         dbTick.Name,
-				LimitPerMint: dbTick.LimitPerMint,
-				TotalSupply:  dbTick.TotalSupply,
-				DeployBy:     dbTick.DeployBy,
-				DeployHash:   dbTick.DeployHash,
-				DeployTime:   dbTick.DeployTime,
-				TransferType: dbTick.TransferType,
-				Decimals:     dbTick.Decimals,
-				CreatedAt:    dbTick.CreatedAt,
+				Chain:          dbTick.Chain,
+				Protocol:       dbTick.Protocol,
+				Tick:           dbTick.Tick,
+				Name:           dbTick.Name,
+				LimitPerMint:   dbTick.LimitPerMint,
+				TotalSupply:    dbTick.TotalSupply,
+				DeployBy:       dbTick.DeployBy,
+				DeployHash:     dbTick.DeployHash,
+				DeployTime:     dbTick.DeployTime,
+				TransferType:   dbTick.TransferType,
+				Decimals:       dbTick.Decimals,
+				CreatedAt:      dbTick.CreatedAt,
+				MerkleRoot:     dbTick.MerkleRoot,
+				ConfusableWith: dbTick.ConfusableWith,
+				Premine:        dbTick.Premine,
+				MintPrice:      dbTick.MintPrice,
+				FeeTo:          dbTick.FeeTo,
+				TickId:         dbTick.TickId,
+				Workc:          dbTick.Workc,
 			}
 			stat, _ := s.dbc.FindInscriptionsStatsByTick(dbTick.Chain, dbTick.Protocol, dbTick.Tick)
 			if stat != nil {
 				overview.Holders = stat.Holders
 				overview.Minted = stat.Minted
+				overview.Burned = stat.Burned
+				overview.Wrapped = stat.Wrapped
 				overview.TxCnt = stat.TxCnt
+				overview.Fees = stat.Fees
 			}
 			result = append(result, overview)
 		}
@@ -444,3 +489,165 @@ func handleGetTickBriefs(s *RpcServer, cmd interface{}, closeChan <-chan struct{
 
 	return resp, nil
 }
+
+func handleGetEthscription(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*GetEthscriptionCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get ethscription cmd params:%v", req)
+
+	item, err := findEthscription(s, req.Chain, req.Id)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if item == nil {
+		return nil, errors.New("Record not found")
+	}
+
+	return &EthscriptionInfo{
+		SN:          item.SID,
+		Id:          item.EthscriptionId,
+		Creator:     item.Creator,
+		Owner:       item.Owner,
+		ContentType: item.ContentType,
+		ContentHash: item.ContentHash,
+		ContentUrl:  fmt.Sprintf("%s%s/%s", EthscriptionContentPath, item.Chain, item.EthscriptionId),
+		Esip6:       item.Esip6,
+		BlockHeight: item.BlockHeight,
+		TxHash:      item.TxHash,
+		CreatedAt:   uint32(item.CreatedAt.Unix()),
+	}, nil
+}
+
+func handleGetLastSN(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*LastSNCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get last sn cmd params:%v", req)
+
+	sn, err := s.dbc.GetLastSN(req.Chain)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	return &LastSNResponse{Chain: req.Chain, LastSN: sn}, nil
+}
+
+func handleGetTrades(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*GetTradesCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get trades cmd params:%v", req)
+
+	req.Protocol = strings.ToLower(req.Protocol)
+	req.Tick = normalizeTick(req.Protocol, req.Tick)
+
+	trades, total, err := s.dbc.GetTrades(req.Limit, req.Offset, req.Chain, req.Protocol, req.Tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*TradeInfo, 0, len(trades))
+	for _, t := range trades {
+		list = append(list, &TradeInfo{
+			ListId:      t.ListId,
+			Market:      t.Market,
+			Seller:      t.Seller,
+			Buyer:       t.Buyer,
+			Amount:      t.Amount.String(),
+			UnitPrice:   t.UnitPrice.String(),
+			Total:       t.Total.String(),
+			BlockHeight: t.BlockHeight,
+			BlockTime:   uint32(t.BlockTime.Unix()),
+			TxHash:      t.TxHash,
+		})
+	}
+
+	return &GetTradesResponse{
+		Trades: list,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+func handleGetMarketStats(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*GetMarketStatsCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get market stats cmd params:%v", req)
+
+	req.Protocol = strings.ToLower(req.Protocol)
+	req.Tick = normalizeTick(req.Protocol, req.Tick)
+
+	item, err := s.dbc.FindMarketStats(req.Chain, req.Protocol, req.Tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if item == nil {
+		return ErrRPCRecordNotFound, errors.New("Record not found")
+	}
+
+	return &MarketStatsInfo{
+		Chain:         item.Chain,
+		Protocol:      item.Protocol,
+		Tick:          item.Tick,
+		Volume:        item.Volume.String(),
+		TradeCnt:      item.TradeCnt,
+		LastPrice:     item.LastPrice.String(),
+		FloorPrice:    item.FloorPrice.String(),
+		Volume24h:     item.Volume24h.String(),
+		TradeCnt24h:   item.TradeCnt24h,
+		LastTradeTime: item.LastTradeTime,
+	}, nil
+}
+
+// findEthscription find ethscription by creation tx hash or sequence number
+func findEthscription(s *RpcServer, chain, id string) (*model.Ethscriptions, error) {
+	if strings.HasPrefix(id, "0x") {
+		return s.dbc.FindEthscription(chain, strings.ToLower(id), 0)
+	}
+
+	sn, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ethscription id[%s]", id)
+	}
+	return s.dbc.FindEthscription(chain, "", sn)
+}
+
+// handleEthscriptionContent
+/***************************************
+ * serve raw ethscription content with its content type
+ * GET {EthscriptionContentPath}{chain}/{id or sn}
+ ***************************************/
+func (s *RpcServer) handleEthscriptionContent(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, EthscriptionContentPath), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "invalid ethscription path", http.StatusBadRequest)
+		return
+	}
+
+	item, err := findEthscription(s, parts[0], parts[1])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if item == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", item.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox") // user generated html & svg
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	_, _ = w.Write(item.Content)
+}
+
+// normalizeTick canonical tick of query inputs, the same as ticks indexed by the protocol
+func normalizeTick(proto, tick string) string {
+	return utils.NormalizeTick(tick, protocol.Rules(proto).CaseSensitive)
+}
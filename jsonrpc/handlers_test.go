@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package jsonrpc
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/cache_store"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/storage"
+	"github.com/uxuycom/indexer/xylog"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func TestFindInscriptionTickRules(t *testing.T) {
+	dbc, err := storage.NewDbClient(&config.DatabaseConfig{Type: storage.DatabaseTypeSqlite3, Dsn: filepath.Join(t.TempDir(), "indexer.db")})
+	assert.Nil(t, err)
+	assert.Nil(t, dbc.SqlDB.AutoMigrate(&model.Inscriptions{}, &model.InscriptionsStats{}))
+	for _, tick := range []string{"PePe", "ordi"} {
+		assert.Nil(t, dbc.SqlDB.Create(&model.Inscriptions{Chain: model.ChainAVAX, Protocol: "brc-20", Tick: tick, LimitPerMint: decimal.Zero, TotalSupply: decimal.Zero}).Error)
+	}
+	s := &RpcServer{dbc: dbc, cacheStore: cache_store.NewCacheStore(1, 60)}
+
+	find := func(tick string) interface{} {
+		resp, _ := handleFindInscriptionTick(s, &FindInscriptionTickCmd{Chain: model.ChainAVAX, Protocol: "BRC-20", Tick: tick}, nil)
+		return resp
+	}
+
+	// ticks of case-sensitive profiles queried as indexed
+	protocol.InitProtocols(&config.Config{
+		Chain:        config.ChainConfig{ChainName: model.ChainAVAX, Rules: map[string]string{"brc-20": "brc-20-cs"}},
+		RuleProfiles: map[string]*config.ProtocolRules{"brc-20-cs": {MaxDecimals: 18, ContractCalldata: true, CaseSensitive: true}},
+	}, dcache.NewManager(nil, model.ChainAVAX))
+	defer protocol.InitProtocols(&config.Config{}, dcache.NewManager(nil, ""))
+
+	resp, ok := find("PePe").(*InscriptionInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "PePe", resp.Tick)
+	assert.Equal(t, ErrRPCRecordNotFound, find("pepe"))
+
+	// lower cased by the default profile
+	protocol.InitProtocols(&config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}}, dcache.NewManager(nil, model.ChainAVAX))
+	resp, ok = find("ORDI").(*InscriptionInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "ordi", resp.Tick)
+}
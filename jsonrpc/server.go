@@ -648,6 +648,8 @@ func (s *RpcServer) Start() {
 		s.setRule(w, r)
 	})
 
+	rpcServeMux.HandleFunc(EthscriptionContentPath, s.handleEthscriptionContent)
+
 	rpcServeMux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
 		rpcHandlers = rpcHandlersBeforeInitV2
 		s.setRule(w, r)
@@ -33,6 +33,12 @@ const (
 	UTXOStatusSpent   = 2
 )
 
+// parse modes of inscription data, recorded with balance changes
+const (
+	ParseModeLenient = "lenient"
+	ParseModeStrict  = "strict"
+)
+
 type Balances struct {
 	ID        uint64          `gorm:"primaryKey" json:"id"`
 	SID       uint64          `json:"sid"  gorm:"column:sid"`
@@ -42,6 +48,7 @@ type Balances struct {
 	Tick      string          `json:"tick" gorm:"column:tick"`
 	Available decimal.Decimal `json:"available" gorm:"column:available;type:decimal(38,18)"` // available balance = overall balance - transferable balance
 	Balance   decimal.Decimal `json:"balance" gorm:"column:balance;type:decimal(38,18)"`     // overall balance
+	ParseMode string          `json:"parse_mode" gorm:"column:parse_mode"`                   // parse mode of the last balance change
 	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
 }
@@ -50,6 +57,41 @@ func (Balances) TableName() string {
 	return "balances"
 }
 
+// AddressMints minted amount of tick per address
+type AddressMints struct {
+	ID        uint64          `gorm:"primaryKey" json:"id"`
+	SID       uint64          `json:"sid"  gorm:"column:sid"`
+	Chain     string          `json:"chain" gorm:"column:chain"`
+	Protocol  string          `json:"protocol" gorm:"column:protocol"`
+	Address   string          `json:"address" gorm:"column:address"`
+	Tick      string          `json:"tick" gorm:"column:tick"`
+	Minted    decimal.Decimal `json:"minted" gorm:"column:minted;type:decimal(38,18)"`
+	MintCnt   uint64          `json:"mint_cnt" gorm:"column:mint_cnt"`
+	Claimed   decimal.Decimal `json:"claimed" gorm:"column:claimed;type:decimal(38,18)"` // allowlist claimed amount
+	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (AddressMints) TableName() string {
+	return "address_mints"
+}
+
+// MintNonces used proof of work nonces of tick per address
+type MintNonces struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	Chain     string    `json:"chain" gorm:"column:chain"`
+	Protocol  string    `json:"protocol" gorm:"column:protocol"`
+	Tick      string    `json:"tick" gorm:"column:tick"`
+	Address   string    `json:"address" gorm:"column:address"`
+	Nonce     string    `json:"nonce" gorm:"column:nonce"`
+	TxHash    string    `json:"tx_hash" gorm:"column:tx_hash"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (MintNonces) TableName() string {
+	return "mint_nonces"
+}
+
 type UTXO struct {
 	ID        uint64          `gorm:"primaryKey" json:"id"`
 	Sn        string          `json:"sn" gorm:"column:sn"`
@@ -60,7 +102,8 @@ type UTXO struct {
 	Amount    decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(38,18)"` // amount
 	RootHash  string          `json:"root_hash" gorm:"column:root_hash"`
 	TxHash    string          `json:"tx_hash" gorm:"column:tx_hash"`
-	Status    int8            `json:"status" gorm:"column:status"` // tx status
+	Outpoint  string          `json:"outpoint" gorm:"column:outpoint"` // txid:vout of utxo held balances
+	Status    int8            `json:"status" gorm:"column:status"`     // tx status
 	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
 }
@@ -75,6 +118,7 @@ type BalanceInscription struct {
 	Tick         string          `json:"tick"`
 	Address      string          `json:"address"`
 	Balance      decimal.Decimal `json:"balance"`
+	Available    decimal.Decimal `json:"available"`
 	DeployHash   string          `json:"deploy_hash"`
 	TransferType int8            `json:"transfer_type"`
 }
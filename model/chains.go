@@ -27,9 +27,14 @@ type ChainGroup string
 const (
 	EvmChainGroup ChainGroup = "evm"
 	BtcChainGroup ChainGroup = "btc"
+
+	// CosmosChainGroup cosmos-sdk chains, inscriptions are written into tx memos
+	CosmosChainGroup ChainGroup = "cosmos"
 )
 
 const (
 	ChainBTC  string = "btc"
 	ChainAVAX string = "avalanche"
+
+	ChainCelestia string = "celestia"
 )
@@ -0,0 +1,49 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"time"
+)
+
+// Ethscriptions non-fungible data uri inscriptions, sid is the chain inscription sequence number
+type Ethscriptions struct {
+	ID             uint64    `gorm:"primaryKey" json:"id"`
+	SID            uint64    `json:"sid" gorm:"column:sid"`
+	Chain          string    `json:"chain" gorm:"column:chain"`
+	EthscriptionId string    `json:"ethscription_id" gorm:"column:ethscription_id"` // creation tx hash
+	Creator        string    `json:"creator" gorm:"column:creator"`
+	Owner          string    `json:"owner" gorm:"column:owner"`
+	ContentType    string    `json:"content_type" gorm:"column:content_type"`
+	ContentHash    string    `json:"content_hash" gorm:"column:content_hash"` // sha256 of the data uri
+	Content        []byte    `json:"-" gorm:"column:content;type:mediumblob"`
+	Esip6          bool      `json:"esip6" gorm:"column:esip6"`
+	BlockHeight    uint64    `json:"block_height" gorm:"column:block_height"`
+	TxHash         string    `json:"tx_hash" gorm:"column:tx_hash"` // last transfer tx hash
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Ethscriptions) TableName() string {
+	return "ethscriptions"
+}
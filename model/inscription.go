@@ -55,6 +55,18 @@ type Inscriptions struct {
 	CreatedAt    time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt    time.Time       `json:"updated_at" gorm:"column:updated_at"`
 	Decimals     int8            `json:"decimals" gorm:"column:decimals"`
+
+	WalletLimit    decimal.Decimal `gorm:"column:wallet_limit;type:decimal(38,18)" json:"wallet_limit"`
+	StartBlock     uint64          `gorm:"column:start_block" json:"start_block"`
+	EndBlock       uint64          `gorm:"column:end_block" json:"end_block"`
+	BlockMintLimit uint64          `gorm:"column:block_mint_limit" json:"block_mint_limit"`
+	MerkleRoot     string          `gorm:"column:merkle_root" json:"merkle_root"`
+	ConfusableWith string          `gorm:"column:confusable_with" json:"confusable_with"`
+	Premine        decimal.Decimal `gorm:"column:premine;type:decimal(38,18)" json:"premine"`
+	MintPrice      decimal.Decimal `gorm:"column:mint_price;type:decimal(38,18)" json:"mint_price"`
+	FeeTo          string          `gorm:"column:fee_to" json:"fee_to"`
+	TickId         string          `gorm:"column:tick_id" json:"tick_id"` // protocol tick id, runes: etching block:tx
+	Workc          string          `gorm:"column:workc" json:"workc"`     // proof of work difficulty of mints
 }
 
 func (Inscriptions) TableName() string {
@@ -69,10 +81,13 @@ type InscriptionsStats struct {
 	Protocol          string          `json:"protocol" gorm:"column:protocol"`
 	Tick              string          `json:"tick" gorm:"column:tick"`
 	Minted            decimal.Decimal `gorm:"column:minted;type:decimal(38,18)" json:"minted"`
+	Burned            decimal.Decimal `gorm:"column:burned;type:decimal(38,18)" json:"burned"`
+	Wrapped           decimal.Decimal `gorm:"column:wrapped;type:decimal(38,18)" json:"wrapped"` // held by the bridges of the tick
 	MintCompletedTime *time.Time      `gorm:"column:mint_completed_time" json:"mint_completed_time"`
 	MintFirstBlock    uint64          `gorm:"column:mint_first_block" json:"mint_first_block"`
 	MintLastBlock     uint64          `gorm:"column:mint_last_block" json:"mint_last_block"`
 	LastSN            uint64          `gorm:"column:last_sn" json:"last_sn"`
+	Fees              decimal.Decimal `gorm:"column:fees;type:decimal(38,18)" json:"fees"`
 	Holders           uint64          `gorm:"column:holders" json:"holders"`
 	TxCnt             uint64          `gorm:"column:tx_cnt" json:"tx_cnt"`
 	CreatedAt         time.Time       `gorm:"column:created_at" json:"created_at"`
@@ -100,7 +115,22 @@ type InscriptionOverView struct {
 	Decimals     int8            `json:"decimals" gorm:"column:decimals"`
 	Holders      uint64          `json:"holders" gorm:"column:holders"`
 	Minted       decimal.Decimal `gorm:"column:minted;type:decimal(38,18)" json:"minted"`
+	Burned       decimal.Decimal `gorm:"column:burned;type:decimal(38,18)" json:"burned"`
+	Wrapped      decimal.Decimal `gorm:"column:wrapped;type:decimal(38,18)" json:"wrapped"`
 	TxCnt        uint64          `gorm:"column:tx_cnt" json:"tx_cnt"`
+	Fees         decimal.Decimal `gorm:"column:fees;type:decimal(38,18)" json:"fees"`
+
+	WalletLimit    decimal.Decimal `gorm:"column:wallet_limit;type:decimal(38,18)" json:"wallet_limit"`
+	StartBlock     uint64          `gorm:"column:start_block" json:"start_block"`
+	EndBlock       uint64          `gorm:"column:end_block" json:"end_block"`
+	BlockMintLimit uint64          `gorm:"column:block_mint_limit" json:"block_mint_limit"`
+	MerkleRoot     string          `gorm:"column:merkle_root" json:"merkle_root"`
+	ConfusableWith string          `gorm:"column:confusable_with" json:"confusable_with"`
+	Premine        decimal.Decimal `gorm:"column:premine;type:decimal(38,18)" json:"premine"`
+	MintPrice      decimal.Decimal `gorm:"column:mint_price;type:decimal(38,18)" json:"mint_price"`
+	FeeTo          string          `gorm:"column:fee_to" json:"fee_to"`
+	TickId         string          `gorm:"column:tick_id" json:"tick_id"`
+	Workc          string          `gorm:"column:workc" json:"workc"`
 }
 
 type InscriptionBrief struct {
@@ -116,6 +146,9 @@ type InscriptionBrief struct {
 	TransferType  int8   `json:"transfer_type"`
 	Status        uint32 `json:"status"`
 	Minted        string `json:"minted"`
+	Burned        string `json:"burned"`
+	Circulating   string `json:"circulating_supply"` // minted - burned
+	Wrapped       string `json:"wrapped_supply"`     // held by the bridges of the tick
 	TxCnt         uint64 `json:"tx_cnt"`
 	CreatedAt     uint32 `json:"created_at"`
 }
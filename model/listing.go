@@ -0,0 +1,58 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ListingStatusListed   = 1
+	ListingStatusDelisted = 2
+	ListingStatusFilled   = 3
+)
+
+// Listings marketplace listings, listed amount is locked out of the seller's available balance
+type Listings struct {
+	ID        uint64          `gorm:"primaryKey" json:"id"`
+	SID       uint64          `json:"sid"  gorm:"column:sid"`
+	Chain     string          `json:"chain" gorm:"column:chain"`
+	Protocol  string          `json:"protocol" gorm:"column:protocol"`
+	Tick      string          `json:"tick" gorm:"column:tick"`
+	ListId    string          `json:"list_id" gorm:"column:list_id"` // list tx hash
+	Seller    string          `json:"seller" gorm:"column:seller"`
+	Market    string          `json:"market" gorm:"column:market"` // marketplace contract
+	Buyer     string          `json:"buyer" gorm:"column:buyer"`
+	Amount    decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(38,18)"`
+	Price     decimal.Decimal `json:"price" gorm:"column:price;type:decimal(38,18)"` // unit price in native coin
+	Status    int8            `json:"status" gorm:"column:status"`
+	TxHash    string          `json:"tx_hash" gorm:"column:tx_hash"` // last state transition tx hash
+	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Listings) TableName() string {
+	return "listings"
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"time"
+)
+
+// Names name service registrations, address is the resolved address of the name
+type Names struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	SID         uint64    `json:"sid" gorm:"column:sid"`
+	Chain       string    `json:"chain" gorm:"column:chain"`
+	Protocol    string    `json:"protocol" gorm:"column:protocol"`
+	Name        string    `json:"name" gorm:"column:name"`
+	Owner       string    `json:"owner" gorm:"column:owner"`
+	Address     string    `json:"address" gorm:"column:address"` // address record, owner if not set
+	Avatar      string    `json:"avatar" gorm:"column:avatar"`
+	Text        string    `json:"text" gorm:"column:text"`
+	BlockHeight uint64    `json:"block_height" gorm:"column:block_height"` // registration block
+	TxHash      string    `json:"tx_hash" gorm:"column:tx_hash"`           // last tx hash of the name
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Names) TableName() string {
+	return "names"
+}
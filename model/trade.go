@@ -0,0 +1,74 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Trades filled marketplace orders, prices in native coin
+type Trades struct {
+	ID          uint64          `gorm:"primaryKey" json:"id"`
+	Chain       string          `json:"chain" gorm:"column:chain"`
+	Protocol    string          `json:"protocol" gorm:"column:protocol"`
+	Tick        string          `json:"tick" gorm:"column:tick"`
+	ListId      string          `json:"list_id" gorm:"column:list_id"` // empty if not a listing order
+	Market      string          `json:"market" gorm:"column:market"`   // marketplace contract
+	Seller      string          `json:"seller" gorm:"column:seller"`
+	Buyer       string          `json:"buyer" gorm:"column:buyer"`
+	Amount      decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(38,18)"`
+	UnitPrice   decimal.Decimal `json:"unit_price" gorm:"column:unit_price;type:decimal(38,18)"`
+	Total       decimal.Decimal `json:"total" gorm:"column:total;type:decimal(38,18)"`
+	BlockHeight uint64          `json:"block_height" gorm:"column:block_height"`
+	BlockTime   time.Time       `json:"block_time" gorm:"column:block_time"`
+	TxHash      string          `json:"tx_hash" gorm:"column:tx_hash"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Trades) TableName() string {
+	return "trades"
+}
+
+// MarketStats per tick market stats, 24h values are rolling window as of the last indexed block
+type MarketStats struct {
+	ID            uint32          `gorm:"primaryKey" json:"id"`
+	SID           uint32          `json:"sid"  gorm:"column:sid"`
+	Chain         string          `json:"chain" gorm:"column:chain"`
+	Protocol      string          `json:"protocol" gorm:"column:protocol"`
+	Tick          string          `json:"tick" gorm:"column:tick"`
+	Volume        decimal.Decimal `json:"volume" gorm:"column:volume;type:decimal(38,18)"`
+	TradeCnt      uint64          `json:"trade_cnt" gorm:"column:trade_cnt"`
+	LastPrice     decimal.Decimal `json:"last_price" gorm:"column:last_price;type:decimal(38,18)"`
+	FloorPrice    decimal.Decimal `json:"floor_price" gorm:"column:floor_price;type:decimal(38,18)"`
+	Volume24h     decimal.Decimal `json:"volume_24h" gorm:"column:volume_24h;type:decimal(38,18)"`
+	TradeCnt24h   uint64          `json:"trade_cnt_24h" gorm:"column:trade_cnt_24h"`
+	LastTradeTime uint64          `json:"last_trade_time" gorm:"column:last_trade_time"` // unix seconds
+	CreatedAt     time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (MarketStats) TableName() string {
+	return "market_stats"
+}
@@ -42,6 +42,11 @@ const (
 	TransactionEventList     TxEvent = 4
 	TransactionEventDelist   TxEvent = 5
 	TransactionEventExchange TxEvent = 6
+	TransactionEventBurn     TxEvent = 7
+	TransactionEventPremine  TxEvent = 8 // balance event only, deployer allocation
+	TransactionEventLock     TxEvent = 9
+	TransactionEventVest     TxEvent = 10
+	TransactionEventUnlock   TxEvent = 11 // balance event only, released by block height
 )
 
 type TransactionRaw struct {
@@ -96,6 +101,7 @@ type BalanceTxn struct {
 	Available decimal.Decimal `json:"available" gorm:"column:available;type:decimal(38,18)"`
 	Balance   decimal.Decimal `json:"balance" gorm:"column:balance;type:decimal(38,18)"`
 	TxHash    string          `json:"tx_hash" gorm:"column:tx_hash"`
+	ParseMode string          `json:"parse_mode" gorm:"column:parse_mode"`
 	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
 }
@@ -120,6 +126,7 @@ type Transaction struct {
 	Gas             int64           `json:"gas" gorm:"column:gas"`                             // gas
 	GasPrice        int64           `json:"gas_price" gorm:"column:gas_price"`                 // gas price
 	Status          int8            `json:"status" gorm:"column:status"`                       // tx status
+	RuleVersion     string          `json:"rule_version" gorm:"column:rule_version"`           // active protocol rule version
 	CreatedAt       time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt       time.Time       `json:"updated_at" gorm:"column:updated_at"`
 }
@@ -0,0 +1,46 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// WrappedBalances erc-20 wrapped balances of bridged ticks
+type WrappedBalances struct {
+	ID        uint64          `gorm:"primaryKey" json:"id"`
+	SID       uint64          `json:"sid" gorm:"column:sid"`
+	Chain     string          `json:"chain" gorm:"column:chain"`
+	Protocol  string          `json:"protocol" gorm:"column:protocol"`
+	Tick      string          `json:"tick" gorm:"column:tick"`
+	Contract  string          `json:"contract" gorm:"column:contract"` // erc-20 wrapper contract
+	Address   string          `json:"address" gorm:"column:address"`
+	Amount    decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(38,18)"`
+	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (WrappedBalances) TableName() string {
+	return "wrapped_balances"
+}
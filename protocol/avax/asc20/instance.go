@@ -23,67 +23,53 @@
 package asc20
 
 import (
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol/common"
 	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
-	"github.com/uxuycom/indexer/xylog"
-	"strings"
-	"sync"
 )
 
+func init() {
+	types.Register(&types.Registration{
+		ChainGroup: model.EvmChainGroup,
+		Protocol:   types.ASC20Protocol,
+		Operates: []string{
+			devents.OperateDeploy,
+			devents.OperateMint,
+			devents.OperateTransfer,
+			devents.OperateBurn,
+			devents.OperateList,
+			devents.OperateExchange,
+			devents.OperateWrap,
+			devents.OperateLock,
+			devents.OperateVest,
+		},
+		FastCheck: common.FastCheckDataPrefix,
+		New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+			return NewProtocol(cache, rules)
+		},
+	})
+}
+
 type Protocol struct {
 	common *common.Protocol
 	cache  *dcache.Manager
-	ticks  *sync.Map // record deploy tx -> tick code
 }
 
-var ParsedABI abi.ABI
-
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
 	return &Protocol{
-		common: common.NewProtocol(cache),
+		common: common.NewProtocol(cache, rules),
 		cache:  cache,
-		ticks:  &sync.Map{},
 	}
 }
 
 func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
-	switch md.Operate {
-	case devents.OperateList:
+	p.common.ResolveRules(block, md)
+	if md.Operate == devents.OperateList {
 		return p.List(block, tx, md)
-
-	case devents.OperateExchange:
-		return p.Exchange(block, tx, md)
 	}
 	return p.common.Parse(block, tx, md)
 }
-
-func ParseMetaDataByEventLogs(chain string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
-	for _, event := range tx.Events {
-		if len(event.Topics) < 1 {
-			continue
-		}
-
-		topic := event.Topics[0].String()
-		if topic == EventTopicHashExchange || topic == EventTopicHashExchange2 {
-			return &devents.MetaData{
-				Chain:    chain,
-				Protocol: types.ASC20Protocol,
-				Operate:  devents.OperateExchange,
-			}, nil
-		}
-	}
-	return nil, nil
-}
-
-func init() {
-	var err error
-	ParsedABI, err = abi.JSON(strings.NewReader(abiJSON))
-	if err != nil {
-		xylog.Logger.Fatalf("asc20 abi decode err:%v", err)
-	}
-}
@@ -28,11 +28,14 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/common"
 	"github.com/uxuycom/indexer/xyerrors"
 )
 
 type List struct {
 	Amount decimal.Decimal `json:"amt"`
+	Price  decimal.Decimal `json:"price"` // optional unit price in native coin
 }
 
 func (p *Protocol) List(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
@@ -40,24 +43,29 @@ func (p *Protocol) List(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, m
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
+
+	// listed amount is locked in seller's balance till delisted or filled
 	result := &devents.TxResult{
 		MD:    md,
 		Block: block,
 		Tx:    tx,
-		Transfer: &devents.Transfer{
-			Sender: tx.From,
-			Receives: []*devents.Receive{
-				{
-					Address: tx.To,
-					Amount:  list.Amount,
-				},
-			},
+		Listing: &devents.Listing{
+			ListId: tx.Hash,
+			Seller: tx.From,
+			Market: tx.To,
+			Amount: list.Amount,
+			Price:  list.Price,
+			Status: model.ListingStatusListed,
 		},
 	}
 	return []*devents.TxResult{result}, nil
 }
 
 func (p *Protocol) verifyList(tx *xycommon.RpcTransaction, md *devents.MetaData) (*List, *xyerrors.InsError) {
+	if err := p.common.VerifyGrammar(md); err != nil {
+		return nil, err
+	}
+
 	tf := &List{}
 	err := json.Unmarshal([]byte(md.Data), tf)
 	if err != nil {
@@ -68,6 +76,10 @@ func (p *Protocol) verifyList(tx *xycommon.RpcTransaction, md *devents.MetaData)
 		return nil, xyerrors.NewInsError(-14, "list amount <= 0")
 	}
 
+	if tf.Price.IsNegative() || -tf.Price.Exponent() > common.NativeDecimals {
+		return nil, xyerrors.NewInsError(-46, fmt.Sprintf("invalid price:%s", tf.Price.String()))
+	}
+
 	var (
 		protocol = md.Protocol
 		tick     = md.Tick
@@ -77,6 +89,10 @@ func (p *Protocol) verifyList(tx *xycommon.RpcTransaction, md *devents.MetaData)
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
 	}
 
+	if err := p.common.VerifyPrecision(md, "amt", tf.Amount, inscription.Decimals); err != nil {
+		return nil, err
+	}
+
 	// sender balance checking
 	ok, balance := p.cache.Balance.Get(protocol, tick, tx.From)
 	if !ok {
@@ -84,8 +100,12 @@ func (p *Protocol) verifyList(tx *xycommon.RpcTransaction, md *devents.MetaData)
 	}
 
 	// balance available checking
-	if balance.Overall.LessThan(tf.Amount) {
-		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender total balance[%v] < transfer amount[%v]", balance.Overall, tf.Amount))
+	if balance.Available.LessThan(tf.Amount) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < list amount[%v]", balance.Available, tf.Amount))
+	}
+
+	if ok, _ := p.cache.Listing.Get(tx.Hash); ok {
+		return nil, xyerrors.NewInsError(-30, fmt.Sprintf("listing[%s] exist", tx.Hash))
 	}
 	return tf, nil
 }
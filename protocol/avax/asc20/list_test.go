@@ -0,0 +1,176 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package asc20_test
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/avax/asc20"
+	"github.com/uxuycom/indexer/protocol/market"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+// avascriptionsABI marketplace events of the avascriptions adapters
+const avascriptionsABI = "../../../config/abi/avascriptions.json"
+
+func TestListingLifecycle(t *testing.T) {
+	var (
+		seller = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+		buyer  = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+		mkt    = common.HexToAddress("0x24e24277e2FF8828d5d2e278764CA258C22BD497")
+		block  = &xycommon.RpcBlock{Number: big.NewInt(1)}
+	)
+	assert.Nil(t, market.Init([]*config.MarketplaceConfig{
+		{
+			Name: "avascriptions", Protocol: types.ASC20Protocol, Contract: mkt.String(), AbiFile: avascriptionsABI, Event: "ASC20OrderExecuted", Escrow: true,
+			Fields: config.MarketplaceFields{Seller: "seller", Buyer: "taker", Tick: "ticker", Amount: "amount", Price: "price", ListId: "listId"},
+		},
+		{
+			Name: "avascriptions-cancel", Protocol: types.ASC20Protocol, Contract: mkt.String(), AbiFile: avascriptionsABI, Event: "ASC20OrderCanceled", Operate: devents.OperateDelist, Escrow: true,
+			Fields: config.MarketplaceFields{Seller: "seller", ListId: "listId"},
+		},
+	}))
+	defer market.Init(nil)
+
+	fd, err := os.Open(avascriptionsABI)
+	assert.Nil(t, err)
+	defer fd.Close()
+	parsed, err := abi.JSON(fd)
+	assert.Nil(t, err)
+
+	cache := testutil.NewCache("avax")
+	p := asc20.NewProtocol(cache, nil)
+	handler := devents.NewTxResultHandler(cache)
+
+	md := func(operate, data string) *devents.MetaData {
+		return &devents.MetaData{Chain: "avax", Protocol: types.ASC20Protocol, Operate: operate, Tick: "avav", Data: data}
+	}
+	handle := func(tx *xycommon.RpcTransaction, md *devents.MetaData) []*devents.TxResult {
+		results, err := p.Parse(block, tx, md)
+		assert.Nil(t, err)
+		for _, r := range results {
+			handler.UpdateCache(r)
+		}
+		return results
+	}
+	listId := func(n int) string {
+		return fmt.Sprintf("0x%064x", n)
+	}
+	list := func(n int, amount, price string) {
+		handle(&xycommon.RpcTransaction{Hash: listId(n), From: seller.String(), To: mkt.String()}, md(devents.OperateList, `{"amt":"`+amount+`","price":"`+price+`"}`))
+	}
+	executed := func(n int, tick string, amount int64) xycommon.RpcLog {
+		data, err := parsed.Events["ASC20OrderExecuted"].Inputs.Pack(seller, buyer, common.HexToHash(listId(n)), tick, big.NewInt(amount), big.NewInt(15e17), uint16(200), uint64(1))
+		assert.Nil(t, err)
+		return xycommon.RpcLog{Address: mkt, Topics: []common.Hash{parsed.Events["ASC20OrderExecuted"].ID}, Data: data}
+	}
+	canceled := func(n int) xycommon.RpcLog {
+		data, err := parsed.Events["ASC20OrderCanceled"].Inputs.Pack(seller, common.HexToHash(listId(n)), uint64(1))
+		assert.Nil(t, err)
+		return xycommon.RpcLog{Address: mkt, Topics: []common.Hash{parsed.Events["ASC20OrderCanceled"].ID}, Data: data}
+	}
+	orders := func(events ...xycommon.RpcLog) []*devents.TxResult {
+		tx := &xycommon.RpcTransaction{Hash: "0xff", Events: events}
+		return handle(tx, market.ParseMetaData("avax", tx))
+	}
+
+	handle(&xycommon.RpcTransaction{From: seller.String(), To: seller.String()}, md(devents.OperateDeploy, `{"p":"asc-20","op":"deploy","tick":"avav","max":"1000","lim":"100"}`))
+	handle(&xycommon.RpcTransaction{From: seller.String(), To: seller.String()}, md(devents.OperateMint, `{"p":"asc-20","op":"mint","tick":"avav","amt":"100"}`))
+
+	// listed amount locked out of available
+	list(1, "60", "0")
+	_, balance := cache.Balance.Get(types.ASC20Protocol, "avav", seller.String())
+	assert.Equal(t, "100", balance.Overall.String())
+	assert.Equal(t, "40", balance.Available.String())
+
+	_, err1 := p.Parse(block, &xycommon.RpcTransaction{Hash: listId(2), From: seller.String(), To: mkt.String()}, md(devents.OperateList, `{"amt":"41"}`))
+	assert.NotNil(t, err1)
+	_, err1 = p.Parse(block, &xycommon.RpcTransaction{Hash: listId(2), From: seller.String(), To: mkt.String()}, md(devents.OperateList, `{"amt":"1","price":"-1"}`))
+	assert.NotNil(t, err1)
+
+	// delisted back to seller
+	results := orders(canceled(1))
+	assert.Len(t, results, 1)
+	assert.Equal(t, devents.OperateDelist, results[0].MD.Operate)
+	assert.Nil(t, results[0].Trade)
+	assert.Nil(t, results[0].Transfer)
+	assert.Equal(t, "100", balance.Available.String())
+
+	// settled listings & orders of other contracts are ignored
+	forged := executed(3, "avav", 30)
+	forged.Address = buyer
+	assert.Len(t, orders(executed(1, "avav", 60), forged), 0)
+
+	// filled to buyer, listing settled once in a tx
+	list(3, "30", "0.05")
+	list(4, "10", "0.04")
+	assert.Len(t, orders(executed(3, "avav", 31)), 0)
+	results = orders(executed(3, "avav", 30), executed(3, "avav", 30))
+	assert.Len(t, results, 1)
+	assert.Equal(t, seller.String(), results[0].Transfer.Sender)
+	assert.Equal(t, listId(3), results[0].Trade.ListId)
+	assert.Equal(t, "0.05", results[0].Trade.UnitPrice.String())
+
+	// floor price by the open listings
+	_, stats := cache.Market.Get(types.ASC20Protocol, "avav")
+	assert.Equal(t, "0.04", stats.FloorPrice.String())
+	assert.Equal(t, "0.04", cache.Listing.Floor(types.ASC20Protocol, "avav").String())
+	orders(canceled(4))
+	assert.True(t, stats.FloorPrice.IsZero())
+
+	assert.Equal(t, "70", balance.Overall.String())
+	assert.Equal(t, "70", balance.Available.String())
+	_, buyerBalance := cache.Balance.Get(types.ASC20Protocol, "avav", buyer.String())
+	assert.Equal(t, "30", buyerBalance.Overall.String())
+
+	_, listing := cache.Listing.Get(listId(3))
+	assert.Equal(t, int8(model.ListingStatusFilled), listing.Status)
+	assert.Equal(t, buyer.String(), listing.Buyer)
+
+	// listings by plain transfers to the market have no records, settled as escrow transfers
+	handle(&xycommon.RpcTransaction{From: seller.String(), To: mkt.String()}, md(devents.OperateTransfer, `{"p":"asc-20","op":"transfer","tick":"avav","amt":"20"}`))
+	results = orders(executed(5, "avav", 20))
+	assert.Len(t, results, 1)
+	assert.Nil(t, results[0].Listing)
+	assert.Equal(t, mkt.String(), results[0].Transfer.Sender)
+	assert.Equal(t, "50", buyerBalance.Overall.String())
+
+	assert.Len(t, orders(executed(6, "avav", 1)), 0)
+	assert.Len(t, orders(canceled(6)), 0)
+}
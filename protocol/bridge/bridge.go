@@ -0,0 +1,145 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package bridge
+
+import (
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/xylog"
+	"sort"
+	"strings"
+)
+
+// wrappers enabled erc-20 wrappers of the indexing chain
+var wrappers []*Wrapper
+
+// Init
+/***************************************
+ * build wrappers of configured bridges, replace the enabled ones
+ ***************************************/
+func Init(cfgs []*config.BridgeConfig) error {
+	items := make([]*Wrapper, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		w, err := NewWrapper(cfg)
+		if err != nil {
+			return err
+		}
+		items = append(items, w)
+		xylog.Logger.Infof("bridge[%s] enabled, tick[%s-%s], contract[%s], bridge[%s]", cfg.Name, w.Protocol(), w.Tick(), cfg.Contract, w.Bridge())
+	}
+	wrappers = items
+	return nil
+}
+
+// Wrappers returns enabled wrappers
+func Wrappers() []*Wrapper {
+	return wrappers
+}
+
+// EventTopics returns event topics of enabled wrappers
+func EventTopics() []string {
+	exists := make(map[string]struct{}, len(wrappers))
+	topics := make([]string, 0, len(wrappers))
+	for _, w := range wrappers {
+		for _, topic := range w.Topics() {
+			topic = strings.ToLower(topic)
+			if _, ok := exists[topic]; ok {
+				continue
+			}
+			exists[topic] = struct{}{}
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// FastCheck reports whether the tx carries events of any wrapper
+func FastCheck(tx *xycommon.RpcTransaction) bool {
+	return match(tx) != nil
+}
+
+func match(tx *xycommon.RpcTransaction) *Wrapper {
+	for i := range tx.Events {
+		for _, w := range wrappers {
+			if w.Match(&tx.Events[i]) {
+				return w
+			}
+		}
+	}
+	return nil
+}
+
+// ParseMetaData
+/***************************************
+ * wrap metadata of each protocol of matched wrapper events, in order of the events
+ ***************************************/
+func ParseMetaData(chain string, tx *xycommon.RpcTransaction) []*devents.MetaData {
+	var items []*devents.MetaData
+	exists := make(map[string]struct{})
+	for i := range tx.Events {
+		for _, w := range wrappers {
+			if !w.Match(&tx.Events[i]) {
+				continue
+			}
+
+			if _, ok := exists[w.Protocol()]; ok {
+				continue
+			}
+			exists[w.Protocol()] = struct{}{}
+			items = append(items, &devents.MetaData{
+				Chain:    chain,
+				Protocol: w.Protocol(),
+				Operate:  devents.OperateWrap,
+				Tick:     w.Tick(),
+			})
+		}
+	}
+	return items
+}
+
+// ExtractMovements
+/***************************************
+ * decode wrapped token movements of the protocol's wrappers in tx events
+ ***************************************/
+func ExtractMovements(protocol string, tx *xycommon.RpcTransaction) []*Movement {
+	items := make([]*Movement, 0, len(tx.Events))
+	for i := range tx.Events {
+		for _, w := range wrappers {
+			if w.Protocol() != protocol || !w.Match(&tx.Events[i]) {
+				continue
+			}
+
+			m, err := w.Decode(&tx.Events[i])
+			if err != nil {
+				xylog.Logger.Infof("tx[%s] - bridge[%s] event decode err:%v", tx.Hash, w.cfg.Name, err)
+				continue
+			}
+			if m != nil {
+				items = append(items, m)
+			}
+		}
+	}
+	return items
+}
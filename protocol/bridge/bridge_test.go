@@ -0,0 +1,203 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package bridge_test
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+const testBridgeABI = `[
+	{"type":"event","name":"Wrapped","anonymous":false,"inputs":[
+		{"name":"account","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"Unwrapped","anonymous":false,"inputs":[
+		{"name":"account","type":"address","indexed":true},
+		{"name":"amount","type":"uint256","indexed":false}
+	]}
+]`
+
+func TestBridges(t *testing.T) {
+	abiFile := filepath.Join(t.TempDir(), "bridge.json")
+	assert.Nil(t, os.WriteFile(abiFile, []byte(testBridgeABI), 0644))
+	parsed, err := abi.JSON(strings.NewReader(testBridgeABI))
+	assert.Nil(t, err)
+
+	var (
+		alice    = ethcommon.HexToAddress("0x00000000000000000000000000000000000000a1")
+		bob      = ethcommon.HexToAddress("0x00000000000000000000000000000000000000b2")
+		carol    = ethcommon.HexToAddress("0x00000000000000000000000000000000000000c3")
+		wrapper1 = ethcommon.HexToAddress("0x00000000000000000000000000000000000000d4")
+		wrapper2 = ethcommon.HexToAddress("0x00000000000000000000000000000000000000e5")
+		vault    = ethcommon.HexToAddress("0x00000000000000000000000000000000000000f6")
+		zero     = ethcommon.Address{}
+	)
+	cfg := &config.Config{Chain: config.ChainConfig{
+		ChainName: "eth",
+		Bridges: []*config.BridgeConfig{
+			{Name: "b1", Protocol: "brc-20", Tick: "DINO", Contract: wrapper1.String(), AmountDecimals: 18},
+			{
+				Name: "b2", Protocol: "brc-20", Tick: "dino", Bridge: vault.String(), Contract: wrapper2.String(), AbiFile: abiFile,
+				Mint: &config.BridgeEventConfig{Event: "Wrapped", Account: "account", Amount: "amount"},
+				Burn: &config.BridgeEventConfig{Event: "Unwrapped", Account: "account", Amount: "amount"},
+			},
+		},
+	}}
+	h := testutil.NewHarness(t, cfg)
+	assert.Contains(t, protocol.EventTopics(), strings.ToLower(parsed.Events["Unwrapped"].ID.String()))
+	assert.Equal(t, []string{wrapper1.String(), vault.String()}, h.Cache.Wrapped.Bridges("brc-20", "dino"))
+
+	transfer := func(contract, from, to ethcommon.Address, amount *big.Int) xycommon.RpcLog {
+		return xycommon.RpcLog{
+			Address: contract,
+			Topics:  []ethcommon.Hash{crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")), ethcommon.BytesToHash(from.Bytes()), ethcommon.BytesToHash(to.Bytes())},
+			Data:    ethcommon.LeftPadBytes(amount.Bytes(), 32),
+		}
+	}
+	event := func(name string, account ethcommon.Address, amount int64) xycommon.RpcLog {
+		data, err := parsed.Events[name].Inputs.NonIndexed().Pack(big.NewInt(amount))
+		assert.Nil(t, err)
+		return xycommon.RpcLog{
+			Address: wrapper2,
+			Topics:  []ethcommon.Hash{parsed.Events[name].ID, ethcommon.BytesToHash(account.Bytes())},
+			Data:    data,
+		}
+	}
+	wrapped := func(contract, address ethcommon.Address) string {
+		_, item := h.Cache.Wrapped.Get("brc-20", "dino", contract.String(), address.String())
+		if item == nil {
+			return "0"
+		}
+		return item.Amount.String()
+	}
+	token := func(amount int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+	}
+
+	// wrapped tick must be deployed
+	_, results, err1 := h.Handle(&xycommon.RpcTransaction{Hash: "0x00", Events: []xycommon.RpcLog{transfer(wrapper1, zero, alice, token(1))}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 0)
+
+	sender := alice.String()
+	_, err1 = h.Inscribe(sender, sender, `data:,{"p":"brc-20","op":"deploy","tick":"dino","max":"1000","lim":"100"}`)
+	assert.Nil(t, err1)
+	_, err1 = h.Inscribe(sender, sender, `data:,{"p":"brc-20","op":"mint","tick":"dino","amt":"100"}`)
+	assert.Nil(t, err1)
+
+	// inscriptions sent to the bridges are the wrapped supply
+	for _, to := range []ethcommon.Address{wrapper1, vault} {
+		results, err1 = h.Inscribe(sender, to.String(), `data:,{"p":"brc-20","op":"transfer","tick":"dino","amt":"30"}`)
+		assert.Nil(t, err1)
+	}
+	assert.Equal(t, "60", h.Handler.BuildInscriptionStat(results[0])[devents.DBActionUpdate].Wrapped.String())
+
+	// wrap by Transfer from the zero address, wrapped tokens moved twice in a tx
+	_, results, err1 = h.Handle(&xycommon.RpcTransaction{Hash: "0x01", Events: []xycommon.RpcLog{
+		transfer(wrapper1, zero, alice, token(30)),
+		transfer(wrapper1, alice, bob, token(20)),
+		transfer(wrapper1, bob, carol, token(15)),
+	}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 3)
+	assert.Equal(t, devents.OperateWrap, results[0].MD.Operate)
+	assert.Equal(t, "dino", results[0].MD.Tick)
+	assert.Equal(t, "", results[0].Wrap.From)
+	assert.True(t, results[0].Wrap.Init)
+	for addr, amount := range map[ethcommon.Address]string{alice: "10", bob: "5", carol: "15"} {
+		assert.Equal(t, amount, wrapped(wrapper1, addr))
+	}
+
+	// inscription balances untouched
+	_, balance := h.Cache.Balance.Get("brc-20", "dino", sender)
+	assert.Equal(t, "40", balance.Overall.String())
+
+	models := h.Handler.BuildWrappedBalance(results[2])
+	assert.Len(t, models[devents.DBActionUpdate], 1)
+	assert.Len(t, models[devents.DBActionCreate], 1)
+	assert.Equal(t, carol.String(), models[devents.DBActionCreate][0].Address)
+
+	// unwrap more than the wrapped balance is ignored
+	_, results, err1 = h.Handle(&xycommon.RpcTransaction{Hash: "0x02", Events: []xycommon.RpcLog{transfer(wrapper1, carol, zero, token(20))}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 0)
+
+	_, results, err1 = h.Handle(&xycommon.RpcTransaction{Hash: "0x03", Events: []xycommon.RpcLog{transfer(wrapper1, carol, zero, token(5))}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "", results[0].Wrap.To)
+	assert.Equal(t, "10", wrapped(wrapper1, carol))
+
+	// custom wrap & unwrap events, Transfer from / to the zero address not double counted
+	_, results, err1 = h.Handle(&xycommon.RpcTransaction{Hash: "0x04", Events: []xycommon.RpcLog{
+		transfer(wrapper2, zero, bob, big.NewInt(25)),
+		event("Wrapped", bob, 25),
+		event("Unwrapped", bob, 5),
+		transfer(wrapper2, bob, zero, big.NewInt(5)),
+	}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "20", wrapped(wrapper2, bob))
+	assert.Equal(t, "5", wrapped(wrapper1, bob))
+
+	// wraps above the inscriptions held by the bridge are ignored
+	_, results, err1 = h.Handle(&xycommon.RpcTransaction{Hash: "0x05", Events: []xycommon.RpcLog{event("Wrapped", carol, 11)}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 0)
+
+	// inscriptions sent to the bridge & wrapped in a tx, the calldata transfer first
+	_, results, err1 = h.Handle(&xycommon.RpcTransaction{
+		Hash:   "0x06",
+		From:   sender,
+		To:     vault.String(),
+		Input:  testutil.InputOf(`data:,{"p":"brc-20","op":"transfer","tick":"dino","amt":"10"}`),
+		Events: []xycommon.RpcLog{event("Wrapped", carol, 20)},
+	})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 2)
+	assert.Equal(t, devents.OperateTransfer, results[0].MD.Operate)
+	assert.Equal(t, devents.OperateWrap, results[1].MD.Operate)
+	assert.Equal(t, "20", wrapped(wrapper2, carol))
+	assert.Equal(t, "40", h.Cache.Wrapped.Supply("brc-20", "dino", wrapper2.String()).String())
+	_, balance = h.Cache.Balance.Get("brc-20", "dino", vault.String())
+	assert.Equal(t, "40", balance.Overall.String())
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package bridge
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// erc20ABI Transfer event of erc-20 wrapper contracts
+const erc20ABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+var transferEvent abi.Event
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		panic(fmt.Sprintf("erc20 abi decode err:%v", err))
+	}
+	transferEvent = parsed.Events["Transfer"]
+}
+
+// Movement wrapped token movement decoded from wrapper event, mint: From empty, burn: To empty
+type Movement struct {
+	Tick     string
+	Contract string
+	Bridge   string // address holding the inscriptions backing the wrapped supply
+	From     string
+	To       string
+	Amount   decimal.Decimal
+}
+
+func (m *Movement) String() string {
+	return fmt.Sprintf("tick[%s] contract[%s] from[%s] to[%s] amount[%v]", m.Tick, m.Contract, m.From, m.To, m.Amount)
+}
+
+// Wrapper
+/*****************************************************
+ * decode wrapped token movements of an erc-20 wrapper contract by config
+ ****************************************************/
+type Wrapper struct {
+	cfg      *config.BridgeConfig
+	contract common.Address
+	bridge   common.Address
+	mint     *abi.Event
+	burn     *abi.Event
+}
+
+func NewWrapper(cfg *config.BridgeConfig) (*Wrapper, error) {
+	parsed := abi.ABI{}
+	if cfg.Mint != nil || cfg.Burn != nil {
+		fd, err := os.Open(cfg.AbiFile)
+		if err != nil {
+			return nil, fmt.Errorf("bridge[%s] abi file open err:%v", cfg.Name, err)
+		}
+		defer fd.Close()
+
+		parsed, err = abi.JSON(fd)
+		if err != nil {
+			return nil, fmt.Errorf("bridge[%s] abi decode err:%v", cfg.Name, err)
+		}
+	}
+	return newWrapper(cfg, parsed)
+}
+
+func newWrapper(cfg *config.BridgeConfig, parsed abi.ABI) (*Wrapper, error) {
+	if !common.IsHexAddress(cfg.Contract) {
+		return nil, fmt.Errorf("bridge[%s] contract[%s] invalid", cfg.Name, cfg.Contract)
+	}
+	if cfg.Bridge != "" && !common.IsHexAddress(cfg.Bridge) {
+		return nil, fmt.Errorf("bridge[%s] bridge address[%s] invalid", cfg.Name, cfg.Bridge)
+	}
+	if cfg.Protocol == "" || cfg.Tick == "" {
+		return nil, fmt.Errorf("bridge[%s] protocol / tick required", cfg.Name)
+	}
+
+	w := &Wrapper{
+		cfg:      cfg,
+		contract: common.HexToAddress(cfg.Contract),
+		bridge:   common.HexToAddress(cfg.Contract),
+	}
+	if cfg.Bridge != "" {
+		w.bridge = common.HexToAddress(cfg.Bridge)
+	}
+
+	var err error
+	if w.mint, err = wrapperEvent(cfg.Name, parsed, cfg.Mint); err != nil {
+		return nil, err
+	}
+	if w.burn, err = wrapperEvent(cfg.Name, parsed, cfg.Burn); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func wrapperEvent(name string, parsed abi.ABI, cfg *config.BridgeEventConfig) (*abi.Event, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	event, ok := parsed.Events[cfg.Event]
+	if !ok {
+		return nil, fmt.Errorf("bridge[%s] event[%s] not found in abi", name, cfg.Event)
+	}
+	if event.ID == transferEvent.ID {
+		return nil, fmt.Errorf("bridge[%s] event[%s] conflicts with erc-20 Transfer event", name, cfg.Event)
+	}
+
+	inputs := make(map[string]struct{}, len(event.Inputs))
+	for _, arg := range event.Inputs {
+		inputs[arg.Name] = struct{}{}
+	}
+	for _, field := range []string{cfg.Account, cfg.Amount} {
+		if _, ok := inputs[field]; !ok {
+			return nil, fmt.Errorf("bridge[%s] field[%s] not found in event[%s]", name, field, cfg.Event)
+		}
+	}
+	return &event, nil
+}
+
+// Protocol protocol of the wrapped tick
+func (w *Wrapper) Protocol() string {
+	return strings.ToLower(w.cfg.Protocol)
+}
+
+// Tick wrapped tick of config
+func (w *Wrapper) Tick() string {
+	return w.cfg.Tick
+}
+
+// Contract address of the wrapper contract
+func (w *Wrapper) Contract() string {
+	return w.contract.String()
+}
+
+// Bridge address holding the wrapped inscriptions
+func (w *Wrapper) Bridge() string {
+	return w.bridge.String()
+}
+
+// Topics event topic hashes of the wrapper
+func (w *Wrapper) Topics() []string {
+	topics := []string{transferEvent.ID.String()}
+	for _, event := range []*abi.Event{w.mint, w.burn} {
+		if event != nil {
+			topics = append(topics, event.ID.String())
+		}
+	}
+	return topics
+}
+
+// Match reports whether the log is emitted by the wrapper's events
+func (w *Wrapper) Match(log *xycommon.RpcLog) bool {
+	if len(log.Topics) < 1 || log.Address != w.contract {
+		return false
+	}
+
+	for _, event := range []*abi.Event{&transferEvent, w.mint, w.burn} {
+		if event != nil && log.Topics[0] == event.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode
+/***************************************
+ * decode event log into wrapped token movement
+ * Transfer events from / to the zero address are mints & burns unless the mint / burn events are configured
+ * nil if the log moves no wrapped tokens
+ ***************************************/
+func (w *Wrapper) Decode(log *xycommon.RpcLog) (*Movement, error) {
+	m := &Movement{
+		Tick:     w.cfg.Tick,
+		Contract: w.contract.String(),
+		Bridge:   w.bridge.String(),
+	}
+
+	var amount decimal.Decimal
+	switch {
+	case w.mint != nil && log.Topics[0] == w.mint.ID:
+		values, err := unpack(w.mint, log)
+		if err != nil {
+			return nil, err
+		}
+		if m.To, err = addressValue(values, w.cfg.Mint.Account); err != nil {
+			return nil, err
+		}
+		if amount, err = numberValue(values, w.cfg.Mint.Amount); err != nil {
+			return nil, err
+		}
+
+	case w.burn != nil && log.Topics[0] == w.burn.ID:
+		values, err := unpack(w.burn, log)
+		if err != nil {
+			return nil, err
+		}
+		if m.From, err = addressValue(values, w.cfg.Burn.Account); err != nil {
+			return nil, err
+		}
+		if amount, err = numberValue(values, w.cfg.Burn.Amount); err != nil {
+			return nil, err
+		}
+
+	default:
+		values, err := unpack(&transferEvent, log)
+		if err != nil {
+			return nil, err
+		}
+		if m.From, err = addressValue(values, "from"); err != nil {
+			return nil, err
+		}
+		if m.To, err = addressValue(values, "to"); err != nil {
+			return nil, err
+		}
+		if amount, err = numberValue(values, "value"); err != nil {
+			return nil, err
+		}
+
+		zero := common.Address{}.String()
+		if m.From == zero {
+			if w.mint != nil {
+				return nil, nil
+			}
+			m.From = ""
+		}
+		if m.To == zero {
+			if w.burn != nil {
+				return nil, nil
+			}
+			m.To = ""
+		}
+	}
+
+	m.Amount = amount.Shift(-w.cfg.AmountDecimals)
+	if m.From == m.To || m.Amount.IsZero() {
+		return nil, nil
+	}
+	return m, nil
+}
+
+func unpack(event *abi.Event, log *xycommon.RpcLog) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(event.Inputs))
+	if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return nil, fmt.Errorf("event data unpack err:%v", err)
+	}
+
+	indexed := make(abi.Arguments, 0, len(event.Inputs))
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(indexed) > 0 {
+		if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+			return nil, fmt.Errorf("event topics parse err:%v", err)
+		}
+	}
+	return values, nil
+}
+
+func addressValue(values map[string]interface{}, name string) (string, error) {
+	v, ok := values[name].(common.Address)
+	if !ok {
+		return "", fmt.Errorf("field[%s] is not an address", name)
+	}
+	return v.String(), nil
+}
+
+func numberValue(values map[string]interface{}, name string) (decimal.Decimal, error) {
+	v, ok := values[name].(*big.Int)
+	if !ok || v == nil {
+		return decimal.Zero, fmt.Errorf("field[%s] is not an integer", name)
+	}
+	return decimal.NewFromBigInt(v, 0), nil
+}
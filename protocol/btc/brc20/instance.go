@@ -24,15 +24,30 @@ package brc20
 
 import (
 	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
 )
 
+func init() {
+	types.Register(&types.Registration{
+		ChainGroup: model.BtcChainGroup,
+		Protocol:   types.BRC20Protocol,
+		Operates:   []string{devents.OperateDeploy, devents.OperateMint, devents.OperateTransfer},
+		FastCheck:  common.FastCheckDataPrefix,
+		New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+			return NewProtocol(cache, rules)
+		},
+	})
+}
+
 type Protocol struct {
 	*common.Protocol
 }
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
 	return &Protocol{
-		Protocol: common.NewProtocol(cache),
+		Protocol: common.NewProtocol(cache, rules),
 	}
 }
@@ -0,0 +1,529 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xyerrors"
+	"math/big"
+	"sort"
+)
+
+func init() {
+	types.Register(&types.Registration{
+		ChainGroup: model.BtcChainGroup,
+		Protocol:   types.RunesProtocol,
+		Operates:   []string{devents.OperateDeploy, devents.OperateMint, devents.OperateTransfer},
+		OptIn:      true,
+		New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+			return NewProtocol(cache, rules)
+		},
+	})
+}
+
+type Protocol struct {
+	common *common.Protocol
+	cache  *dcache.Manager
+}
+
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
+	return &Protocol{
+		common: common.NewProtocol(cache, rules),
+		cache:  cache,
+	}
+}
+
+// FastCheck
+/***************************************
+ * runestone checked first, then spent outputs, any of them may hold runes
+ * outputs created earlier in the block are not cached before the block is handled,
+ * so runes of inputs are resolved by parsing in order
+ ***************************************/
+func (p *Protocol) FastCheck(tx *xycommon.RpcTransaction) bool {
+	return Decipher(tx) != nil || spends(tx)
+}
+
+// ParseMetaData
+/***************************************
+ * runes txs carry a runestone or spend outputs, runes of the spent outputs resolved by parsing
+ ***************************************/
+func (p *Protocol) ParseMetaData(chain string, tx *xycommon.RpcTransaction) *devents.MetaData {
+	stone := Decipher(tx)
+	if stone == nil && !spends(tx) {
+		return nil
+	}
+
+	operate := devents.OperateTransfer
+	if stone != nil && stone.Etching != nil {
+		operate = devents.OperateDeploy
+	} else if stone != nil && stone.Mint != nil {
+		operate = devents.OperateMint
+	}
+	return &devents.MetaData{
+		Chain:    chain,
+		Protocol: types.RunesProtocol,
+		Operate:  operate,
+	}
+}
+
+// spends reports whether the tx spends any output
+func spends(tx *xycommon.RpcTransaction) bool {
+	for _, in := range tx.Vin {
+		if !in.IsCoinBase() {
+			return true
+		}
+	}
+	return false
+}
+
+// runeBalance runes of the tx, amounts in rune units without divisibility
+type runeBalance struct {
+	id          RuneId
+	name        string
+	divisiblity int32
+
+	spent       []*devents.Outpoint
+	minted      *big.Int
+	unallocated *big.Int
+	allocated   map[int]*big.Int
+	burned      *big.Int
+	deploy      *devents.Deploy
+}
+
+func (b *runeBalance) amount(n *big.Int) decimal.Decimal {
+	return decimal.NewFromBigInt(n, -b.divisiblity)
+}
+
+func (b *runeBalance) allocate(output int, amount *big.Int) {
+	if amount.Sign() <= 0 {
+		return
+	}
+	b.unallocated.Sub(b.unallocated, amount)
+
+	if _, ok := b.allocated[output]; !ok {
+		b.allocated[output] = new(big.Int)
+	}
+	b.allocated[output].Add(b.allocated[output], amount)
+}
+
+// Parse
+/***************************************
+ * runes of inputs, mint & etching premine are allocated to outputs by edicts,
+ * the rest to the pointer or the first non OP_RETURN output, cenotaphs burn all
+ ***************************************/
+func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	p.common.ResolveRules(block, md)
+
+	height := block.Number.Uint64()
+	if height < FirstRuneHeight {
+		return nil, nil
+	}
+
+	txIndex := uint32(0)
+	if tx.TxIndex != nil {
+		txIndex = uint32(tx.TxIndex.Uint64())
+	}
+
+	balances := make(map[RuneId]*runeBalance, 4)
+	order := make([]RuneId, 0, 4)
+	touch := func(id RuneId, name string, divisibility int32) *runeBalance {
+		if b, ok := balances[id]; ok {
+			return b
+		}
+		b := &runeBalance{
+			id:          id,
+			name:        name,
+			divisiblity: divisibility,
+			minted:      new(big.Int),
+			unallocated: new(big.Int),
+			allocated:   make(map[int]*big.Int),
+			burned:      new(big.Int),
+		}
+		balances[id] = b
+		order = append(order, id)
+		return b
+	}
+
+	// runes of spent outputs
+	for _, in := range tx.Vin {
+		if in.IsCoinBase() {
+			continue
+		}
+
+		key := outpoint(in.Txid, in.Vout)
+		for _, item := range p.cache.UTXO.GetOutpoint(md.Protocol, key) {
+			ok, tick := p.cache.Inscription.Get(md.Protocol, item.Tick)
+			if !ok {
+				return nil, xyerrors.ErrInternal.WrapCause(xyerrors.NewInsError(-100, fmt.Sprintf("rune[%s] of outpoint[%s] not found", item.Tick, key)))
+			}
+
+			id, _ := ParseRuneId(tick.Id)
+			b := touch(id, item.Tick, int32(tick.Decimals))
+			b.unallocated.Add(b.unallocated, item.Amount.Shift(int32(tick.Decimals)).BigInt())
+			b.spent = append(b.spent, &devents.Outpoint{Outpoint: key, Address: item.Owner, Amount: item.Amount})
+		}
+	}
+
+	stone := Decipher(tx)
+	if stone != nil && stone.Mint != nil {
+		if name, tick, ok := p.mintable(md, *stone.Mint, height); ok {
+			b := touch(*stone.Mint, name, int32(tick.Decimals))
+			amount := tick.LimitPerMint.Shift(int32(tick.Decimals)).BigInt()
+			b.minted.Add(b.minted, amount)
+			b.unallocated.Add(b.unallocated, amount)
+		}
+	}
+
+	r, ok, err := p.etchedRune(md, tx, stone, height, txIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var etched *runeBalance
+	if ok {
+		etched = touch(RuneId{Block: height, Tx: txIndex}, r.String(), int32(stone.Etching.Divisibility))
+		etched.deploy = buildDeploy(etched, r, stone, height)
+		if !stone.Cenotaph && stone.Etching.Premine != nil {
+			etched.minted.Add(etched.minted, stone.Etching.Premine)
+			etched.unallocated.Add(etched.unallocated, stone.Etching.Premine)
+		}
+	}
+
+	destinations := make([]int, 0, len(tx.Vout))
+	for i, out := range tx.Vout {
+		if !IsOpReturn(out.ScriptPubKey.Hex) {
+			destinations = append(destinations, i)
+		}
+	}
+
+	if stone != nil && !stone.Cenotaph {
+		for _, edict := range stone.Edicts {
+			id := edict.Id
+			if id == (RuneId{}) {
+				if etched == nil {
+					continue
+				}
+				id = etched.id
+			}
+
+			b, ok := balances[id]
+			if !ok {
+				continue
+			}
+
+			if int(edict.Output) < len(tx.Vout) {
+				amount := edict.Amount
+				if amount.Sign() == 0 || amount.Cmp(b.unallocated) > 0 {
+					amount = new(big.Int).Set(b.unallocated)
+				}
+				b.allocate(int(edict.Output), amount)
+				continue
+			}
+
+			// split among all non OP_RETURN outputs
+			if len(destinations) <= 0 {
+				continue
+			}
+			if edict.Amount.Sign() == 0 {
+				each, remainder := new(big.Int).QuoRem(b.unallocated, big.NewInt(int64(len(destinations))), new(big.Int))
+				for i, output := range destinations {
+					amount := new(big.Int).Set(each)
+					if int64(i) < remainder.Int64() {
+						amount.Add(amount, big.NewInt(1))
+					}
+					b.allocate(output, amount)
+				}
+				continue
+			}
+			for _, output := range destinations {
+				amount := edict.Amount
+				if amount.Cmp(b.unallocated) > 0 {
+					amount = new(big.Int).Set(b.unallocated)
+				}
+				b.allocate(output, amount)
+			}
+		}
+	}
+
+	// unallocated runes to the pointer or the first non OP_RETURN output, burned by cenotaphs
+	vout := -1
+	if stone != nil && stone.Pointer != nil {
+		vout = int(*stone.Pointer)
+	} else if len(destinations) > 0 {
+		vout = destinations[0]
+	}
+	for _, id := range order {
+		b := balances[id]
+		if b.unallocated.Sign() <= 0 {
+			continue
+		}
+		if (stone != nil && stone.Cenotaph) || vout < 0 {
+			b.burned.Add(b.burned, b.unallocated)
+			b.unallocated.SetInt64(0)
+			continue
+		}
+		b.allocate(vout, new(big.Int).Set(b.unallocated))
+	}
+
+	items := make([]*devents.TxResult, 0, len(order))
+	for _, id := range order {
+		items = append(items, p.buildResult(block, tx, md, balances[id]))
+	}
+	return items, nil
+}
+
+// mintable
+/***************************************
+ * open mint of the rune at the height, mints are capped by the supply
+ ***************************************/
+func (p *Protocol) mintable(md *devents.MetaData, id RuneId, height uint64) (string, *dcache.Tick, bool) {
+	ok, name := p.cache.Inscription.GetNameById(md.Protocol, id.String())
+	if !ok {
+		return "", nil, false
+	}
+
+	ok, tick := p.cache.Inscription.Get(md.Protocol, name)
+	if !ok || tick.LimitPerMint.LessThanOrEqual(decimal.Zero) {
+		return "", nil, false
+	}
+
+	if tick.StartBlock > 0 && height < tick.StartBlock {
+		return "", nil, false
+	}
+	// end height of runes terms is exclusive
+	if tick.EndBlock > 0 && height >= tick.EndBlock {
+		return "", nil, false
+	}
+
+	ok, stats := p.cache.InscriptionStats.Get(md.Protocol, name)
+	if !ok || stats.Minted.Add(tick.LimitPerMint).GreaterThan(tick.TotalSupply) {
+		return "", nil, false
+	}
+	return name, tick, true
+}
+
+// etchedRune
+/***************************************
+ * rune etched by the tx, names must be unlocked, unique & committed by an input tapscript
+ * the committed output must be taproot & mature, checked by the previous outputs of inputs
+ ***************************************/
+func (p *Protocol) etchedRune(md *devents.MetaData, tx *xycommon.RpcTransaction, stone *Runestone, height uint64, txIndex uint32) (Rune, bool, *xyerrors.InsError) {
+	if stone == nil || stone.Etching == nil {
+		return Rune{}, false, nil
+	}
+
+	if stone.Etching.Rune == nil {
+		return ReservedRune(height, txIndex), true, nil
+	}
+
+	r := *stone.Etching.Rune
+	if r.Cmp(MinimumAtHeight(height)) < 0 || r.Reserved() {
+		return Rune{}, false, nil
+	}
+	if ok, _ := p.cache.Inscription.Get(md.Protocol, r.String()); ok {
+		return Rune{}, false, nil
+	}
+
+	// prevouts are required for commitment checking, block retried if not fetched
+	if len(tx.Prevouts) != len(tx.Vin) {
+		return Rune{}, false, xyerrors.ErrInternal.WrapCause(xyerrors.NewInsError(-100, fmt.Sprintf("prevouts of tx[%s] not fetched, inputs[%d], prevouts[%d]", tx.Hash, len(tx.Vin), len(tx.Prevouts))))
+	}
+	return r, commits(tx, r, height), nil
+}
+
+// commits reports whether any input tapscript pushes the rune commitment,
+// spending a taproot output of at least CommitConfirmations confirmations
+func commits(tx *xycommon.RpcTransaction, r Rune, height uint64) bool {
+	commitment := r.Commitment()
+	for i, in := range tx.Vin {
+		prevout := tx.Prevouts[i]
+		if prevout == nil || !IsTaproot(prevout.ScriptPubKey.Hex) {
+			continue
+		}
+		if prevout.Height > height || height-prevout.Height+1 < CommitConfirmations {
+			continue
+		}
+
+		witness := in.Witness
+
+		// annex excluded
+		if len(witness) >= 2 {
+			if last, err := hex.DecodeString(witness[len(witness)-1]); err == nil && len(last) > 0 && last[0] == txscript.TaprootAnnexTag {
+				witness = witness[:len(witness)-1]
+			}
+		}
+		if len(witness) < 2 {
+			continue
+		}
+
+		script, err := hex.DecodeString(witness[len(witness)-2])
+		if err != nil {
+			continue
+		}
+
+		tokenizer := txscript.MakeScriptTokenizer(0, script)
+		for tokenizer.Next() {
+			if tokenizer.Opcode() <= txscript.OP_PUSHDATA4 && bytes.Equal(tokenizer.Data(), commitment) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildDeploy
+/***************************************
+ * rune entry of the etching, mint terms window is [start, end) by heights & offsets
+ * etchings of cenotaphs have no supply
+ ***************************************/
+func buildDeploy(b *runeBalance, r Rune, stone *Runestone, height uint64) *devents.Deploy {
+	etching := stone.Etching
+	deploy := &devents.Deploy{
+		Name:    r.Spaced(etching.Spacers),
+		Decimal: int8(etching.Divisibility),
+		Id:      b.id.String(),
+	}
+	if stone.Cenotaph {
+		return deploy
+	}
+
+	if etching.Premine != nil {
+		deploy.Premine = b.amount(etching.Premine)
+	}
+	deploy.MaxSupply = deploy.Premine
+
+	terms := etching.Terms
+	if terms == nil || terms.Amount == nil || terms.Cap == nil {
+		return deploy
+	}
+
+	var start, end *uint64
+	if terms.HeightStart != nil {
+		start = terms.HeightStart
+	}
+	if terms.OffsetStart != nil {
+		v := saturatingAdd(height, *terms.OffsetStart)
+		if start == nil || v > *start {
+			start = &v
+		}
+	}
+	if terms.HeightEnd != nil {
+		end = terms.HeightEnd
+	}
+	if terms.OffsetEnd != nil {
+		v := saturatingAdd(height, *terms.OffsetEnd)
+		if end == nil || v < *end {
+			end = &v
+		}
+	}
+
+	// mints never open
+	if end != nil && (*end == 0 || (start != nil && *end <= *start)) {
+		return deploy
+	}
+
+	deploy.MintLimit = b.amount(terms.Amount)
+	deploy.MaxSupply = deploy.Premine.Add(b.amount(new(big.Int).Mul(terms.Amount, terms.Cap)))
+	if start != nil {
+		deploy.StartBlock = *start
+	}
+	if end != nil {
+		deploy.EndBlock = *end
+	}
+	return deploy
+}
+
+func (p *Protocol) buildResult(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData, b *runeBalance) *devents.TxResult {
+	md := omd.Copy()
+	md.Tick = b.name
+	md.Operate = devents.OperateTransfer
+	if b.minted.Sign() > 0 {
+		md.Operate = devents.OperateMint
+	}
+	if b.deploy != nil {
+		md.Operate = devents.OperateDeploy
+	}
+
+	transfer := &devents.UTXOTransfer{
+		Spent:  b.spent,
+		Minted: b.amount(b.minted),
+		Burned: b.amount(b.burned),
+	}
+
+	outputs := make([]int, 0, len(b.allocated))
+	for output := range b.allocated {
+		outputs = append(outputs, output)
+	}
+	sort.Ints(outputs)
+
+	for _, output := range outputs {
+		amount := b.allocated[output]
+		if IsOpReturn(tx.Vout[output].ScriptPubKey.Hex) {
+			transfer.Burned = transfer.Burned.Add(b.amount(amount))
+			continue
+		}
+		transfer.Created = append(transfer.Created, &devents.Outpoint{
+			Outpoint: outpoint(tx.Hash, uint32(output)),
+			Address:  owner(tx.Vout[output]),
+			Amount:   b.amount(amount),
+		})
+	}
+
+	return &devents.TxResult{
+		MD:     md,
+		Block:  block,
+		Tx:     tx,
+		Deploy: b.deploy,
+		UTXO:   transfer,
+	}
+}
+
+func outpoint(txid string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txid, vout)
+}
+
+// owner address of the output, script hex of non-standard outputs
+func owner(out btcjson.Vout) string {
+	if out.ScriptPubKey.Address != "" {
+		return out.ScriptPubKey.Address
+	}
+	return out.ScriptPubKey.Hex
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a+b < a {
+		return ^uint64(0)
+	}
+	return a + b
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SpacerChar separator of spaced rune names
+const SpacerChar = "•"
+
+const (
+	// FirstRuneHeight runes activation height of bitcoin mainnet
+	FirstRuneHeight uint64 = 840000
+
+	// CommitConfirmations confirmations of the taproot output committing the rune name before etching
+	CommitConfirmations uint64 = 6
+
+	// unlockInterval rune names of one character shorter unlocked per interval, 12 steps per halving
+	unlockInterval uint64 = 210000 / 12
+)
+
+var (
+	// reserved names since AAAAAAAAAAAAAAAAAAAAAAAAAAA, assigned to etchings without a rune name
+	reserved, _ = new(big.Int).SetString("6402364363415443603228541259936211926", 10)
+
+	// steps minimum value of names by length, steps[n] is the first name of n+1 characters
+	steps = func() []*big.Int {
+		items := []*big.Int{new(big.Int)}
+		for i := 1; i <= 27; i++ {
+			v := new(big.Int).Mul(items[i-1], big.NewInt(26))
+			items = append(items, v.Add(v, big.NewInt(26)))
+		}
+		return items
+	}()
+)
+
+// RuneId block height & tx index of the etching
+type RuneId struct {
+	Block uint64
+	Tx    uint32
+}
+
+func (r RuneId) String() string {
+	return fmt.Sprintf("%d:%d", r.Block, r.Tx)
+}
+
+// newRuneId tx index must be 0 of block 0
+func newRuneId(block, tx *big.Int) (RuneId, bool) {
+	if !block.IsUint64() || !tx.IsUint64() || tx.Uint64() > 0xFFFFFFFF {
+		return RuneId{}, false
+	}
+	if block.Sign() == 0 && tx.Sign() > 0 {
+		return RuneId{}, false
+	}
+	return RuneId{Block: block.Uint64(), Tx: uint32(tx.Uint64())}, true
+}
+
+// next rune id of the delta encoded edict
+func (r RuneId) next(block, tx *big.Int) (RuneId, bool) {
+	b := new(big.Int).Add(new(big.Int).SetUint64(r.Block), block)
+	t := new(big.Int).Set(tx)
+	if block.Sign() == 0 {
+		t.Add(t, big.NewInt(int64(r.Tx)))
+	}
+	return newRuneId(b, t)
+}
+
+// ParseRuneId parse rune id of block:tx format
+func ParseRuneId(id string) (RuneId, bool) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return RuneId{}, false
+	}
+
+	block, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return RuneId{}, false
+	}
+	tx, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return RuneId{}, false
+	}
+	return newRuneId(new(big.Int).SetUint64(block), new(big.Int).SetUint64(tx))
+}
+
+// Rune
+/*****************************************************
+ * rune name, modified base-26 integer of upper case letters
+ ****************************************************/
+type Rune struct {
+	n *big.Int
+}
+
+// ParseRune parse rune name, spacers ignored
+func ParseRune(name string) (Rune, bool) {
+	name = strings.ReplaceAll(name, SpacerChar, "")
+	if name == "" {
+		return Rune{}, false
+	}
+
+	n := new(big.Int)
+	for i, c := range name {
+		if c < 'A' || c > 'Z' {
+			return Rune{}, false
+		}
+		if i > 0 {
+			n.Add(n, big.NewInt(1))
+		}
+		n.Mul(n, big.NewInt(26))
+		n.Add(n, big.NewInt(int64(c-'A')))
+	}
+
+	if n.Cmp(maxU128) > 0 {
+		return Rune{}, false
+	}
+	return Rune{n}, true
+}
+
+// ReservedRune name of etchings without a rune name
+func ReservedRune(block uint64, tx uint32) Rune {
+	n := new(big.Int).Lsh(new(big.Int).SetUint64(block), 32)
+	n.Or(n, big.NewInt(int64(tx)))
+	return Rune{n.Add(n, reserved)}
+}
+
+// MinimumAtHeight shortest rune name can be etched at the height
+func MinimumAtHeight(height uint64) Rune {
+	offset := height + 1
+	if offset < FirstRuneHeight {
+		return Rune{steps[12]}
+	}
+	if offset >= FirstRuneHeight+210000 {
+		return Rune{new(big.Int)}
+	}
+
+	progress := offset - FirstRuneHeight
+	length := 12 - progress/unlockInterval
+	end, start := steps[length-1], steps[length]
+	remainder := new(big.Int).SetUint64(progress % unlockInterval)
+
+	delta := new(big.Int).Sub(start, end)
+	delta.Mul(delta, remainder).Div(delta, new(big.Int).SetUint64(unlockInterval))
+	return Rune{delta.Sub(start, delta)}
+}
+
+func (r Rune) Reserved() bool {
+	return r.n.Cmp(reserved) >= 0
+}
+
+func (r Rune) Cmp(o Rune) int {
+	return r.n.Cmp(o.n)
+}
+
+// Commitment little endian bytes of the name without trailing zeros, pushed in the etching inputs
+func (r Rune) Commitment() []byte {
+	bytes := r.n.Bytes()
+	out := make([]byte, 0, len(bytes))
+	for i := len(bytes) - 1; i >= 0; i-- {
+		out = append(out, bytes[i])
+	}
+	return out
+}
+
+func (r Rune) String() string {
+	if r.n.Cmp(maxU128) == 0 {
+		return "BCGDENLQRQWDSLRUGSNLBTMFIJAV"
+	}
+
+	n := new(big.Int).Add(r.n, big.NewInt(1))
+	chars := make([]byte, 0, 28)
+	mod := new(big.Int)
+	for n.Sign() > 0 {
+		n.Sub(n, big.NewInt(1))
+		n.DivMod(n, big.NewInt(26), mod)
+		chars = append(chars, byte('A'+mod.Int64()))
+	}
+
+	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+	return string(chars)
+}
+
+// Spaced rune name with spacers, bit i of spacers is a spacer after the i-th character
+func (r Rune) Spaced(spacers uint32) string {
+	name := r.String()
+	var b strings.Builder
+	for i, c := range name {
+		b.WriteRune(c)
+		if i < len(name)-1 && spacers&(1<<uint(i)) != 0 {
+			b.WriteString(SpacerChar)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xyerrors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// runestoneHex OP_RETURN OP_13 script of the tag/value integers
+func runestoneHex(integers ...uint64) string {
+	payload := make([]byte, 0, len(integers)*3)
+	for _, v := range integers {
+		payload = append(payload, EncodeVarint(new(big.Int).SetUint64(v))...)
+	}
+	return fmt.Sprintf("6a5d%02x%s", len(payload), hex.EncodeToString(payload))
+}
+
+func output(address string) btcjson.Vout {
+	return btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Address: address, Hex: "5120" + address}}
+}
+
+// prevout output created at the height, taproot if committed
+func prevout(height uint64, taproot bool) *xycommon.RpcPrevout {
+	script := "0014" + strings.Repeat("11", 20)
+	if taproot {
+		script = "5120" + strings.Repeat("22", 32)
+	}
+	return &xycommon.RpcPrevout{Height: height, ScriptPubKey: btcjson.ScriptPubKeyResult{Hex: script}}
+}
+
+func nulldata(script string) btcjson.Vout {
+	return btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Hex: script}}
+}
+
+func TestRuneName(t *testing.T) {
+	for n, name := range map[int64]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		r, ok := ParseRune(name)
+		assert.True(t, ok)
+		assert.Equal(t, name, r.String())
+		assert.Equal(t, 0, r.Cmp(Rune{n: big.NewInt(n)}))
+	}
+
+	r, _ := ParseRune("UNCOMMONGOODS")
+	assert.Equal(t, "UNCOMMON•GOODS", r.Spaced(1<<7))
+	assert.Equal(t, 1, r.Cmp(MinimumAtHeight(FirstRuneHeight)))
+	short, _ := ParseRune("ABC")
+	assert.Equal(t, -1, short.Cmp(MinimumAtHeight(FirstRuneHeight)))
+	assert.False(t, r.Reserved())
+	assert.True(t, ReservedRune(FirstRuneHeight, 1).Reserved())
+
+	id, ok := ParseRuneId("840000:1")
+	assert.True(t, ok)
+	assert.Equal(t, RuneId{Block: 840000, Tx: 1}, id)
+}
+
+func TestDecipher(t *testing.T) {
+	// edict & pointer
+	stone := Decipher(&xycommon.RpcTransaction{Vout: []btcjson.Vout{
+		nulldata(runestoneHex(tagPointer, 1, tagBody, 840000, 1, 300, 0)),
+		output("a1"),
+	}})
+	assert.NotNil(t, stone)
+	assert.False(t, stone.Cenotaph)
+	assert.Equal(t, uint32(1), *stone.Pointer)
+	assert.Len(t, stone.Edicts, 1)
+	assert.Equal(t, RuneId{Block: 840000, Tx: 1}, stone.Edicts[0].Id)
+	assert.Equal(t, "300", stone.Edicts[0].Amount.String())
+
+	// no runestone
+	assert.Nil(t, Decipher(&xycommon.RpcTransaction{Vout: []btcjson.Vout{nulldata("6a0401020304")}}))
+
+	// cenotaphs
+	for script, flaw := range map[string]string{
+		runestoneHex(30, 1):                    FlawUnrecognizedEvenTag,
+		runestoneHex(tagFlags, 1<<7):           FlawUnrecognizedFlag,
+		runestoneHex(tagBody, 840000, 1, 300):  FlawTrailingIntegers,
+		runestoneHex(tagBody, 840000, 1, 1, 3): FlawEdictOutput,
+		"6a5d51":                               FlawOpcode,
+	} {
+		stone = Decipher(&xycommon.RpcTransaction{Vout: []btcjson.Vout{nulldata(script), output("a1")}})
+		assert.NotNil(t, stone, script)
+		assert.True(t, stone.Cenotaph, script)
+		assert.Equal(t, flaw, stone.Flaw, script)
+	}
+}
+
+func TestRunesLifecycle(t *testing.T) {
+	cache := dcache.NewManager(nil, "btc")
+	cache.Balance = dcache.NewBalance()
+	cache.UTXO = dcache.NewUTXO()
+	cache.Inscription = dcache.NewInscription()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	cache.AddressMint = dcache.NewAddressMint()
+	p := NewProtocol(cache, nil)
+	handler := devents.NewTxResultHandler(cache)
+
+	parse := func(height uint64, index int64, tx *xycommon.RpcTransaction) []*devents.TxResult {
+		tx.TxIndex = big.NewInt(index)
+		md := p.ParseMetaData("btc", tx)
+		if md == nil {
+			return nil
+		}
+
+		block := &xycommon.RpcBlock{Number: new(big.Int).SetUint64(height)}
+		results, err := p.Parse(block, tx, md)
+		assert.Nil(t, err)
+		for _, r := range results {
+			handler.UpdateCache(r)
+		}
+		return results
+	}
+	balance := func(address string) string {
+		_, b := cache.Balance.Get(types.RunesProtocol, "UNCOMMONGOODS", address)
+		return b.Overall.String()
+	}
+
+	// etching of UNCOMMON•GOODS, premine to the first non OP_RETURN output
+	r, _ := ParseRune("UNCOMMONGOODS")
+	commitment := r.Commitment()
+	tapscript := fmt.Sprintf("%02x%sac", len(commitment), hex.EncodeToString(commitment))
+	etching := runestoneHex(
+		tagFlags, 1<<flagEtching|1<<flagTerms,
+		tagRune, r.n.Uint64(),
+		tagSpacers, 1<<7,
+		tagPremine, 1000,
+		tagAmount, 100,
+		tagCap, 10,
+	)
+	committed := []btcjson.Vin{{Txid: "f0", Witness: []string{"00", tapscript, "c0"}}}
+
+	// commitments of immature or non taproot outputs are not etched
+	for i, prev := range []*xycommon.RpcPrevout{prevout(FirstRuneHeight-4, true), prevout(FirstRuneHeight-5, false)} {
+		assert.Len(t, parse(FirstRuneHeight, int64(i+2), &xycommon.RpcTransaction{
+			Hash:     fmt.Sprintf("x%d", i),
+			Vin:      committed,
+			Vout:     []btcjson.Vout{nulldata(etching), output("a1")},
+			Prevouts: []*xycommon.RpcPrevout{prev},
+		}), 0)
+	}
+
+	// prevouts not fetched, block retried
+	tx := &xycommon.RpcTransaction{Hash: "x2", Vin: committed, Vout: []btcjson.Vout{nulldata(etching), output("a1")}}
+	_, err := p.Parse(&xycommon.RpcBlock{Number: new(big.Int).SetUint64(FirstRuneHeight)}, tx, p.ParseMetaData("btc", tx))
+	assert.True(t, errors.Is(err, xyerrors.ErrInternal))
+
+	results := parse(FirstRuneHeight, 1, &xycommon.RpcTransaction{
+		Hash:     "e1",
+		Vin:      committed,
+		Vout:     []btcjson.Vout{nulldata(etching), output("a1")},
+		Prevouts: []*xycommon.RpcPrevout{prevout(FirstRuneHeight-5, true)},
+	})
+	assert.Len(t, results, 1)
+	assert.Equal(t, devents.OperateDeploy, results[0].MD.Operate)
+	assert.Equal(t, "UNCOMMONGOODS", results[0].MD.Tick)
+	assert.Equal(t, "UNCOMMON•GOODS", results[0].Deploy.Name)
+	assert.Equal(t, "840000:1", results[0].Deploy.Id)
+	assert.Equal(t, "2000", results[0].Deploy.MaxSupply.String())
+	assert.Equal(t, "e1:1", results[0].UTXO.Created[0].Outpoint)
+	assert.Equal(t, "1000", balance("a1"))
+
+	// names not committed by inputs are not etched
+	assert.Len(t, parse(FirstRuneHeight, 2, &xycommon.RpcTransaction{
+		Hash:     "e2",
+		Vin:      []btcjson.Vin{{Txid: "f1"}},
+		Vout:     []btcjson.Vout{nulldata(etching), output("a1")},
+		Prevouts: []*xycommon.RpcPrevout{prevout(FirstRuneHeight-5, true)},
+	}), 0)
+
+	// runestones & spent outputs, coinbase only txs skipped
+	assert.True(t, p.FastCheck(&xycommon.RpcTransaction{Vout: []btcjson.Vout{nulldata(etching)}}))
+	assert.True(t, p.FastCheck(&xycommon.RpcTransaction{Vin: []btcjson.Vin{{Txid: "e1", Vout: 0}}, Vout: []btcjson.Vout{output("a1")}}))
+	assert.False(t, p.FastCheck(&xycommon.RpcTransaction{Vin: []btcjson.Vin{{Coinbase: "03"}}, Vout: []btcjson.Vout{output("a1")}}))
+
+	// mint by rune id
+	results = parse(FirstRuneHeight+1, 1, &xycommon.RpcTransaction{
+		Hash: "m1",
+		Vin:  []btcjson.Vin{{Txid: "f2"}},
+		Vout: []btcjson.Vout{output("b1"), nulldata(runestoneHex(tagMint, 840000, tagMint, 1))},
+	})
+	assert.Len(t, results, 1)
+	assert.Equal(t, devents.OperateMint, results[0].MD.Operate)
+	assert.Equal(t, "100", balance("b1"))
+
+	// edict transfer, change to the pointer output
+	results = parse(FirstRuneHeight+2, 1, &xycommon.RpcTransaction{
+		Hash: "t1",
+		Vin:  []btcjson.Vin{{Txid: "e1", Vout: 1}},
+		Vout: []btcjson.Vout{output("c1"), output("a1"), nulldata(runestoneHex(tagPointer, 1, tagBody, 840000, 1, 300, 0))},
+	})
+	assert.Len(t, results, 1)
+	assert.Equal(t, devents.OperateTransfer, results[0].MD.Operate)
+	assert.Equal(t, "e1:1", results[0].UTXO.Spent[0].Outpoint)
+	assert.Len(t, results[0].UTXO.Created, 2)
+	assert.Equal(t, "300", balance("c1"))
+	assert.Equal(t, "700", balance("a1"))
+	assert.Len(t, cache.UTXO.GetOutpoint(types.RunesProtocol, "e1:1"), 0)
+	assert.Equal(t, "700", cache.UTXO.GetOutpoint(types.RunesProtocol, "t1:1")[0].Amount.String())
+
+	// spent without runestone, all to the first output
+	parse(FirstRuneHeight+3, 1, &xycommon.RpcTransaction{
+		Hash: "t2",
+		Vin:  []btcjson.Vin{{Txid: "t1", Vout: 0}},
+		Vout: []btcjson.Vout{output("d1")},
+	})
+	assert.Equal(t, "0", balance("c1"))
+	assert.Equal(t, "300", balance("d1"))
+
+	// cenotaph burns input runes
+	results = parse(FirstRuneHeight+4, 1, &xycommon.RpcTransaction{
+		Hash: "t3",
+		Vin:  []btcjson.Vin{{Txid: "t2", Vout: 0}},
+		Vout: []btcjson.Vout{output("d1"), nulldata(runestoneHex(30, 1))},
+	})
+	assert.Len(t, results, 1)
+	assert.Equal(t, "300", results[0].UTXO.Burned.String())
+	assert.Equal(t, "0", balance("d1"))
+
+	_, stats := cache.InscriptionStats.Get(types.RunesProtocol, "UNCOMMONGOODS")
+	assert.Equal(t, "1100", stats.Minted.String())
+	assert.Equal(t, "300", stats.Burned.String())
+	assert.Equal(t, int64(2), stats.Holders)
+
+	// outputs created earlier in the block are not cached when txs are checked
+	mint := &xycommon.RpcTransaction{
+		Hash: "m2",
+		Vin:  []btcjson.Vin{{Txid: "f3"}},
+		Vout: []btcjson.Vout{output("b2"), nulldata(runestoneHex(tagMint, 840000, tagMint, 1))},
+	}
+	spend := &xycommon.RpcTransaction{
+		Hash: "t4",
+		Vin:  []btcjson.Vin{{Txid: "m2", Vout: 0}},
+		Vout: []btcjson.Vout{output("c2")},
+	}
+	assert.True(t, p.FastCheck(mint))
+	assert.True(t, p.FastCheck(spend))
+	assert.Len(t, parse(FirstRuneHeight+5, 1, mint), 1)
+	results = parse(FirstRuneHeight+5, 2, spend)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "m2:0", results[0].UTXO.Spent[0].Outpoint)
+	assert.Equal(t, "0", balance("b2"))
+	assert.Equal(t, "100", balance("c2"))
+}
+
+func TestMintTermsEnd(t *testing.T) {
+	cache := dcache.NewManager(nil, "btc")
+	cache.Inscription = dcache.NewInscription()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	p := NewProtocol(cache, nil)
+	md := &devents.MetaData{Chain: "btc", Protocol: types.RunesProtocol}
+
+	for i, end := range []uint64{1, FirstRuneHeight + 10} {
+		r, _ := ParseRune(fmt.Sprintf("UNCOMMONGOODS%c", 'A'+i))
+		b := &runeBalance{id: RuneId{Block: FirstRuneHeight, Tx: uint32(i + 1)}}
+		height := end
+		stone := &Runestone{Etching: &Etching{Terms: &Terms{Amount: big.NewInt(1), Cap: big.NewInt(10), HeightEnd: &height}}}
+
+		// the end height is stored exclusive
+		deploy := buildDeploy(b, r, stone, FirstRuneHeight)
+		assert.Equal(t, end, deploy.EndBlock)
+
+		cache.Inscription.Create(md.Protocol, r.String(), &dcache.Tick{Id: deploy.Id, LimitPerMint: deploy.MintLimit, TotalSupply: deploy.MaxSupply, EndBlock: deploy.EndBlock})
+		cache.InscriptionStats.Create(md.Protocol, r.String(), &dcache.InsStats{})
+
+		_, _, ok := p.mintable(md, b.id, FirstRuneHeight+1)
+		assert.Equal(t, end > FirstRuneHeight+1, ok, end)
+		_, _, ok = p.mintable(md, b.id, end)
+		assert.False(t, ok, end)
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"math/big"
+)
+
+const (
+	opReturn = 0x6a
+	opMagic  = 0x5d // OP_13, runestone magic number
+)
+
+// runestone field tags, even tags unrecognized are cenotaphs
+const (
+	tagBody         = 0
+	tagDivisibility = 1
+	tagFlags        = 2
+	tagSpacers      = 3
+	tagRune         = 4
+	tagSymbol       = 5
+	tagPremine      = 6
+	tagCap          = 8
+	tagAmount       = 10
+	tagHeightStart  = 12
+	tagHeightEnd    = 14
+	tagOffsetStart  = 16
+	tagOffsetEnd    = 18
+	tagMint         = 20
+	tagPointer      = 22
+)
+
+// runestone flag bits
+const (
+	flagEtching = 0
+	flagTerms   = 1
+	flagTurbo   = 2
+)
+
+const (
+	MaxDivisibility = 38
+	MaxSpacers      = 0x07FFFFFF
+)
+
+// cenotaph flaws
+const (
+	FlawOpcode              = "opcode"
+	FlawInvalidScript       = "invalid script"
+	FlawVarint              = "varint"
+	FlawTrailingIntegers    = "trailing integers"
+	FlawTruncatedField      = "truncated field"
+	FlawEdictRuneId         = "edict rune id"
+	FlawEdictOutput         = "edict output"
+	FlawSupplyOverflow      = "supply overflow"
+	FlawUnrecognizedFlag    = "unrecognized flag"
+	FlawUnrecognizedEvenTag = "unrecognized even tag"
+)
+
+var maxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+type Edict struct {
+	Id     RuneId
+	Amount *big.Int // 0: all unallocated
+	Output uint32   // outputs count: split among non OP_RETURN outputs
+}
+
+type Terms struct {
+	Amount      *big.Int
+	Cap         *big.Int
+	HeightStart *uint64
+	HeightEnd   *uint64
+	OffsetStart *uint64
+	OffsetEnd   *uint64
+}
+
+type Etching struct {
+	Divisibility uint8
+	Premine      *big.Int
+	Rune         *Rune // reserved name if nil
+	Spacers      uint32
+	Symbol       string
+	Terms        *Terms
+	Turbo        bool
+}
+
+// Supply premine & all mints, false if overflows u128
+func (e *Etching) Supply() (*big.Int, bool) {
+	supply := new(big.Int)
+	if e.Premine != nil {
+		supply.Set(e.Premine)
+	}
+	if e.Terms != nil && e.Terms.Amount != nil && e.Terms.Cap != nil {
+		supply.Add(supply, new(big.Int).Mul(e.Terms.Amount, e.Terms.Cap))
+	}
+	return supply, supply.Cmp(maxU128) <= 0
+}
+
+// Runestone
+/*****************************************************
+ * runestone decoded from the first OP_RETURN OP_13 output
+ * cenotaphs burn input runes, etchings & mints of them are still counted
+ ****************************************************/
+type Runestone struct {
+	Edicts  []*Edict
+	Etching *Etching
+	Mint    *RuneId
+	Pointer *uint32
+
+	Cenotaph bool
+	Flaw     string
+}
+
+// IsOpReturn reports whether the output script is an OP_RETURN script
+func IsOpReturn(vout string) bool {
+	script, err := hex.DecodeString(vout)
+	return err == nil && len(script) > 0 && script[0] == opReturn
+}
+
+// IsTaproot reports whether the output script is a pay to taproot script
+func IsTaproot(vout string) bool {
+	script, err := hex.DecodeString(vout)
+	return err == nil && txscript.IsPayToTaproot(script)
+}
+
+// payload
+/***************************************
+ * data pushes of the runestone output, flaw if it's not push only
+ ***************************************/
+func payload(tx *xycommon.RpcTransaction) (bool, []byte, string) {
+	for _, out := range tx.Vout {
+		script, err := hex.DecodeString(out.ScriptPubKey.Hex)
+		if err != nil || len(script) < 2 || script[0] != opReturn || script[1] != opMagic {
+			continue
+		}
+
+		data := make([]byte, 0, len(script))
+		tokenizer := txscript.MakeScriptTokenizer(0, script[2:])
+		for tokenizer.Next() {
+			if tokenizer.Opcode() > txscript.OP_PUSHDATA4 {
+				return true, nil, FlawOpcode
+			}
+			data = append(data, tokenizer.Data()...)
+		}
+		if tokenizer.Err() != nil {
+			return true, nil, FlawInvalidScript
+		}
+		return true, data, ""
+	}
+	return false, nil, ""
+}
+
+// decodeVarint u128 LEB128 integer
+func decodeVarint(data []byte) (*big.Int, int, bool) {
+	n := new(big.Int)
+	for i, b := range data {
+		if i > 18 {
+			return nil, 0, false
+		}
+
+		value := uint64(b & 0x7f)
+		if i == 18 && value&0x7c != 0 {
+			return nil, 0, false
+		}
+		n.Or(n, new(big.Int).Lsh(new(big.Int).SetUint64(value), uint(7*i)))
+
+		if b&0x80 == 0 {
+			return n, i + 1, true
+		}
+	}
+	return nil, 0, false
+}
+
+// EncodeVarint u128 LEB128 integer
+func EncodeVarint(n *big.Int) []byte {
+	v := new(big.Int).Set(n)
+	out := make([]byte, 0, 19)
+	mask := big.NewInt(0x7f)
+	for v.Cmp(mask) > 0 {
+		out = append(out, byte(new(big.Int).And(v, mask).Uint64())|0x80)
+		v.Rsh(v, 7)
+	}
+	return append(out, byte(v.Uint64()))
+}
+
+// Decipher
+/***************************************
+ * decode runestone of the tx, nil if the tx has no runestone
+ ***************************************/
+func Decipher(tx *xycommon.RpcTransaction) *Runestone {
+	ok, data, flaw := payload(tx)
+	if !ok {
+		return nil
+	}
+	if flaw != "" {
+		return &Runestone{Cenotaph: true, Flaw: flaw}
+	}
+
+	integers := make([]*big.Int, 0, len(data))
+	for len(data) > 0 {
+		n, size, ok := decodeVarint(data)
+		if !ok {
+			return &Runestone{Cenotaph: true, Flaw: FlawVarint}
+		}
+		integers = append(integers, n)
+		data = data[size:]
+	}
+
+	stone := &Runestone{}
+	fields := newFields()
+	for i := 0; i < len(integers); i += 2 {
+		tag := integers[i]
+		if tag.Sign() == 0 {
+			stone.Edicts, flaw = decodeEdicts(tx, integers[i+1:])
+			break
+		}
+
+		if i+1 >= len(integers) {
+			flaw = FlawTruncatedField
+			break
+		}
+		fields.push(tag, integers[i+1])
+	}
+
+	flags := new(big.Int)
+	if v, ok := fields.take(tagFlags, 1, func(v []*big.Int) bool { return true }); ok {
+		flags = v[0]
+	}
+
+	if takeFlag(flags, flagEtching) {
+		stone.Etching = decodeEtching(fields, flags)
+	}
+
+	if v, ok := fields.take(tagMint, 2, func(v []*big.Int) bool {
+		_, ok := newRuneId(v[0], v[1])
+		return ok
+	}); ok {
+		id, _ := newRuneId(v[0], v[1])
+		stone.Mint = &id
+	}
+
+	if v, ok := fields.take(tagPointer, 1, func(v []*big.Int) bool {
+		return v[0].IsUint64() && v[0].Uint64() < uint64(len(tx.Vout))
+	}); ok {
+		pointer := uint32(v[0].Uint64())
+		stone.Pointer = &pointer
+	}
+
+	if stone.Etching != nil {
+		if _, ok := stone.Etching.Supply(); !ok {
+			flaw = FlawSupplyOverflow
+		}
+	}
+	if flags.Sign() != 0 {
+		flaw = FlawUnrecognizedFlag
+	}
+	if fields.evenTag() {
+		flaw = FlawUnrecognizedEvenTag
+	}
+
+	if flaw != "" {
+		// cenotaphs keep the mint & the explicitly named etching only
+		cenotaph := &Runestone{Cenotaph: true, Flaw: flaw, Mint: stone.Mint}
+		if stone.Etching != nil && stone.Etching.Rune != nil {
+			cenotaph.Etching = &Etching{Rune: stone.Etching.Rune}
+		}
+		return cenotaph
+	}
+	return stone
+}
+
+func decodeEdicts(tx *xycommon.RpcTransaction, integers []*big.Int) ([]*Edict, string) {
+	edicts := make([]*Edict, 0, len(integers)/4)
+	id := RuneId{}
+	for i := 0; i < len(integers); i += 4 {
+		if i+4 > len(integers) {
+			return edicts, FlawTrailingIntegers
+		}
+
+		next, ok := id.next(integers[i], integers[i+1])
+		if !ok {
+			return edicts, FlawEdictRuneId
+		}
+
+		output := integers[i+3]
+		if !output.IsUint64() || output.Uint64() > uint64(len(tx.Vout)) {
+			return edicts, FlawEdictOutput
+		}
+
+		id = next
+		edicts = append(edicts, &Edict{Id: id, Amount: integers[i+2], Output: uint32(output.Uint64())})
+	}
+	return edicts, ""
+}
+
+func decodeEtching(fields *fields, flags *big.Int) *Etching {
+	etching := &Etching{}
+	if v, ok := fields.take(tagDivisibility, 1, func(v []*big.Int) bool {
+		return v[0].IsUint64() && v[0].Uint64() <= MaxDivisibility
+	}); ok {
+		etching.Divisibility = uint8(v[0].Uint64())
+	}
+
+	if v, ok := fields.take(tagPremine, 1, func(v []*big.Int) bool { return true }); ok {
+		etching.Premine = v[0]
+	}
+
+	if v, ok := fields.take(tagRune, 1, func(v []*big.Int) bool { return true }); ok {
+		r := Rune{v[0]}
+		etching.Rune = &r
+	}
+
+	if v, ok := fields.take(tagSpacers, 1, func(v []*big.Int) bool {
+		return v[0].IsUint64() && v[0].Uint64() <= MaxSpacers
+	}); ok {
+		etching.Spacers = uint32(v[0].Uint64())
+	}
+
+	if v, ok := fields.take(tagSymbol, 1, func(v []*big.Int) bool {
+		return v[0].IsUint64() && v[0].Uint64() <= 0x10FFFF && (v[0].Uint64() < 0xD800 || v[0].Uint64() > 0xDFFF)
+	}); ok {
+		etching.Symbol = string(rune(v[0].Uint64()))
+	}
+
+	if takeFlag(flags, flagTerms) {
+		terms := &Terms{}
+		if v, ok := fields.take(tagCap, 1, func(v []*big.Int) bool { return true }); ok {
+			terms.Cap = v[0]
+		}
+		if v, ok := fields.take(tagAmount, 1, func(v []*big.Int) bool { return true }); ok {
+			terms.Amount = v[0]
+		}
+		terms.HeightStart = fields.takeUint64(tagHeightStart)
+		terms.HeightEnd = fields.takeUint64(tagHeightEnd)
+		terms.OffsetStart = fields.takeUint64(tagOffsetStart)
+		terms.OffsetEnd = fields.takeUint64(tagOffsetEnd)
+		etching.Terms = terms
+	}
+
+	etching.Turbo = takeFlag(flags, flagTurbo)
+	return etching
+}
+
+// takeFlag clear the flag bit & reports whether it's set
+func takeFlag(flags *big.Int, bit int) bool {
+	if flags.Bit(bit) == 0 {
+		return false
+	}
+	flags.SetBit(flags, bit, 0)
+	return true
+}
+
+// fields tag values of runestone message, values of a tag are taken in order
+type fields struct {
+	tags   []*big.Int
+	values map[string][]*big.Int
+}
+
+func newFields() *fields {
+	return &fields{values: make(map[string][]*big.Int)}
+}
+
+func (f *fields) push(tag, value *big.Int) {
+	key := tag.String()
+	if _, ok := f.values[key]; !ok {
+		f.tags = append(f.tags, tag)
+	}
+	f.values[key] = append(f.values[key], value)
+}
+
+// take the first n values of the tag if valid, the values are kept otherwise
+func (f *fields) take(tag int64, n int, valid func(v []*big.Int) bool) ([]*big.Int, bool) {
+	key := big.NewInt(tag).String()
+	values := f.values[key]
+	if len(values) < n || !valid(values[:n]) {
+		return nil, false
+	}
+
+	taken := values[:n]
+	if len(values) == n {
+		delete(f.values, key)
+	} else {
+		f.values[key] = values[n:]
+	}
+	return taken, true
+}
+
+func (f *fields) takeUint64(tag int64) *uint64 {
+	v, ok := f.take(tag, 1, func(v []*big.Int) bool { return v[0].IsUint64() })
+	if !ok {
+		return nil
+	}
+	n := v[0].Uint64()
+	return &n
+}
+
+// evenTag reports whether unrecognized even tags left
+func (f *fields) evenTag() bool {
+	for _, tag := range f.tags {
+		if _, ok := f.values[tag.String()]; ok && tag.Bit(0) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/utils"
+	"strings"
+)
+
+// normalizeAddress
+/***************************************
+ * checksum hex addresses of evm chains, lower case bech32 addresses of cosmos chains
+ * bech32 addresses are accepted for bech32 senders only & must share the prefix of the sender
+ ***************************************/
+func normalizeAddress(sender, address string) (string, bool) {
+	address = strings.TrimSpace(address)
+	senderHrp, _, err := utils.DecodeBech32Address(sender)
+	if strings.HasPrefix(address, "0x") || err != nil {
+		if !ethcommon.IsHexAddress(address) {
+			return "", false
+		}
+		return ethcommon.HexToAddress(address).String(), true
+	}
+
+	hrp, _, err := utils.DecodeBech32Address(address)
+	if err != nil || hrp != senderHrp {
+		return "", false
+	}
+	return strings.ToLower(address), true
+}
+
+// normalizeReceiver
+/***************************************
+ * receiver address checking by the address format of the sender, bitcoin addresses kept as is
+ ***************************************/
+func normalizeReceiver(sender, address string) (string, bool) {
+	address = strings.TrimSpace(address)
+	if isBitcoinAddress(sender) {
+		return address, isBitcoinAddress(address)
+	}
+	return normalizeAddress(sender, address)
+}
+
+// isBitcoinAddress base58 & segwit addresses of bitcoin mainnet & testnet
+func isBitcoinAddress(address string) bool {
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNet3Params} {
+		if _, err := btcutil.DecodeAddress(address, params); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// accountAddress 20 bytes account of hex & bech32 addresses, allowlist leaves are built by accounts
+func accountAddress(address string) (ethcommon.Address, bool) {
+	if ethcommon.IsHexAddress(address) {
+		return ethcommon.HexToAddress(address), true
+	}
+
+	_, bytes, err := utils.DecodeBech32Address(address)
+	if err != nil || len(bytes) != ethcommon.AddressLength {
+		return ethcommon.Address{}, false
+	}
+	return ethcommon.BytesToAddress(bytes), true
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/bridge"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"strings"
+)
+
+// Wrap
+/***************************************
+ * wrapped token movements of configured bridge wrappers, inscriptions held by the bridge back the wrapped supply
+ * wrapped balances are tracked apart from inscription balances
+ ***************************************/
+func (base *Protocol) Wrap(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	movements := bridge.ExtractMovements(omd.Protocol, tx)
+	if len(movements) <= 0 {
+		return nil, nil
+	}
+
+	// wrapped balance & bridge supply changes by previous movements of the tx
+	changes := make(map[string]decimal.Decimal, len(movements))
+	supplies := make(map[string]decimal.Decimal, len(movements))
+	items := make([]*devents.TxResult, 0, len(movements))
+	for _, m := range movements {
+		md := omd.Copy()
+		md.Operate = devents.OperateWrap
+		md.Tick = utils.NormalizeTick(m.Tick, base.rulesOf(md).CaseSensitive)
+
+		from := strings.ToLower(fmt.Sprintf("%s_%s_%s", md.Tick, m.Contract, m.From))
+		supply := strings.ToLower(m.Bridge)
+		if err := base.verifyWrap(md, m, changes[from], supplies[supply]); err != nil {
+			xylog.Logger.Infof("tx[%s] - wrapped token movement verified failed, err:%v, movement:%v", tx.Hash, err, m)
+			continue
+		}
+
+		if m.From != "" {
+			changes[from] = changes[from].Sub(m.Amount)
+		} else {
+			supplies[supply] = supplies[supply].Add(m.Amount)
+		}
+		if m.To != "" {
+			to := strings.ToLower(fmt.Sprintf("%s_%s_%s", md.Tick, m.Contract, m.To))
+			changes[to] = changes[to].Add(m.Amount)
+		} else {
+			supplies[supply] = supplies[supply].Sub(m.Amount)
+		}
+
+		items = append(items, &devents.TxResult{
+			MD:    md,
+			Block: block,
+			Tx:    tx,
+			Wrap: &devents.Wrap{
+				Contract: m.Contract,
+				From:     m.From,
+				To:       m.To,
+				Amount:   m.Amount,
+			},
+		})
+	}
+	return items, nil
+}
+
+func (base *Protocol) verifyWrap(md *devents.MetaData, m *bridge.Movement, change, supplyChange decimal.Decimal) *xyerrors.InsError {
+	if m.Amount.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-14, "wrapped amount <= 0")
+	}
+
+	ok, inscription := base.cache.Inscription.Get(md.Protocol, md.Tick)
+	if !ok || inscription == nil {
+		return xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", md.Protocol, md.Tick))
+	}
+
+	// wraps are backed by the bridge, unwraps & transfers are debited from the wrapped balance
+	if m.From == "" {
+		return base.verifyWrapSupply(md, m, supplyChange)
+	}
+
+	balance := change
+	if ok, item := base.cache.Wrapped.Get(md.Protocol, md.Tick, m.Contract, m.From); ok {
+		balance = balance.Add(item.Amount)
+	}
+	if balance.LessThan(m.Amount) {
+		return xyerrors.NewInsError(-17, fmt.Sprintf("sender wrapped balance[%v] < amount[%v]", balance, m.Amount))
+	}
+	return nil
+}
+
+// verifyWrapSupply
+/***************************************
+ * wrapped supply of the bridge's wrappers must not exceed the inscriptions held by the bridge
+ ***************************************/
+func (base *Protocol) verifyWrapSupply(md *devents.MetaData, m *bridge.Movement, change decimal.Decimal) *xyerrors.InsError {
+	supply := change.Add(m.Amount)
+	for _, w := range bridge.Wrappers() {
+		if w.Protocol() != md.Protocol || !strings.EqualFold(w.Bridge(), m.Bridge) {
+			continue
+		}
+
+		if utils.NormalizeTick(w.Tick(), base.rulesOf(md).CaseSensitive) != md.Tick {
+			continue
+		}
+		supply = supply.Add(base.cache.Wrapped.Supply(md.Protocol, md.Tick, w.Contract()))
+	}
+
+	held := decimal.Zero
+	if ok, balance := base.cache.Balance.Get(md.Protocol, md.Tick, m.Bridge); ok {
+		held = balance.Overall
+	}
+	if supply.GreaterThan(held) {
+		return xyerrors.NewInsError(-64, fmt.Sprintf("wrapped supply[%v] > bridge[%s] balance[%v]", supply, m.Bridge, held))
+	}
+	return nil
+}
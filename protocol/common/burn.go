@@ -0,0 +1,98 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/xyerrors"
+)
+
+type Burn struct {
+	Amount decimal.Decimal `json:"amt"`
+}
+
+func (base *Protocol) Burn(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	b, err := base.verifyBurn(tx, md)
+	if err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
+	}
+
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Burn: &devents.Burn{
+			Sender: tx.From,
+			Amount: b.Amount,
+		},
+	}
+	return []*devents.TxResult{result}, nil
+}
+
+func (base *Protocol) verifyBurn(tx *xycommon.RpcTransaction, md *devents.MetaData) (*Burn, *xyerrors.InsError) {
+	if !base.rulesOf(md).Burn {
+		return nil, xyerrors.NewInsError(-63, fmt.Sprintf("protocol[%s] burn operation not enabled", md.Protocol))
+	}
+
+	if err := base.VerifyGrammar(md); err != nil {
+		return nil, err
+	}
+
+	b := &Burn{}
+	err := json.Unmarshal([]byte(md.Data), b)
+	if err != nil {
+		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("data json deocde err:%v, data[%s]", err, md.Data))
+	}
+
+	if b.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, xyerrors.NewInsError(-14, "burn amount <= 0")
+	}
+
+	var (
+		protocol = md.Protocol
+		tick     = md.Tick
+	)
+	ok, inscription := base.cache.Inscription.Get(protocol, tick)
+	if !ok || inscription == nil {
+		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
+	}
+
+	if err := base.VerifyPrecision(md, "amt", b.Amount, inscription.Decimals); err != nil {
+		return nil, err
+	}
+
+	// sender balance checking
+	ok, balance := base.cache.Balance.Get(protocol, tick, tx.From)
+	if !ok {
+		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, tx.From))
+	}
+
+	if balance.Available.LessThan(b.Amount) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < burn amount[%v]", balance.Available, b.Amount))
+	}
+	return b, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/protocol/types"
+	"testing"
+)
+
+func TestBurn(t *testing.T) {
+	// burns are opt-in by profiles
+	rules := *types.DefaultRules
+	rules.Burn = true
+	rules.BurnAddresses = []string{"0x000000000000000000000000000000000000dEaD"}
+	cfg := &config.Config{
+		Chain:        config.ChainConfig{ChainName: model.ChainAVAX},
+		RuleProfiles: map[string]*config.ProtocolRules{"asc-20": &rules},
+	}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		sender = "0x00000000000000000000000000000000000000a1"
+		alice  = "0x00000000000000000000000000000000000000b2"
+		dead   = "0x000000000000000000000000000000000000dead"
+	)
+
+	_, err := h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"ash","max":"1000","lim":"100"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"ash","amt":"100"}`)
+	assert.Nil(t, err)
+
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"burn","tick":"ash","amt":"101"}`)
+	assert.Equal(t, -17, testutil.CauseCode(err))
+
+	results, err := h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"burn","tick":"ash","amt":"20"}`)
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Transfer)
+	assert.Equal(t, "20", results[0].Burn.Amount.String())
+
+	// transfer to a burn address is burned, not credited
+	results, err = h.Inscribe(sender, dead, `data:,{"p":"asc-20","op":"transfer","tick":"ash","amt":"30"}`)
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Transfer)
+	assert.Equal(t, "30", results[0].Burn.Amount.String())
+	ok, _ := h.Cache.Balance.Get("asc-20", "ash", dead)
+	assert.False(t, ok)
+
+	// mixed batch transfer
+	results, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"transfer","tick":"ash","to":[{"to":"`+alice+`","amt":"10"},{"to":"`+dead+`","amt":"40"}]}`)
+	assert.Nil(t, err)
+	assert.Len(t, results[0].Transfer.Receives, 1)
+	assert.Equal(t, "40", results[0].Burn.Amount.String())
+
+	// one balance event of the sender for the transfer & the burn
+	events := h.Handler.BuildBalanceTxEvents(results[0])
+	assert.Len(t, events, 2)
+	assert.Equal(t, sender, events[0].Address)
+	assert.Equal(t, "-50", events[0].Amount.String())
+
+	_, balance := h.Cache.Balance.Get("asc-20", "ash", sender)
+	assert.Equal(t, "0", balance.Overall.String())
+
+	_, stats := h.Cache.InscriptionStats.Get("asc-20", "ash")
+	assert.Equal(t, "100", stats.Minted.String())
+	assert.Equal(t, "90", stats.Burned.String())
+	assert.Equal(t, int64(1), stats.Holders)
+
+	// burns are not enabled by the built-in profiles
+	h = testutil.NewHarness(t, &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}})
+
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"ash","max":"1000","lim":"100"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"ash","amt":"100"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"burn","tick":"ash","amt":"20"}`)
+	assert.Equal(t, -63, testutil.CauseCode(err))
+
+	results, err = h.Inscribe(sender, dead, `data:,{"p":"asc-20","op":"transfer","tick":"ash","amt":"30"}`)
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Burn)
+	ok, _ = h.Cache.Balance.Get("asc-20", "ash", dead)
+	assert.True(t, ok)
+}
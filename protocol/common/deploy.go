@@ -25,12 +25,16 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
 	"github.com/uxuycom/indexer/xyerrors"
 	"math"
 	"math/big"
+	"strings"
 )
 
 type Deploy struct {
@@ -38,6 +42,23 @@ type Deploy struct {
 	MaxSupply decimal.Decimal `json:"max"`
 	MintLimit decimal.Decimal `json:"lim"`
 	Decimal   decimal.Decimal `json:"dec"`
+
+	// optional mint constraints
+	WalletLimit    decimal.Decimal `json:"wlim"`  // total mint amount limit per address
+	StartBlock     decimal.Decimal `json:"start"` // mint start block
+	EndBlock       decimal.Decimal `json:"end"`   // mint end block
+	BlockMintLimit decimal.Decimal `json:"blim"`  // maximum mints per block
+	MerkleRoot     string          `json:"root"`  // allowlist merkle root, mints require proofs
+	Premine        decimal.Decimal `json:"pre"`   // supply allocated to the deployer at deploy time
+
+	// paid mints, native coin sent to the fee recipient with mints
+	Price decimal.Decimal `json:"price"`  // native coin price per token minted, 0: free mint
+	FeeTo string          `json:"fee_to"` // mint fee recipient, deployer by default
+
+	// proof of work mints, hex prefix mint hashes must meet, e.g. 0x0000
+	Workc string `json:"workc"`
+
+	Confusable string `json:"-"` // deployed tick the tick is visually confusable with, flagged
 }
 
 func (base *Protocol) Deploy(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
@@ -55,6 +76,18 @@ func (base *Protocol) Deploy(block *xycommon.RpcBlock, tx *xycommon.RpcTransacti
 			MaxSupply: d.MaxSupply,
 			MintLimit: d.MintLimit,
 			Decimal:   int8(d.Decimal.IntPart()),
+
+			WalletLimit:    d.WalletLimit,
+			StartBlock:     d.StartBlock.BigInt().Uint64(),
+			EndBlock:       d.EndBlock.BigInt().Uint64(),
+			BlockMintLimit: d.BlockMintLimit.BigInt().Uint64(),
+			MerkleRoot:     d.MerkleRoot,
+			Confusable:     d.Confusable,
+			Premine:        d.Premine,
+			Deployer:       tx.From,
+			Price:          d.Price,
+			FeeTo:          d.FeeTo,
+			Workc:          d.Workc,
 		},
 	}
 	return []*devents.TxResult{result}, nil
@@ -66,17 +99,38 @@ func (base *Protocol) verifyDeploy(tx *xycommon.RpcTransaction, md *devents.Meta
 		return nil, xyerrors.NewInsError(-12, fmt.Sprintf("protocol[%s] / tick[%s] nil", md.Protocol, md.Tick))
 	}
 
+	// tick length checking
+	rules := base.rulesOf(md)
+	tickLen := utils.TickLength(md.Tick, rules.TickLengthUnit)
+	if (rules.TickMinLength > 0 && tickLen < rules.TickMinLength) || (rules.TickMaxLength > 0 && tickLen > rules.TickMaxLength) {
+		return nil, xyerrors.NewInsError(-22, fmt.Sprintf("tick[%s] length[%d] invalid, protocol[%s]", md.Tick, tickLen, md.Protocol))
+	}
+
 	// exists checking
 	if ok, _ := base.cache.Inscription.Get(md.Protocol, md.Tick); ok {
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription deployed & abort, protocol[%s], tick[%s]", md.Protocol, md.Tick))
 	}
 
+	if err := base.VerifyGrammar(md); err != nil {
+		return nil, err
+	}
+
 	deploy := &Deploy{}
 	err := json.Unmarshal([]byte(md.Data), deploy)
 	if err != nil {
 		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("json decode err:%v", err))
 	}
 
+	// homoglyph / case variants of deployed ticks
+	if ok, name := base.cache.Inscription.GetConfusable(md.Protocol, md.Tick); ok {
+		switch rules.Confusables {
+		case types.ConfusableReject:
+			return nil, xyerrors.NewInsError(-40, fmt.Sprintf("tick[%s] confusable with deployed tick[%s], protocol[%s]", md.Tick, name, md.Protocol))
+		case types.ConfusableFlag:
+			deploy.Confusable = name
+		}
+	}
+
 	// max > 0
 	if deploy.MaxSupply.LessThanOrEqual(decimal.Zero) {
 		return nil, xyerrors.NewInsError(-14, "max <= 0")
@@ -97,9 +151,9 @@ func (base *Protocol) verifyDeploy(tx *xycommon.RpcTransaction, md *devents.Meta
 		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("invalid decimal:%s", deploy.Decimal.String()))
 	}
 
-	// maximum decimals is 18
-	if deploy.Decimal.IntPart() > 18 {
-		return nil, xyerrors.NewInsError(-18, fmt.Sprintf("decimal[%d] > 18", deploy.Decimal.IntPart()))
+	// maximum decimals, 18 by default
+	if deploy.Decimal.IntPart() > rules.MaxDecimals {
+		return nil, xyerrors.NewInsError(-18, fmt.Sprintf("decimal[%d] > %d", deploy.Decimal.IntPart(), rules.MaxDecimals))
 	}
 
 	// MaxSupply must <= uint64
@@ -107,5 +161,130 @@ func (base *Protocol) verifyDeploy(tx *xycommon.RpcTransaction, md *devents.Meta
 	if deploy.MaxSupply.GreaterThan(maxUint64Decimal) {
 		return nil, xyerrors.NewInsError(-19, fmt.Sprintf("max[%s] > max_uint64", deploy.MaxSupply.String()))
 	}
+
+	if err := base.verifyDeployMintConstraints(deploy); err != nil {
+		return nil, err
+	}
+
+	if err := base.verifyDeployPayment(tx, deploy); err != nil {
+		return nil, err
+	}
+
+	if err := base.verifyDeployWork(md, deploy); err != nil {
+		return nil, err
+	}
+
+	// amounts precision of strict mode
+	amounts := []struct {
+		name  string
+		value decimal.Decimal
+	}{
+		{"max", deploy.MaxSupply},
+		{"lim", deploy.MintLimit},
+		{"wlim", deploy.WalletLimit},
+		{"pre", deploy.Premine},
+	}
+	for _, item := range amounts {
+		if err := base.VerifyPrecision(md, item.name, item.value, int8(deploy.Decimal.IntPart())); err != nil {
+			return nil, err
+		}
+	}
 	return deploy, nil
 }
+
+func (base *Protocol) verifyDeployMintConstraints(deploy *Deploy) *xyerrors.InsError {
+	// wallet limit: 0 or in range [limit, max]
+	if deploy.WalletLimit.IsNegative() {
+		return xyerrors.NewInsError(-24, "wlim < 0")
+	}
+	if deploy.WalletLimit.IsPositive() && (deploy.WalletLimit.LessThan(deploy.MintLimit) || deploy.WalletLimit.GreaterThan(deploy.MaxSupply)) {
+		return xyerrors.NewInsError(-24, fmt.Sprintf("wlim[%s] out of range [lim, max]", deploy.WalletLimit.String()))
+	}
+
+	// block window & block mints must be uint64
+	maxUint64Decimal := decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)
+	fields := []struct {
+		name  string
+		value decimal.Decimal
+	}{
+		{"start", deploy.StartBlock},
+		{"end", deploy.EndBlock},
+		{"blim", deploy.BlockMintLimit},
+	}
+	for _, f := range fields {
+		if !f.value.IsInteger() || f.value.IsNegative() || f.value.GreaterThan(maxUint64Decimal) {
+			return xyerrors.NewInsError(-25, fmt.Sprintf("invalid %s:%s", f.name, f.value.String()))
+		}
+	}
+
+	// end >= start
+	if deploy.EndBlock.IsPositive() && deploy.EndBlock.LessThan(deploy.StartBlock) {
+		return xyerrors.NewInsError(-25, fmt.Sprintf("end[%s] < start[%s]", deploy.EndBlock.String(), deploy.StartBlock.String()))
+	}
+
+	// premine: 0 or in range (0, max]
+	if deploy.Premine.IsNegative() || deploy.Premine.GreaterThan(deploy.MaxSupply) {
+		return xyerrors.NewInsError(-43, fmt.Sprintf("pre[%s] out of range [0, max]", deploy.Premine.String()))
+	}
+
+	// premine credited at deploy time, precision checked in all modes
+	if -deploy.Premine.Exponent() > int32(deploy.Decimal.IntPart()) {
+		return xyerrors.NewInsError(-45, fmt.Sprintf("pre[%s] precision exceeds decimals[%d]", deploy.Premine.String(), deploy.Decimal.IntPart()))
+	}
+
+	// merkle root must be a 32 bytes hex string
+	if deploy.MerkleRoot != "" {
+		root, err := hexutil.Decode(deploy.MerkleRoot)
+		if err != nil || len(root) != 32 {
+			return xyerrors.NewInsError(-36, fmt.Sprintf("invalid merkle root:%s", deploy.MerkleRoot))
+		}
+		deploy.MerkleRoot = hexutil.Encode(root)
+	}
+	return nil
+}
+
+// verifyDeployPayment
+/***************************************
+ * paid mint price must be in native coin precision,
+ * fee recipient is the deployer if not specified
+ ***************************************/
+func (base *Protocol) verifyDeployPayment(tx *xycommon.RpcTransaction, deploy *Deploy) *xyerrors.InsError {
+	if deploy.Price.IsNegative() || -deploy.Price.Exponent() > NativeDecimals {
+		return xyerrors.NewInsError(-46, fmt.Sprintf("invalid price:%s", deploy.Price.String()))
+	}
+
+	if !deploy.Price.IsPositive() {
+		deploy.FeeTo = ""
+		return nil
+	}
+
+	if deploy.FeeTo == "" {
+		deploy.FeeTo = tx.From
+	}
+	feeTo, ok := normalizeAddress(tx.From, deploy.FeeTo)
+	if !ok {
+		return xyerrors.NewInsError(-46, fmt.Sprintf("invalid fee recipient:%s", deploy.FeeTo))
+	}
+	deploy.FeeTo = feeTo
+	return nil
+}
+
+// verifyDeployWork
+/***************************************
+ * proof of work difficulty, hex prefix of 1 ~ 64 nibbles
+ * ignored if proof of work mints not enabled by the rules
+ ***************************************/
+func (base *Protocol) verifyDeployWork(md *devents.MetaData, deploy *Deploy) *xyerrors.InsError {
+	if base.rulesOf(md).PowHash == "" || deploy.Workc == "" {
+		deploy.Workc = ""
+		return nil
+	}
+
+	workc := strings.ToLower(strings.TrimSpace(deploy.Workc))
+	nibbles := strings.TrimPrefix(workc, "0x")
+	if !strings.HasPrefix(workc, "0x") || len(nibbles) < 1 || len(nibbles) > 64 || strings.Trim(nibbles, "0123456789abcdef") != "" {
+		return xyerrors.NewInsError(-49, fmt.Sprintf("invalid workc:%s", deploy.Workc))
+	}
+	deploy.Workc = workc
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"testing"
+)
+
+func TestPremine(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		deployer = "0x00000000000000000000000000000000000000a1"
+		minter   = "0x00000000000000000000000000000000000000b2"
+	)
+
+	_, err := h.Inscribe(deployer, deployer, `data:,{"p":"asc-20","op":"deploy","tick":"pre","max":"100","lim":"10","pre":"101"}`)
+	assert.Equal(t, -43, testutil.CauseCode(err))
+	_, err = h.Inscribe(deployer, deployer, `data:,{"p":"asc-20","op":"deploy","tick":"pre","max":"100","lim":"10","pre":"-1"}`)
+	assert.Equal(t, -43, testutil.CauseCode(err))
+	_, err = h.Inscribe(deployer, deployer, `data:,{"p":"asc-20","op":"deploy","tick":"pre","max":"100","lim":"10","pre":"1.5","dec":"0"}`)
+	assert.Equal(t, -45, testutil.CauseCode(err))
+
+	results, err := h.Inscribe(deployer, deployer, `data:,{"p":"asc-20","op":"deploy","tick":"pre","max":"100","lim":"10","pre":"40"}`)
+	assert.Nil(t, err)
+	_, balance := h.Cache.Balance.Get("asc-20", "pre", deployer)
+	assert.Equal(t, "40", balance.Overall.String())
+	assert.Equal(t, "40", balance.Available.String())
+	_, stats := h.Cache.InscriptionStats.Get("asc-20", "pre")
+	assert.Equal(t, "40", stats.Minted.String())
+	assert.Equal(t, int64(1), stats.Holders)
+
+	events := h.Handler.BuildBalanceTxEvents(results[0])
+	assert.Len(t, events, 1)
+	assert.Equal(t, model.TransactionEventPremine, events[0].Event)
+	assert.Equal(t, devents.DBActionCreate, events[0].Action)
+	assert.Equal(t, deployer, events[0].Address)
+	assert.Equal(t, "40", events[0].Amount.String())
+
+	// premine counts towards the max supply
+	mint := `data:,{"p":"asc-20","op":"mint","tick":"pre","amt":"10"}`
+	for i := 0; i < 6; i++ {
+		_, err = h.Inscribe(minter, minter, mint)
+		assert.Nil(t, err)
+	}
+	_, err = h.Inscribe(minter, minter, mint)
+	assert.NotNil(t, err)
+	assert.Equal(t, "100", stats.Minted.String())
+	assert.Equal(t, int64(2), stats.Holders)
+}
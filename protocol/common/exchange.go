@@ -0,0 +1,215 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/market"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"strings"
+)
+
+// Exchange
+/***************************************
+ * filled & canceled orders of configured marketplaces, orders of listings are settled by
+ * listing state, others are transfers from seller (or the escrow market) to buyer
+ ***************************************/
+func (base *Protocol) Exchange(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	orders := market.ExtractOrders(omd.Protocol, tx)
+	if len(orders) <= 0 {
+		return nil, nil
+	}
+
+	// amounts debited per protocol, tick & sender & listings settled by previous orders of the tx
+	spent := make(map[string]decimal.Decimal, len(orders))
+	settled := make(map[string]struct{}, len(orders))
+	items := make([]*devents.TxResult, 0, len(orders))
+	for _, order := range orders {
+		md := omd.Copy()
+		md.Operate = order.Operate
+
+		if order.ListId != "" {
+			if ok, _ := base.cache.Listing.Get(order.ListId); ok {
+				item, err := base.settleListing(block, tx, md, order, settled)
+				if err != nil {
+					xylog.Logger.Infof("tx[%s] - marketplace listing order verified failed, err:%v, order:%v", tx.Hash, err, order)
+					continue
+				}
+				settled[strings.ToLower(order.ListId)] = struct{}{}
+				items = append(items, item)
+				continue
+			}
+		}
+
+		sender := order.Seller
+		if order.Escrow {
+			sender = order.Market
+		}
+
+		if err := base.verifyOrder(md, order, sender, spent); err != nil {
+			xylog.Logger.Infof("tx[%s] - marketplace order verified failed, err:%v, order:%v", tx.Hash, err, order)
+			continue
+		}
+		key := spentKey(md, sender)
+		spent[key] = spent[key].Add(order.Amount)
+
+		item := &devents.TxResult{
+			MD:    md,
+			Block: block,
+			Tx:    tx,
+			Transfer: &devents.Transfer{
+				Sender: sender,
+				Receives: []*devents.Receive{
+					{
+						Address: order.Buyer,
+						Amount:  order.Amount,
+					},
+				},
+			},
+		}
+		if order.Operate == devents.OperateExchange {
+			item.Trade = devents.NewTrade("", order.Market, order.Seller, order.Buyer, order.Amount, order.Price.Shift(-NativeDecimals))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// settleListing
+/***************************************
+ * delist or fill an open listing, the order is emitted by the listing's market
+ ***************************************/
+func (base *Protocol) settleListing(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData, order *market.Order, settled map[string]struct{}) (*devents.TxResult, *xyerrors.InsError) {
+	listing, err := base.verifyListingOrder(md, order, settled)
+	if err != nil {
+		return nil, err
+	}
+	md.Tick = listing.Tick
+
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Listing: &devents.Listing{
+			ListId: order.ListId,
+			Seller: listing.Seller,
+			Market: listing.Market,
+			Amount: listing.Amount,
+			Status: model.ListingStatusDelisted,
+		},
+	}
+
+	if order.Operate == devents.OperateDelist {
+		return result, nil
+	}
+
+	result.Listing.Buyer = order.Buyer
+	result.Listing.Status = model.ListingStatusFilled
+	result.Transfer = &devents.Transfer{
+		Sender: listing.Seller,
+		Receives: []*devents.Receive{
+			{
+				Address: order.Buyer,
+				Amount:  listing.Amount,
+			},
+		},
+	}
+	result.Trade = devents.NewTrade(order.ListId, listing.Market, listing.Seller, order.Buyer, listing.Amount, order.Price.Shift(-NativeDecimals))
+	return result, nil
+}
+
+func (base *Protocol) verifyListingOrder(md *devents.MetaData, order *market.Order, settled map[string]struct{}) (*dcache.ListingItem, *xyerrors.InsError) {
+	_, listing := base.cache.Listing.Get(order.ListId)
+	if _, ok := settled[strings.ToLower(order.ListId)]; ok || listing.Status != model.ListingStatusListed {
+		return nil, xyerrors.NewInsError(-32, fmt.Sprintf("listing[%s] status[%d] not listed", order.ListId, listing.Status))
+	}
+
+	if !strings.EqualFold(listing.Market, order.Market) || listing.Protocol != md.Protocol {
+		return nil, xyerrors.NewInsError(-33, fmt.Sprintf("listing[%s] market[%s]-protocol[%s] mismatch, order market[%s]-protocol[%s]", order.ListId, listing.Market, listing.Protocol, order.Market, md.Protocol))
+	}
+
+	// mapped fields of the order must match the listing
+	if order.Tick != "" && utils.NormalizeTick(order.Tick, base.rulesOf(md).CaseSensitive) != listing.Tick {
+		return nil, xyerrors.NewInsError(-33, fmt.Sprintf("listing[%s] tick[%s] mismatch, order tick[%s]", order.ListId, listing.Tick, order.Tick))
+	}
+
+	if !order.Amount.IsZero() && !listing.Amount.Equal(order.Amount) {
+		return nil, xyerrors.NewInsError(-34, fmt.Sprintf("listing[%s] amount[%v] != order amount[%v]", order.ListId, listing.Amount, order.Amount))
+	}
+
+	if order.Seller != "" && !strings.EqualFold(listing.Seller, order.Seller) {
+		return nil, xyerrors.NewInsError(-35, fmt.Sprintf("listing[%s] seller[%s] != order seller[%s]", order.ListId, listing.Seller, order.Seller))
+	}
+	return listing, nil
+}
+
+func (base *Protocol) verifyOrder(md *devents.MetaData, order *market.Order, sender string, spent map[string]decimal.Decimal) *xyerrors.InsError {
+	// orders mapping listings only, settled by listing records
+	if order.Tick == "" && order.TickHash == "" {
+		return xyerrors.NewInsError(-31, fmt.Sprintf("listing[%s] not exist", order.ListId))
+	}
+
+	// resolve tick by name or hash
+	if order.Tick != "" {
+		md.Tick = utils.NormalizeTick(order.Tick, base.rulesOf(md).CaseSensitive)
+	} else {
+		ok, tick := base.cache.Inscription.GetNameByHash(md.Protocol, order.TickHash)
+		if !ok {
+			return xyerrors.NewInsError(-11, fmt.Sprintf("tick not found, hash[%s]", order.TickHash))
+		}
+		md.Tick = tick
+	}
+
+	if order.Amount.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-14, "order amount <= 0")
+	}
+
+	ok, inscription := base.cache.Inscription.Get(md.Protocol, md.Tick)
+	if !ok || inscription == nil {
+		return xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", md.Protocol, md.Tick))
+	}
+
+	// sender balance checking
+	ok, balance := base.cache.Balance.Get(md.Protocol, md.Tick, sender)
+	if !ok {
+		return xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", md.Protocol, md.Tick, sender))
+	}
+
+	available := balance.Available.Sub(spent[spentKey(md, sender)])
+	if available.LessThan(order.Amount) {
+		return xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < order amount[%v]", available, order.Amount))
+	}
+	return nil
+}
+
+// spentKey key of amounts debited by orders of a tx
+func spentKey(md *devents.MetaData, sender string) string {
+	return fmt.Sprintf("%s_%s_%s", md.Protocol, md.Tick, strings.ToLower(sender))
+}
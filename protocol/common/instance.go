@@ -23,25 +23,83 @@
 package common
 
 import (
+	"fmt"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
+	"strings"
 )
 
-const DataPrefix = "0x646174613a"
+const (
+	DataPrefix = "0x646174613a"
+
+	// GzipDataPrefix ESIP-7 gzip compressed calldata, gzip magic & deflate method
+	GzipDataPrefix = "0x1f8b08"
+)
 
 type Protocol struct {
 	cache *dcache.Manager
+	rules types.RuleSchedule
 }
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
+	if len(rules) <= 0 {
+		rules = types.NewRuleSchedule()
+	}
 	return &Protocol{
 		cache: cache,
+		rules: rules,
 	}
 }
 
+// Rules returns the latest rules of the protocol
+func (base *Protocol) Rules() *config.ProtocolRules {
+	return base.rules.Latest().Rules
+}
+
+// ResolveRules
+/***************************************
+ * resolve rules as of the tx block by fork schedule, recorded to the metadata
+ ***************************************/
+func (base *Protocol) ResolveRules(block *xycommon.RpcBlock, md *devents.MetaData) *config.ProtocolRules {
+	var height uint64
+	if block != nil && block.Number != nil {
+		height = block.Number.Uint64()
+	}
+
+	fork := base.rules.At(height)
+	md.Rules = fork.Rules
+	md.RuleVersion = fork.Version
+	return fork.Rules
+}
+
+// rulesOf returns the rules resolved to the metadata, latest rules if not resolved
+func (base *Protocol) rulesOf(md *devents.MetaData) *config.ProtocolRules {
+	if md.Rules != nil {
+		return md.Rules
+	}
+	return base.Rules()
+}
+
 func (base *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	rules := base.ResolveRules(block, md)
+
+	// operates of contract events, calldata of the tx not used
+	switch md.Operate {
+	case devents.OperateExchange:
+		return base.Exchange(block, tx, md)
+	case devents.OperateWrap:
+		return base.Wrap(block, tx, md)
+	}
+
+	// calldata sent to contracts checking
+	if !rules.ContractCalldata && tx.ToContract {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(xyerrors.NewInsError(-21, fmt.Sprintf("protocol[%s] calldata sent to contract[%s] ignored", md.Protocol, tx.To)))
+	}
+
 	switch md.Operate {
 	case devents.OperateDeploy:
 		return base.Deploy(block, tx, md)
@@ -49,6 +107,31 @@ func (base *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransactio
 		return base.Mint(block, tx, md)
 	case devents.OperateTransfer:
 		return base.Transfer(block, tx, md)
+	case devents.OperateBurn:
+		return base.Burn(block, tx, md)
+	case devents.OperateLock, devents.OperateVest:
+		return base.Lock(block, tx, md)
 	}
 	return nil, nil
 }
+
+// IsBurnAddress burn address checking of the rules, case-insensitive
+func (base *Protocol) IsBurnAddress(md *devents.MetaData, address string) bool {
+	for _, item := range base.rulesOf(md).BurnAddresses {
+		if strings.EqualFold(item, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// FastCheckDataPrefix input dmt format checking
+func FastCheckDataPrefix(tx *xycommon.RpcTransaction) bool {
+	// 0x prefix checking
+	if !strings.HasPrefix(tx.Input, "0x") {
+		return false
+	}
+
+	// data prefix checking
+	return strings.HasPrefix(tx.Input, DataPrefix) || strings.HasPrefix(tx.Input, GzipDataPrefix)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/xyerrors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// MaxVestTranches maximum tranches of a vesting schedule
+const MaxVestTranches = 100
+
+type Lock struct {
+	Amount decimal.Decimal `json:"amt"`
+
+	// Unlock block height of time locks, all amount released at the height
+	Unlock decimal.Decimal `json:"unlock"`
+
+	// Schedule vesting tranches of ascending heights, [{"height":"100","amt":"10"}]
+	Schedule []*VestTranche `json:"schedule"`
+
+	// To beneficiary of the locked amount, sender by default
+	To string `json:"to"`
+
+	tranches []*model.LockTranche
+}
+
+type VestTranche struct {
+	Height decimal.Decimal `json:"height"`
+	Amount decimal.Decimal `json:"amt"`
+}
+
+// Lock
+/***************************************
+ * time lock & vesting, amount locked out of the beneficiary's available balance until released by block height
+ ***************************************/
+func (base *Protocol) Lock(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	l, err := base.verifyLock(block, tx, md)
+	if err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
+	}
+
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Lock: &devents.Lock{
+			LockId:   tx.Hash,
+			Address:  l.To,
+			Amount:   l.Amount,
+			Tranches: l.tranches,
+		},
+	}
+
+	// locks to other addresses, amount transferred to the beneficiary before locking
+	if !strings.EqualFold(l.To, tx.From) {
+		result.Transfer = &devents.Transfer{
+			Sender: tx.From,
+			Receives: []*devents.Receive{
+				{
+					Address: l.To,
+					Amount:  l.Amount,
+				},
+			},
+		}
+	}
+	return []*devents.TxResult{result}, nil
+}
+
+func (base *Protocol) verifyLock(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*Lock, *xyerrors.InsError) {
+	if err := base.VerifyGrammar(md); err != nil {
+		return nil, err
+	}
+
+	l := &Lock{}
+	err := json.Unmarshal([]byte(md.Data), l)
+	if err != nil {
+		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("data json deocde err:%v, data[%s]", err, md.Data))
+	}
+
+	var height uint64
+	if block != nil && block.Number != nil {
+		height = block.Number.Uint64()
+	}
+	if err := l.parseTranches(md.Operate, height); err != nil {
+		return nil, err
+	}
+
+	var (
+		protocol = md.Protocol
+		tick     = md.Tick
+	)
+	ok, inscription := base.cache.Inscription.Get(protocol, tick)
+	if !ok || inscription == nil {
+		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
+	}
+
+	for _, item := range l.tranches {
+		if err := base.VerifyPrecision(md, "amt", item.Amount, inscription.Decimals); err != nil {
+			return nil, err
+		}
+	}
+
+	// beneficiary, sender by default
+	l.To = strings.TrimSpace(l.To)
+	if l.To == "" {
+		l.To = tx.From
+	} else {
+		to, ok := normalizeAddress(tx.From, l.To)
+		if !ok || base.IsBurnAddress(md, to) {
+			return nil, xyerrors.NewInsError(-61, fmt.Sprintf("lock beneficiary address[%s] invalid", l.To))
+		}
+		l.To = to
+	}
+
+	if ok, _ := base.cache.Locks.Get(tx.Hash); ok {
+		return nil, xyerrors.NewInsError(-62, fmt.Sprintf("lock[%s] exists", tx.Hash))
+	}
+
+	// sender balance checking
+	ok, balance := base.cache.Balance.Get(protocol, tick, tx.From)
+	if !ok {
+		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, tx.From))
+	}
+
+	if balance.Available.LessThan(l.Amount) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < lock amount[%v]", balance.Available, l.Amount))
+	}
+	return l, nil
+}
+
+// parseTranches
+/***************************************
+ * build unlock tranches, a single tranche of time locks, heights must be after the current block
+ ***************************************/
+func (l *Lock) parseTranches(operate string, height uint64) *xyerrors.InsError {
+	items := []*VestTranche{{Height: l.Unlock, Amount: l.Amount}}
+	if operate == devents.OperateVest {
+		if len(l.Schedule) <= 0 || len(l.Schedule) > MaxVestTranches {
+			return xyerrors.NewInsError(-59, fmt.Sprintf("vesting tranches size[%d] out of range [1, %d]", len(l.Schedule), MaxVestTranches))
+		}
+		items = l.Schedule
+	}
+
+	maxUint64Decimal := decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)
+	last := decimal.NewFromBigInt(new(big.Int).SetUint64(height), 0)
+	total := decimal.Zero
+	l.tranches = make([]*model.LockTranche, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			return xyerrors.NewInsError(-59, "vesting tranche empty")
+		}
+
+		if item.Amount.LessThanOrEqual(decimal.Zero) {
+			return xyerrors.NewInsError(-14, "lock amount <= 0")
+		}
+
+		// ascending heights after the current block
+		if !item.Height.IsInteger() || item.Height.LessThanOrEqual(last) || item.Height.GreaterThan(maxUint64Decimal) {
+			return xyerrors.NewInsError(-60, fmt.Sprintf("invalid unlock height:%s, current[%s]", item.Height.String(), last.String()))
+		}
+		last = item.Height
+
+		total = total.Add(item.Amount)
+		l.tranches = append(l.tranches, &model.LockTranche{
+			Height: item.Height.BigInt().Uint64(),
+			Amount: item.Amount,
+		})
+	}
+
+	// amount of vesting is the sum of tranches if set
+	if !l.Amount.IsZero() && !l.Amount.Equal(total) {
+		return xyerrors.NewInsError(-59, fmt.Sprintf("lock amount[%s] != tranches total[%s]", l.Amount.String(), total.String()))
+	}
+	l.Amount = total
+	return nil
+}
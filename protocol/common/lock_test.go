@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"math/big"
+	"testing"
+)
+
+func TestLockVest(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		sender = "0x00000000000000000000000000000000000000a1"
+		alice  = "0x00000000000000000000000000000000000000b2"
+	)
+	release := func(height int64) []*devents.TxResult {
+		results := h.Handler.BuildUnlocks(model.ChainAVAX, &xycommon.RpcBlock{Number: big.NewInt(height)})
+		for _, r := range results {
+			h.Handler.UpdateCache(r)
+		}
+		return results
+	}
+	balanceOf := func(address string) (string, string) {
+		_, balance := h.Cache.Balance.Get("asc-20", "ash", address)
+		return balance.Available.String(), balance.Overall.String()
+	}
+
+	_, err := h.InscribeAt(1, sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"ash","max":"1000","lim":"100"}`)
+	assert.Nil(t, err)
+	_, err = h.InscribeAt(1, sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"ash","amt":"100"}`)
+	assert.Nil(t, err)
+
+	// unlock height must be after the current block
+	_, err = h.InscribeAt(5, sender, sender, `data:,{"p":"asc-20","op":"lock","tick":"ash","amt":"30","unlock":"5"}`)
+	assert.Equal(t, -60, testutil.CauseCode(err))
+
+	results, err := h.InscribeAt(5, sender, sender, `data:,{"p":"asc-20","op":"lock","tick":"ash","amt":"30","unlock":"10"}`)
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Transfer)
+	lockId := results[0].Lock.LockId
+	available, overall := balanceOf(sender)
+	assert.Equal(t, "70", available)
+	assert.Equal(t, "100", overall)
+
+	locks := h.Handler.BuildLock(results[0])
+	assert.Equal(t, `[{"height":10,"amt":"30"}]`, locks[devents.DBActionCreate].Schedule)
+	assert.Equal(t, uint64(10), locks[devents.DBActionCreate].UnlockHeight)
+
+	// locked amount is not transferable
+	_, err = h.InscribeAt(6, sender, sender, `data:,{"p":"asc-20","op":"transfer","tick":"ash","amt":"80"}`)
+	assert.Equal(t, -17, testutil.CauseCode(err))
+
+	// vesting schedules, ascending heights & amount of the tranches total
+	_, err = h.InscribeAt(6, sender, sender, `data:,{"p":"asc-20","op":"vest","tick":"ash","to":"`+alice+`","schedule":[{"height":"30","amt":"10"},{"height":"20","amt":"30"}]}`)
+	assert.Equal(t, -60, testutil.CauseCode(err))
+	_, err = h.InscribeAt(6, sender, sender, `data:,{"p":"asc-20","op":"vest","tick":"ash","amt":"50","schedule":[{"height":"20","amt":"10"},{"height":"30","amt":"30"}]}`)
+	assert.Equal(t, -59, testutil.CauseCode(err))
+	_, err = h.InscribeAt(6, sender, sender, `data:,{"p":"asc-20","op":"vest","tick":"ash","schedule":[]}`)
+	assert.Equal(t, -59, testutil.CauseCode(err))
+	_, err = h.InscribeAt(6, sender, sender, `data:,{"p":"asc-20","op":"vest","tick":"ash","to":"0x1234","schedule":[{"height":"20","amt":"10"}]}`)
+	assert.Equal(t, -61, testutil.CauseCode(err))
+
+	results, err = h.InscribeAt(6, sender, sender, `data:,{"p":"asc-20","op":"vest","tick":"ash","to":"`+alice+`","schedule":[{"height":"20","amt":"10"},{"height":"30","amt":"30"}]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "40", results[0].Transfer.Receives[0].Amount.String())
+	assert.Equal(t, "40", results[0].Lock.Amount.String())
+	available, overall = balanceOf(sender)
+	assert.Equal(t, "30", available)
+	assert.Equal(t, "60", overall)
+	available, overall = balanceOf(alice)
+	assert.Equal(t, "0", available)
+	assert.Equal(t, "40", overall)
+
+	// released by block height, before the txs of the block
+	assert.Len(t, release(9), 0)
+	results = release(10)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "30", results[0].Unlock.Amount.String())
+	available, _ = balanceOf(sender)
+	assert.Equal(t, "60", available)
+
+	dm := h.Handler.BuildModel(results[0])
+	assert.Nil(t, dm.Tx)
+	assert.Equal(t, "30", dm.Locks[devents.DBActionUpdate].Released.String())
+	assert.Equal(t, lockId, dm.BalanceTxs[0].TxHash)
+	assert.Equal(t, model.TransactionEventUnlock, dm.BalanceTxs[0].Event)
+	assert.Equal(t, "60", dm.Balances[devents.DBActionUpdate][0].Available.String())
+	ok, _ := h.Cache.Locks.Get(lockId)
+	assert.False(t, ok)
+
+	// due tranches of skipped heights are released together
+	results = release(25)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "10", results[0].Unlock.Amount.String())
+	assert.Len(t, release(26), 0)
+	results = release(40)
+	assert.Equal(t, "30", results[0].Unlock.Amount.String())
+	assert.Equal(t, "40", results[0].Unlock.Released.String())
+	available, overall = balanceOf(alice)
+	assert.Equal(t, "40", available)
+	assert.Equal(t, "40", overall)
+	assert.Len(t, release(50), 0)
+}
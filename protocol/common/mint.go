@@ -25,18 +25,43 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
 	"github.com/uxuycom/indexer/xyerrors"
+	"math/big"
+	"strings"
 )
 
+// NativeDecimals decimals of the native coin, tx value in wei
+const NativeDecimals = 18
+
+// MaxNonceLength max proof of work nonce length of mints
+const MaxNonceLength = 64
+
 type Mint struct {
 	Amount decimal.Decimal `json:"amt"`
+
+	// allowlist mint, proof of leaf keccak256(abi.encodePacked(from[, uint256 alloc * 10^dec]))
+	Proof []string        `json:"proof"`
+	Alloc decimal.Decimal `json:"alloc"` // optional allocation of the address
+
+	// Nonce proof of work nonce, used once per address
+	Nonce string `json:"nonce"`
+
+	minter  string          // credited address, the sender of paid & proof of work mints
+	claimer string          // allowlist address, the sender of allowlist mints
+	fee     decimal.Decimal // native coin charged, price * credited amount
 }
 
 func (base *Protocol) Mint(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
-	m, err := base.verifyMint(tx, md)
+	m, err := base.verifyMint(block, tx, md)
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
@@ -45,14 +70,26 @@ func (base *Protocol) Mint(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction
 		Block: block,
 		Tx:    tx,
 		Mint: &devents.Mint{
-			Minter: tx.To,
-			Amount: m.Amount,
+			Minter:  m.minter,
+			Amount:  m.Amount,
+			Fee:     m.fee,
+			Nonce:   m.Nonce,
+			Claimer: m.claimer,
 		},
 	}
 	return []*devents.TxResult{result}, nil
 }
 
-func (base *Protocol) verifyMint(tx *xycommon.RpcTransaction, md *devents.MetaData) (*Mint, *xyerrors.InsError) {
+func (base *Protocol) verifyMint(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*Mint, *xyerrors.InsError) {
+	// self inscription checking, paid mints are sent to the fee recipient & credited to the sender instead
+	if base.rulesOf(md).SelfMint && !strings.EqualFold(tx.From, tx.To) && !base.paidMint(md) {
+		return nil, xyerrors.NewInsError(-23, fmt.Sprintf("mint must be self inscription, from[%s], to[%s]", tx.From, tx.To))
+	}
+
+	if err := base.VerifyGrammar(md); err != nil {
+		return nil, err
+	}
+
 	mint := &Mint{}
 	err := json.Unmarshal([]byte(md.Data), mint)
 	if err != nil {
@@ -72,6 +109,19 @@ func (base *Protocol) verifyMint(tx *xycommon.RpcTransaction, md *devents.MetaDa
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s], tick[%s]", protocol, tick))
 	}
 
+	if err := base.VerifyPrecision(md, "amt", mint.Amount, inscription.Decimals); err != nil {
+		return nil, err
+	}
+	if err := base.VerifyPrecision(md, "alloc", mint.Alloc, inscription.Decimals); err != nil {
+		return nil, err
+	}
+
+	// paid mints are sent to the fee recipient, proof of work bound to the sender, both credited to the sender
+	mint.minter = tx.To
+	if inscription.Price.IsPositive() || inscription.Workc != "" {
+		mint.minter = tx.From
+	}
+
 	// mint amount maximum checking
 	if mint.Amount.GreaterThan(inscription.LimitPerMint) {
 		return nil, xyerrors.NewInsError(-17, "mint amount exceeds limit per mint")
@@ -87,10 +137,182 @@ func (base *Protocol) verifyMint(tx *xycommon.RpcTransaction, md *devents.MetaDa
 		return nil, xyerrors.NewInsError(-20, "mint completed")
 	}
 
+	// mint window checking
+	blockNum := block.Number.Uint64()
+	if inscription.StartBlock > 0 && blockNum < inscription.StartBlock {
+		return nil, xyerrors.NewInsError(-26, fmt.Sprintf("mint not started, block[%d] < start[%d]", blockNum, inscription.StartBlock))
+	}
+	if inscription.EndBlock > 0 && blockNum > inscription.EndBlock {
+		return nil, xyerrors.NewInsError(-27, fmt.Sprintf("mint ended, block[%d] > end[%d]", blockNum, inscription.EndBlock))
+	}
+
+	// mints per block checking
+	if inscription.BlockMintLimit > 0 && base.cache.InscriptionStats.MintsInBlock(protocol, tick, blockNum) >= inscription.BlockMintLimit {
+		return nil, xyerrors.NewInsError(-28, fmt.Sprintf("block[%d] mints exceeds limit[%d]", blockNum, inscription.BlockMintLimit))
+	}
+
 	// final mint = math.Min(Total Supply - Minted)
 	mintLeft := inscription.TotalSupply.Sub(stats.Minted)
 	if mint.Amount.GreaterThan(mintLeft) {
 		mint.Amount = mintLeft
 	}
+
+	// per wallet minted checking, final mint = math.Min(Wallet Limit - Wallet Minted)
+	if inscription.WalletLimit.GreaterThan(decimal.Zero) {
+		walletLeft := inscription.WalletLimit.Sub(base.cache.AddressMint.Minted(protocol, tick, mint.minter))
+		if walletLeft.LessThanOrEqual(decimal.Zero) {
+			return nil, xyerrors.NewInsError(-29, fmt.Sprintf("address[%s] mint amount reached wallet limit", mint.minter))
+		}
+
+		if mint.Amount.GreaterThan(walletLeft) {
+			mint.Amount = walletLeft
+		}
+	}
+
+	if inscription.MerkleRoot != "" {
+		if err := base.verifyAllowlist(tx, md, inscription, mint); err != nil {
+			return nil, err
+		}
+	}
+
+	if inscription.Price.IsPositive() {
+		if err := base.verifyPayment(tx, inscription, mint); err != nil {
+			return nil, err
+		}
+	}
+
+	mint.Nonce = strings.TrimSpace(mint.Nonce)
+	if inscription.Workc == "" {
+		mint.Nonce = ""
+		return mint, nil
+	}
+
+	if err := base.verifyWork(tx, md, inscription, mint); err != nil {
+		return nil, err
+	}
 	return mint, nil
 }
+
+// verifyWork
+/***************************************
+ * proof of work mint hash must meet the workc prefix of the tick,
+ * the nonce of the mint can't be reused by the same sender
+ ***************************************/
+func (base *Protocol) verifyWork(tx *xycommon.RpcTransaction, md *devents.MetaData, inscription *dcache.Tick, mint *Mint) *xyerrors.InsError {
+	if mint.Nonce == "" || len(mint.Nonce) > MaxNonceLength {
+		return xyerrors.NewInsError(-50, fmt.Sprintf("invalid proof of work nonce:%s", mint.Nonce))
+	}
+
+	if base.cache.AddressMint.NonceUsed(md.Protocol, md.Tick, tx.From, mint.Nonce) {
+		return xyerrors.NewInsError(-50, fmt.Sprintf("address[%s] nonce[%s] used", tx.From, mint.Nonce))
+	}
+
+	hash := tx.Hash
+	if base.rulesOf(md).PowHash == types.PowHashCalldata {
+		account, ok := accountAddress(tx.From)
+		if !ok {
+			return xyerrors.NewInsError(-51, fmt.Sprintf("proof of work sender[%s] invalid", tx.From))
+		}
+
+		calldata, err := hexutil.Decode(tx.Input)
+		if err != nil {
+			return xyerrors.NewInsError(-51, fmt.Sprintf("calldata decode err:%v", err))
+		}
+		hash = crypto.Keccak256Hash(account.Bytes(), calldata).Hex()
+	}
+
+	if !strings.HasPrefix(strings.ToLower(hash), inscription.Workc) {
+		return xyerrors.NewInsError(-51, fmt.Sprintf("hash[%s] does not meet workc[%s]", hash, inscription.Workc))
+	}
+	return nil
+}
+
+// paidMint checks the tick of metadata requires mint fee
+func (base *Protocol) paidMint(md *devents.MetaData) bool {
+	ok, inscription := base.cache.Inscription.Get(md.Protocol, md.Tick)
+	return ok && inscription.Price.IsPositive()
+}
+
+// verifyPayment
+/***************************************
+ * paid mints must send native coin to the fee recipient of the tick, checked after the amount clamped by supply & wallet limits
+ * underpaid mints are reduced to the amount paid for, rejected if nothing paid for
+ * the fee charged is price * credited amount, overpaid value is not counted as fee
+ ***************************************/
+func (base *Protocol) verifyPayment(tx *xycommon.RpcTransaction, inscription *dcache.Tick, mint *Mint) *xyerrors.InsError {
+	if !strings.EqualFold(tx.To, inscription.FeeTo) {
+		return xyerrors.NewInsError(-47, fmt.Sprintf("mint fee recipient[%s] mismatch, to[%s]", inscription.FeeTo, tx.To))
+	}
+
+	value := decimal.Zero
+	if tx.Value != nil {
+		value = decimal.NewFromBigInt(tx.Value, -NativeDecimals)
+	}
+
+	// paid amount = floor(value / price) in tick decimals
+	if value.LessThan(inscription.Price.Mul(mint.Amount)) {
+		paid, _ := value.Shift(int32(inscription.Decimals)).QuoRem(inscription.Price, 0)
+		mint.Amount = paid.Shift(-int32(inscription.Decimals))
+	}
+
+	if mint.Amount.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-48, fmt.Sprintf("mint fee[%s] insufficient, price[%s]", value.String(), inscription.Price.String()))
+	}
+	mint.fee = inscription.Price.Mul(mint.Amount)
+	return nil
+}
+
+// verifyAllowlist
+/***************************************
+ * sender must be included in the tick's merkle allowlist, the leaf is the root of single leaf trees
+ * the sender may credit the mint to another address, allocation claimed by the sender
+ * final mint = math.Min(Allocation - Claimed) if allocation provided
+ ***************************************/
+func (base *Protocol) verifyAllowlist(tx *xycommon.RpcTransaction, md *devents.MetaData, inscription *dcache.Tick, mint *Mint) *xyerrors.InsError {
+	account, ok := accountAddress(tx.From)
+	if !ok {
+		return xyerrors.NewInsError(-38, fmt.Sprintf("allowlist address[%s] invalid", tx.From))
+	}
+
+	proof := make([][]byte, 0, len(mint.Proof))
+	for _, item := range mint.Proof {
+		node, err := hexutil.Decode(item)
+		if err != nil || len(node) != 32 {
+			return xyerrors.NewInsError(-38, fmt.Sprintf("allowlist proof node[%s] invalid", item))
+		}
+		proof = append(proof, node)
+	}
+
+	var allocation *big.Int
+	if !mint.Alloc.IsZero() {
+		alloc := mint.Alloc.Shift(int32(inscription.Decimals))
+		if !alloc.IsInteger() || alloc.IsNegative() {
+			return xyerrors.NewInsError(-38, fmt.Sprintf("allowlist allocation[%s] invalid", mint.Alloc.String()))
+		}
+		allocation = alloc.BigInt()
+	}
+
+	leaf := utils.MerkleLeaf(account, allocation)
+	if !utils.VerifyMerkleProof(leaf, proof, ethcommon.FromHex(inscription.MerkleRoot)) {
+		if len(proof) <= 0 {
+			return xyerrors.NewInsError(-37, fmt.Sprintf("allowlist proof required, tick[%s-%s]", md.Protocol, md.Tick))
+		}
+		return xyerrors.NewInsError(-38, fmt.Sprintf("address[%s] not in allowlist, tick[%s-%s]", tx.From, md.Protocol, md.Tick))
+	}
+
+	// claimed amount tracked by sender
+	mint.claimer = tx.From
+	if allocation == nil {
+		return nil
+	}
+
+	allocLeft := mint.Alloc.Sub(base.cache.AddressMint.Claimed(md.Protocol, md.Tick, mint.claimer))
+	if allocLeft.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-39, fmt.Sprintf("address[%s] allowlist allocation claimed", mint.claimer))
+	}
+
+	if mint.Amount.GreaterThan(allocLeft) {
+		mint.Amount = allocLeft
+	}
+	return nil
+}
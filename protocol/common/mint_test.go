@@ -0,0 +1,229 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common_test
+
+import (
+	"bytes"
+	"fmt"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func TestMintConstraints(t *testing.T) {
+	h := testutil.NewHarness(t, &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}})
+
+	_, err := h.Inscribe("0x01", "0x01", `data:,{"p":"asc-20","op":"deploy","tick":"caps","max":"100","lim":"10","wlim":"5"}`)
+	assert.Equal(t, -24, testutil.CauseCode(err))
+	_, err = h.Inscribe("0x01", "0x01", `data:,{"p":"asc-20","op":"deploy","tick":"caps","max":"100","lim":"10","start":"20","end":"10"}`)
+	assert.Equal(t, -25, testutil.CauseCode(err))
+
+	_, err = h.Inscribe("0x01", "0x01", `data:,{"p":"asc-20","op":"deploy","tick":"caps","max":"100","lim":"10","wlim":"15","start":"10","end":"20","blim":"2"}`)
+	assert.Nil(t, err)
+
+	mint := `data:,{"p":"asc-20","op":"mint","tick":"caps","amt":"10"}`
+	_, err = h.InscribeAt(9, "0x01", "0x01", mint)
+	assert.Equal(t, -26, testutil.CauseCode(err))
+	_, err = h.InscribeAt(21, "0x01", "0x01", mint)
+	assert.Equal(t, -27, testutil.CauseCode(err))
+
+	// 2 mints per block
+	_, err = h.InscribeAt(10, "0x01", "0x01", mint)
+	assert.Nil(t, err)
+	_, err = h.InscribeAt(10, "0x02", "0x02", mint)
+	assert.Nil(t, err)
+	_, err = h.InscribeAt(10, "0x03", "0x03", mint)
+	assert.Equal(t, -28, testutil.CauseCode(err))
+
+	// wallet limit: the second mint is clamped to 5, the third rejected
+	_, err = h.InscribeAt(11, "0x01", "0x01", mint)
+	assert.Nil(t, err)
+	_, err = h.InscribeAt(12, "0x01", "0x01", mint)
+	assert.Equal(t, -29, testutil.CauseCode(err))
+
+	_, balance := h.Cache.Balance.Get("asc-20", "caps", "0x01")
+	assert.Equal(t, "15", balance.Overall.String())
+	_, item := h.Cache.AddressMint.Get("asc-20", "caps", "0x01")
+	assert.Equal(t, uint64(2), item.MintCnt)
+}
+
+func TestMerkleAllowlist(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		sender = "0x00000000000000000000000000000000000000a1"
+		alice  = "0x00000000000000000000000000000000000000b2"
+		bob    = "0x00000000000000000000000000000000000000c3"
+	)
+
+	// two leaves tree, allocations in the smallest unit (dec 18)
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	leafSender := utils.MerkleLeaf(ethcommon.HexToAddress(sender), new(big.Int).Mul(big.NewInt(150), unit))
+	leafAlice := utils.MerkleLeaf(ethcommon.HexToAddress(alice), new(big.Int).Mul(big.NewInt(50), unit))
+	pair := append(append([]byte{}, leafSender...), leafAlice...)
+	if bytes.Compare(leafSender, leafAlice) > 0 {
+		pair = append(append([]byte{}, leafAlice...), leafSender...)
+	}
+	root := hexutil.Encode(crypto.Keccak256(pair))
+	proofOf := func(leaf []byte) string {
+		return `"proof":["` + hexutil.Encode(leaf) + `"]`
+	}
+
+	_, err := h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"wl","max":"1000","lim":"100","dec":"18","root":"0x1234"}`)
+	assert.Equal(t, -36, testutil.CauseCode(err))
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"wl","max":"1000","lim":"100","dec":"18","root":"`+root+`"}`)
+	assert.Nil(t, err)
+
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100"}`)
+	assert.Equal(t, -37, testutil.CauseCode(err))
+	_, err = h.Inscribe(bob, bob, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"150",`+proofOf(leafAlice)+`}`)
+	assert.Equal(t, -38, testutil.CauseCode(err))
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"200",`+proofOf(leafAlice)+`}`)
+	assert.Equal(t, -38, testutil.CauseCode(err))
+
+	results, err := h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"150",`+proofOf(leafAlice)+`}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", results[0].Mint.Amount.String())
+
+	// clamped to the remaining allocation
+	results, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"150",`+proofOf(leafAlice)+`}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "50", results[0].Mint.Amount.String())
+
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"150",`+proofOf(leafAlice)+`}`)
+	assert.Equal(t, -39, testutil.CauseCode(err))
+
+	// leaf of the sender, not the credited address
+	_, err = h.Inscribe(bob, alice, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"50",`+proofOf(leafSender)+`}`)
+	assert.Equal(t, -38, testutil.CauseCode(err))
+
+	// allowlisted senders may credit another address, the allocation claimed by the sender
+	results, err = h.Inscribe(alice, bob, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"50",`+proofOf(leafSender)+`}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "50", results[0].Mint.Amount.String())
+	assert.Equal(t, bob, results[0].Mint.Minter)
+	assert.Equal(t, alice, results[0].Mint.Claimer)
+	_, err = h.Inscribe(alice, alice, `data:,{"p":"asc-20","op":"mint","tick":"wl","amt":"100","alloc":"50",`+proofOf(leafSender)+`}`)
+	assert.Equal(t, -39, testutil.CauseCode(err))
+
+	// single leaf tree, the leaf is the root & the proof empty
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"one","max":"1000","lim":"100","root":"`+hexutil.Encode(utils.MerkleLeaf(ethcommon.HexToAddress(bob), nil))+`"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"one","amt":"10"}`)
+	assert.Equal(t, -37, testutil.CauseCode(err))
+	results, err = h.Inscribe(bob, bob, `data:,{"p":"asc-20","op":"mint","tick":"one","amt":"10"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, bob, results[0].Mint.Claimer)
+
+	// proofs of ticks without allowlist are not tracked
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"open","max":"1000","lim":"100"}`)
+	assert.Nil(t, err)
+	results, err = h.Inscribe(bob, bob, `data:,{"p":"asc-20","op":"mint","tick":"open","amt":"10",`+proofOf(leafAlice)+`}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "", results[0].Mint.Claimer)
+}
+
+func TestProofOfWorkMint(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX, Protocols: []string{"asc-20", "ierc-20"}}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		miner = "0x00000000000000000000000000000000000000a1"
+		zero  = "0x0000000000000000000000000000000000000000"
+	)
+	mined := func(hash, to, data string) *xycommon.RpcTransaction {
+		tx := testutil.CalldataAt(1, miner, to, data)
+		tx.Hash = hash
+		return tx
+	}
+	hashOf := func(prefix string) string {
+		return prefix + strings.Repeat("1", 66-len(prefix))
+	}
+
+	_, _, err := h.Handle(mined(hashOf("0x"), miner, `data:,{"p":"ierc-20","op":"deploy","tick":"ethpi","max":"1000","lim":"100","workc":"0xzz"}`))
+	assert.Equal(t, -49, testutil.CauseCode(err))
+	_, _, err = h.Handle(mined(hashOf("0x"), miner, `data:,{"p":"ierc-20","op":"deploy","tick":"ethpi","max":"1000","lim":"100","workc":"0x00"}`))
+	assert.Nil(t, err)
+	_, tick := h.Cache.Inscription.Get(types.IERC20Protocol, "ethpi")
+	assert.Equal(t, "0x00", tick.Workc)
+
+	// difficulty ignored by protocols without proof of work mints
+	_, _, err = h.Handle(mined(hashOf("0x"), miner, `data:,{"p":"asc-20","op":"deploy","tick":"free","max":"1000","lim":"100","workc":"0x00"}`))
+	assert.Nil(t, err)
+	_, tick = h.Cache.Inscription.Get(types.ASC20Protocol, "free")
+	assert.Equal(t, "", tick.Workc)
+
+	// mints sent to the zero address credited to the sender
+	_, results, err := h.Handle(mined(hashOf("0x00ab"), zero, `data:,{"p":"ierc-20","op":"mint","tick":"ethpi","amt":"100","nonce":"7"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, miner, results[0].Mint.Minter)
+	assert.Equal(t, "7", results[0].Mint.Nonce)
+	assert.Len(t, h.Handler.BuildMintNonce(results[0]), 1)
+	_, balance := h.Cache.Balance.Get(types.IERC20Protocol, "ethpi", miner)
+	assert.Equal(t, "100", balance.Overall.String())
+
+	_, _, err = h.Handle(mined(hashOf("0x00cd"), zero, `data:,{"p":"ierc-20","op":"mint","tick":"ethpi","amt":"100","nonce":"7"}`))
+	assert.Equal(t, -50, testutil.CauseCode(err))
+	_, _, err = h.Handle(mined(hashOf("0x00cd"), zero, `data:,{"p":"ierc-20","op":"mint","tick":"ethpi","amt":"100"}`))
+	assert.Equal(t, -50, testutil.CauseCode(err))
+	_, _, err = h.Handle(mined(hashOf("0x01"), zero, `data:,{"p":"ierc-20","op":"mint","tick":"ethpi","amt":"100","nonce":"8"}`))
+	assert.Equal(t, -51, testutil.CauseCode(err))
+
+	// calldata hash bound to the sender
+	cfg.RuleProfiles = map[string]*config.ProtocolRules{"ierc-calldata": {MaxDecimals: 18, ContractCalldata: true, PowHash: types.PowHashCalldata}}
+	cfg.Chain.Rules = map[string]string{types.IERC20Protocol: "ierc-calldata"}
+	h.Reload(cfg)
+
+	mint := func(nonce int) string {
+		return fmt.Sprintf(`data:,{"p":"ierc-20","op":"mint","tick":"ethpi","amt":"100","nonce":"%d"}`, nonce)
+	}
+	nonce := 0
+	for ; ; nonce++ {
+		hash := crypto.Keccak256Hash(ethcommon.HexToAddress(miner).Bytes(), []byte(mint(nonce))).Hex()
+		if strings.HasPrefix(hash, "0x00") {
+			break
+		}
+	}
+	_, _, err = h.Handle(mined(hashOf("0x11"), zero, mint(nonce)))
+	assert.Nil(t, err)
+	_, _, err = h.Handle(mined(hashOf("0x00"), zero, mint(nonce+1)))
+	assert.Equal(t, -51, testutil.CauseCode(err))
+	assert.Equal(t, "200", balance.Overall.String())
+}
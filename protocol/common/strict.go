@@ -0,0 +1,88 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+)
+
+// strictAmountKeys numeric fields of inscription data, plain decimal strings required in strict mode
+var strictAmountKeys = map[string]bool{
+	"max":    true,
+	"lim":    true,
+	"dec":    true,
+	"amt":    true,
+	"wlim":   true,
+	"start":  true,
+	"end":    true,
+	"blim":   true,
+	"pre":    true,
+	"alloc":  true,
+	"price":  true,
+	"unlock": true,
+	"height": true,
+}
+
+// strictIntegerKeys integer fields of inscription data, plain integer strings required in strict mode
+var strictIntegerKeys = map[string]bool{
+	"dec":    true,
+	"start":  true,
+	"end":    true,
+	"blim":   true,
+	"unlock": true,
+	"height": true,
+}
+
+// VerifyGrammar
+/***************************************
+ * strict mode json & numeric grammar checking of inscription data, no checking in lenient mode
+ ***************************************/
+func (base *Protocol) VerifyGrammar(md *devents.MetaData) *xyerrors.InsError {
+	if !base.rulesOf(md).Strict {
+		return nil
+	}
+
+	if err := utils.VerifyStrictJSON([]byte(md.Data), strictAmountKeys, strictIntegerKeys); err != nil {
+		return xyerrors.NewInsError(-44, fmt.Sprintf("strict grammar err:%v, data[%s]", err, md.Data))
+	}
+	return nil
+}
+
+// VerifyPrecision
+/***************************************
+ * strict mode amount fractional digits must not exceed the tick decimals
+ ***************************************/
+func (base *Protocol) VerifyPrecision(md *devents.MetaData, field string, amount decimal.Decimal, decimals int8) *xyerrors.InsError {
+	if !base.rulesOf(md).Strict {
+		return nil
+	}
+
+	if -amount.Exponent() > int32(decimals) {
+		return xyerrors.NewInsError(-45, fmt.Sprintf("%s[%s] precision exceeds decimals[%d]", field, amount.String(), decimals))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"testing"
+)
+
+func TestStrictParsing(t *testing.T) {
+	cfg := &config.Config{
+		Chain: config.ChainConfig{
+			ChainName: model.ChainAVAX,
+			Forks: map[string][]*config.RuleForkConfig{
+				"asc-20": {{Height: 10, Profile: "strict"}},
+			},
+		},
+	}
+	h := testutil.NewHarness(t, cfg)
+
+	minter := "0x00000000000000000000000000000000000000a1"
+
+	// lenient decimals before the fork
+	_, err := h.InscribeAt(1, minter, minter, `data:,{"p":"asc-20","op":"deploy","tick":"lax","max":1e3,"lim":"+10","dec":"2"}`)
+	assert.Nil(t, err)
+	md, results, err := h.Handle(testutil.CalldataAt(2, minter, minter, `data:,{"p":"asc-20","op":"mint","tick":"lax","amt":1.001}`))
+	assert.Nil(t, err)
+	assert.Equal(t, model.ParseModeLenient, md.ParseMode())
+	txns, _ := h.Handler.BuildBalance(results[0])
+	assert.Equal(t, model.ParseModeLenient, txns[0].ParseMode)
+
+	// strict grammar from the fork height
+	cases := []string{
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":1000,"lim":"10","dec":"2"}`,
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"1e3","lim":"10","dec":"2"}`,
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"+1000","lim":"10","dec":"2"}`,
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":".5","lim":"10","dec":"2"}`,
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"1000","lim":"10","lim":"20","dec":"2"}`,
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"1000","lim":"10","Lim":"1e3","dec":"2"}`,
+		`data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"1000","lim":"10","dec":"18.5"}`,
+		`data:,{"p":"asc-20","op":"mint","tick":"lax","amt":"1","Amt":"1e3"}`,
+	}
+	for _, data := range cases {
+		_, err = h.InscribeAt(10, minter, minter, data)
+		assert.Equal(t, -44, testutil.CauseCode(err), data)
+	}
+	_, err = h.InscribeAt(10, minter, minter, `data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"1000.001","lim":"10","dec":"2"}`)
+	assert.Equal(t, -45, testutil.CauseCode(err))
+
+	_, err = h.InscribeAt(10, minter, minter, `data:,{"p":"asc-20","op":"deploy","tick":"tight","max":"1000","lim":"10","dec":"2"}`)
+	assert.Nil(t, err)
+	_, err = h.InscribeAt(11, minter, minter, `data:,{"p":"asc-20","op":"mint","tick":"tight","amt":"1.001"}`)
+	assert.Equal(t, -45, testutil.CauseCode(err))
+	md, results, err = h.Handle(testutil.CalldataAt(11, minter, minter, `data:,{"p":"asc-20","op":"mint","tick":"tight","amt":"1.01"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, model.ParseModeStrict, md.ParseMode())
+	txns, balances := h.Handler.BuildBalance(results[0])
+	assert.Equal(t, model.ParseModeStrict, txns[0].ParseMode)
+	assert.Equal(t, model.ParseModeStrict, balances[devents.DBActionCreate][0].ParseMode)
+
+	// batch receivers amounts checked as well
+	_, err = h.InscribeAt(12, minter, minter, `data:,{"p":"asc-20","op":"transfer","tick":"tight","to":[{"to":"0x00000000000000000000000000000000000000b2","amt":1}]}`)
+	assert.Equal(t, -44, testutil.CauseCode(err))
+	_, err = h.InscribeAt(12, minter, minter, `data:,{"p":"asc-20","op":"transfer","tick":"tight","to":[{"to":"0x00000000000000000000000000000000000000b2","amt":"0.011"}]}`)
+	assert.Equal(t, -45, testutil.CauseCode(err))
+	_, err = h.InscribeAt(12, minter, minter, `data:,{"p":"asc-20","op":"transfer","tick":"tight","to":[{"to":"0x00000000000000000000000000000000000000b2","amt":"0.01"}]}`)
+	assert.Nil(t, err)
+}
@@ -23,6 +23,7 @@
 package common
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/shopspring/decimal"
@@ -31,8 +32,22 @@ import (
 	"github.com/uxuycom/indexer/xyerrors"
 )
 
+// MaxBatchReceives maximum receivers of batch transfer
+const MaxBatchReceives = 1000
+
 type Transfer struct {
 	Amount decimal.Decimal `json:"amt"`
+
+	// To batch transfer receivers, [{"to":"0x..","amt":"1"}], or a single receiver address "0x.." overriding tx.To
+	// tx.To is the receiver if empty, other values are invalid
+	To json.RawMessage `json:"to"`
+
+	receives []*TransferReceive
+}
+
+type TransferReceive struct {
+	To     string          `json:"to"`
+	Amount decimal.Decimal `json:"amt"`
 }
 
 func (base *Protocol) Transfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
@@ -40,32 +55,66 @@ func (base *Protocol) Transfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransac
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
+
+	// amounts sent to burn addresses are burned
+	burned := decimal.Zero
+	receives := make([]*devents.Receive, 0, len(tf.receives))
+	for _, item := range tf.receives {
+		if base.IsBurnAddress(md, item.To) {
+			burned = burned.Add(item.Amount)
+			continue
+		}
+
+		receives = append(receives, &devents.Receive{
+			Address: item.To,
+			Amount:  item.Amount,
+		})
+	}
+
 	result := &devents.TxResult{
 		MD:    md,
 		Block: block,
 		Tx:    tx,
-		Transfer: &devents.Transfer{
+	}
+
+	if len(receives) > 0 {
+		result.Transfer = &devents.Transfer{
+			Sender:   tx.From,
+			Receives: receives,
+		}
+	}
+
+	if burned.GreaterThan(decimal.Zero) {
+		result.Burn = &devents.Burn{
 			Sender: tx.From,
-			Receives: []*devents.Receive{
-				{
-					Address: tx.To,
-					Amount:  tf.Amount,
-				},
-			},
-		},
+			Amount: burned,
+		}
 	}
 	return []*devents.TxResult{result}, nil
 }
 
 func (base *Protocol) verifyTransfer(tx *xycommon.RpcTransaction, md *devents.MetaData) (*Transfer, *xyerrors.InsError) {
+	if err := base.VerifyGrammar(md); err != nil {
+		return nil, err
+	}
+
 	tf := &Transfer{}
 	err := json.Unmarshal([]byte(md.Data), tf)
 	if err != nil {
 		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("data json deocde err:%v, data[%s]", err, md.Data))
 	}
 
-	if tf.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, xyerrors.NewInsError(-14, "transfer amount <= 0")
+	if err := tf.parseReceives(tx); err != nil {
+		return nil, err
+	}
+
+	// total amount of all receivers
+	total := decimal.Zero
+	for _, item := range tf.receives {
+		if item.Amount.LessThanOrEqual(decimal.Zero) {
+			return nil, xyerrors.NewInsError(-14, "transfer amount <= 0")
+		}
+		total = total.Add(item.Amount)
 	}
 
 	var (
@@ -77,15 +126,75 @@ func (base *Protocol) verifyTransfer(tx *xycommon.RpcTransaction, md *devents.Me
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
 	}
 
+	for _, item := range tf.receives {
+		if err := base.VerifyPrecision(md, "amt", item.Amount, inscription.Decimals); err != nil {
+			return nil, err
+		}
+	}
+
 	// sender balance checking
 	ok, balance := base.cache.Balance.Get(protocol, tick, tx.From)
 	if !ok {
 		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, tx.From))
 	}
 
-	// balance available checking
-	if balance.Overall.LessThan(tf.Amount) {
-		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender total balance[%v] < transfer amount[%v]", balance.Overall, tf.Amount))
+	// balance available checking, all or nothing for batch transfer
+	if balance.Available.LessThan(total) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < transfer amount[%v]", balance.Available, total))
 	}
 	return tf, nil
 }
+
+// parseReceives
+/***************************************
+ * build receivers from batch list or single receiver address, tx.To if empty
+ * a single receiver address overrides tx.To, of the amount of the transfer
+ ***************************************/
+func (tf *Transfer) parseReceives(tx *xycommon.RpcTransaction) *xyerrors.InsError {
+	raw := bytes.TrimSpace(tf.To)
+	if len(raw) <= 0 || string(raw) == "null" || string(raw) == `""` {
+		tf.receives = []*TransferReceive{{To: tx.To, Amount: tf.Amount}}
+		return nil
+	}
+
+	switch raw[0] {
+	case '"':
+		receive := &TransferReceive{Amount: tf.Amount}
+		if err := json.Unmarshal(raw, &receive.To); err != nil {
+			return xyerrors.NewInsError(-13, fmt.Sprintf("receiver json decode err:%v", err))
+		}
+		to, ok := normalizeReceiver(tx.From, receive.To)
+		if !ok {
+			return xyerrors.NewInsError(-18, fmt.Sprintf("receiver address[%s] invalid", receive.To))
+		}
+		receive.To = to
+		tf.receives = []*TransferReceive{receive}
+		return nil
+	case '[':
+	default:
+		return xyerrors.NewInsError(-18, fmt.Sprintf("receivers[%s] must be an address or a list", string(raw)))
+	}
+
+	items := make([]*TransferReceive, 0)
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return xyerrors.NewInsError(-13, fmt.Sprintf("batch receivers json decode err:%v", err))
+	}
+
+	if len(items) <= 0 || len(items) > MaxBatchReceives {
+		return xyerrors.NewInsError(-18, fmt.Sprintf("batch receivers size[%d] out of range [1, %d]", len(items), MaxBatchReceives))
+	}
+
+	for _, item := range items {
+		if item == nil {
+			return xyerrors.NewInsError(-18, "batch receiver empty")
+		}
+
+		to, ok := normalizeReceiver(tx.From, item.To)
+		if !ok {
+			return xyerrors.NewInsError(-18, fmt.Sprintf("batch receiver address[%s] invalid", item.To))
+		}
+		item.To = to
+	}
+	tf.receives = items
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"testing"
+)
+
+func TestBatchTransfer(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		sender = "0x00000000000000000000000000000000000000A1"
+		alice  = "0x00000000000000000000000000000000000000b2"
+		bob    = "0x00000000000000000000000000000000000000C3"
+	)
+
+	_, err := h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"deploy","tick":"drop","max":"1000","lim":"100"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"mint","tick":"drop","amt":"100"}`)
+	assert.Nil(t, err)
+
+	// total 110 > 100, nothing transferred
+	_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"transfer","tick":"drop","to":[{"to":"`+alice+`","amt":"60"},{"to":"`+bob+`","amt":"50"}]}`)
+	assert.Equal(t, -17, testutil.CauseCode(err))
+
+	// malformed receivers
+	for _, to := range []string{`[{"to":"0x01","amt":"1"}]`, `[{"to":"alice","amt":"1"}]`, `"alice"`, `123`, `{"to":"` + alice + `"}`} {
+		_, err = h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"transfer","tick":"drop","amt":"1","to":`+to+`}`)
+		assert.Equal(t, -18, testutil.CauseCode(err), to)
+	}
+
+	results, err := h.Inscribe(sender, sender, `data:,{"p":"asc-20","op":"transfer","tick":"drop","to":[{"to":"`+alice+`","amt":"60"},{"to":"`+bob+`","amt":"30"}]}`)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Len(t, results[0].Transfer.Receives, 2)
+
+	for addr, amount := range map[string]string{sender: "10", alice: "60", bob: "30"} {
+		_, balance := h.Cache.Balance.Get("asc-20", "drop", addr)
+		assert.Equal(t, amount, balance.Overall.String())
+	}
+	_, stats := h.Cache.InscriptionStats.Get("asc-20", "drop")
+	assert.Equal(t, int64(3), stats.Holders)
+
+	// plain transfer still goes to tx.To
+	results, err = h.Inscribe(alice, bob, `data:,{"p":"asc-20","op":"transfer","tick":"drop","amt":"10"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, bob, results[0].Transfer.Receives[0].Address)
+
+	// empty receivers go to tx.To
+	results, err = h.Inscribe(alice, bob, `data:,{"p":"asc-20","op":"transfer","tick":"drop","amt":"10","to":""}`)
+	assert.Nil(t, err)
+	assert.Equal(t, bob, results[0].Transfer.Receives[0].Address)
+
+	// a single receiver address overrides tx.To
+	results, err = h.Inscribe(alice, sender, `data:,{"p":"asc-20","op":"transfer","tick":"drop","amt":"10","to":"`+bob+`"}`)
+	assert.Nil(t, err)
+	assert.Len(t, results[0].Transfer.Receives, 1)
+	assert.Equal(t, bob, results[0].Transfer.Receives[0].Address)
+	for addr, amount := range map[string]string{sender: "10", alice: "30", bob: "60"} {
+		_, balance := h.Cache.Balance.Get("asc-20", "drop", addr)
+		assert.Equal(t, amount, balance.Overall.String())
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package protocol_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// conformanceVectors vector files of the conformance suite
+const conformanceVectors = "testdata/conformance/*.json"
+
+// conformanceVector
+/***************************************
+ * synthetic txs run in order against a fresh cache, with the expected
+ * results / rejection codes of every tx and the final balances & stats
+ ***************************************/
+type conformanceVector struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Config      config.Config    `json:"config"`
+	Txs         []*vectorTx      `json:"txs"`
+	Balances    []*vectorBalance `json:"balances"`
+	Stats       []*vectorStats   `json:"stats"`
+}
+
+type vectorTx struct {
+	Block      uint64            `json:"block"`
+	Hash       string            `json:"hash"` // 0x + index of the tx by default
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Data       string            `json:"data"`  // utf-8 calldata, hex encoded as input
+	Input      string            `json:"input"` // raw hex input, used if data empty
+	Value      string            `json:"value"` // native coin value in wei
+	ToContract bool              `json:"to_contract"`
+	Events     []xycommon.RpcLog `json:"events"`
+
+	Ignored bool            `json:"ignored"` // not an inscription of enabled protocols
+	Code    int             `json:"code"`    // rejection code, 0: accepted
+	Results []*vectorResult `json:"results"` // unchecked if absent
+}
+
+type vectorResult struct {
+	Protocol     string              `json:"protocol"`
+	Operate      string              `json:"op"`
+	Tick         string              `json:"tick"`
+	Deploy       *vectorDeploy       `json:"deploy,omitempty"`
+	Mint         *vectorMint         `json:"mint,omitempty"`
+	Transfer     *vectorTransfer     `json:"transfer,omitempty"`
+	Burn         *vectorBurn         `json:"burn,omitempty"`
+	Listing      *vectorListing      `json:"listing,omitempty"`
+	Ethscription *vectorEthscription `json:"ethscription,omitempty"`
+}
+
+type vectorDeploy struct {
+	Max     string `json:"max"`
+	Lim     string `json:"lim"`
+	Dec     int8   `json:"dec"`
+	Premine string `json:"pre"`
+}
+
+type vectorMint struct {
+	Minter string `json:"minter"`
+	Amount string `json:"amt"`
+	Fee    string `json:"fee"`
+}
+
+type vectorTransfer struct {
+	Sender   string           `json:"sender"`
+	Receives []*vectorReceive `json:"receives"`
+}
+
+type vectorReceive struct {
+	Address string `json:"address"`
+	Amount  string `json:"amt"`
+}
+
+type vectorBurn struct {
+	Sender string `json:"sender"`
+	Amount string `json:"amt"`
+}
+
+type vectorListing struct {
+	Seller string `json:"seller"`
+	Buyer  string `json:"buyer"`
+	Amount string `json:"amt"`
+	Status int8   `json:"status"`
+}
+
+type vectorEthscription struct {
+	Id          string `json:"id"`
+	Creator     string `json:"creator"`
+	Owner       string `json:"owner"`
+	Previous    string `json:"previous"`
+	ContentType string `json:"content_type"`
+}
+
+type vectorBalance struct {
+	Protocol  string `json:"protocol"`
+	Tick      string `json:"tick"`
+	Address   string `json:"address"`
+	Overall   string `json:"overall"`
+	Available string `json:"available"` // overall if empty
+}
+
+type vectorStats struct {
+	Protocol string `json:"protocol"`
+	Tick     string `json:"tick"`
+	Minted   string `json:"minted"`
+	Burned   string `json:"burned"`
+	Fees     string `json:"fees"`
+	Holders  int64  `json:"holders"`
+	TxCnt    uint64 `json:"tx_cnt"` // unchecked if 0
+}
+
+// amountOf normalized decimal string of the vector amount, empty as zero
+func amountOf(s string) string {
+	if s == "" {
+		return decimal.Zero.String()
+	}
+	return decimal.RequireFromString(s).String()
+}
+
+func (r *vectorResult) normalize() *vectorResult {
+	if r.Deploy != nil {
+		r.Deploy.Max, r.Deploy.Lim, r.Deploy.Premine = amountOf(r.Deploy.Max), amountOf(r.Deploy.Lim), amountOf(r.Deploy.Premine)
+	}
+	if r.Mint != nil {
+		r.Mint.Amount, r.Mint.Fee = amountOf(r.Mint.Amount), amountOf(r.Mint.Fee)
+	}
+	if r.Transfer != nil {
+		for _, item := range r.Transfer.Receives {
+			item.Amount = amountOf(item.Amount)
+		}
+	}
+	if r.Burn != nil {
+		r.Burn.Amount = amountOf(r.Burn.Amount)
+	}
+	if r.Listing != nil {
+		r.Listing.Amount = amountOf(r.Listing.Amount)
+	}
+	return r
+}
+
+// newVectorResult summary of the tx result in vector form
+func newVectorResult(r *devents.TxResult) *vectorResult {
+	ret := &vectorResult{
+		Protocol: r.MD.Protocol,
+		Operate:  r.MD.Operate,
+		Tick:     r.MD.Tick,
+	}
+	if r.Deploy != nil {
+		ret.Deploy = &vectorDeploy{
+			Max:     r.Deploy.MaxSupply.String(),
+			Lim:     r.Deploy.MintLimit.String(),
+			Dec:     r.Deploy.Decimal,
+			Premine: r.Deploy.Premine.String(),
+		}
+	}
+	if r.Mint != nil {
+		ret.Mint = &vectorMint{Minter: r.Mint.Minter, Amount: r.Mint.Amount.String(), Fee: r.Mint.Fee.String()}
+	}
+	if r.Transfer != nil {
+		ret.Transfer = &vectorTransfer{Sender: r.Transfer.Sender}
+		for _, item := range r.Transfer.Receives {
+			ret.Transfer.Receives = append(ret.Transfer.Receives, &vectorReceive{Address: item.Address, Amount: item.Amount.String()})
+		}
+	}
+	if r.Burn != nil {
+		ret.Burn = &vectorBurn{Sender: r.Burn.Sender, Amount: r.Burn.Amount.String()}
+	}
+	if r.Listing != nil {
+		ret.Listing = &vectorListing{Seller: r.Listing.Seller, Buyer: r.Listing.Buyer, Amount: r.Listing.Amount.String(), Status: r.Listing.Status}
+	}
+	if r.Ethscription != nil {
+		ret.Ethscription = &vectorEthscription{
+			Id:          r.Ethscription.Id,
+			Creator:     r.Ethscription.Creator,
+			Owner:       r.Ethscription.Owner,
+			Previous:    r.Ethscription.Previous,
+			ContentType: r.Ethscription.ContentType,
+		}
+	}
+	return ret.normalize()
+}
+
+func loadConformanceVectors(t *testing.T) []*conformanceVector {
+	files, err := filepath.Glob(conformanceVectors)
+	if err != nil || len(files) <= 0 {
+		t.Fatalf("conformance vectors[%s] not found, err:%v", conformanceVectors, err)
+	}
+
+	vectors := make([]*conformanceVector, 0, len(files))
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("vector file[%s] read err:%v", file, err)
+		}
+
+		vector := &conformanceVector{}
+		if err = json.Unmarshal(raw, vector); err != nil {
+			t.Fatalf("vector file[%s] decode err:%v", file, err)
+		}
+		if vector.Name == "" {
+			vector.Name = filepath.Base(file)
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors
+}
+
+// TestConformance
+/***************************************
+ * run vectors through metadata parsing, protocols & result handler,
+ * rule changes must be reviewed against the vectors
+ ***************************************/
+func TestConformance(t *testing.T) {
+	defer protocol.InitProtocols(&config.Config{}, dcache.NewManager(nil, ""))
+
+	for _, vector := range loadConformanceVectors(t) {
+		t.Run(vector.Name, func(t *testing.T) {
+			runConformanceVector(t, vector)
+		})
+	}
+}
+
+func runConformanceVector(t *testing.T, vector *conformanceVector) {
+	cfg := &vector.Config
+	chain := cfg.Chain.ChainName
+	cache := testutil.NewCache(chain)
+	protocol.InitProtocols(cfg, cache)
+	handler := devents.NewTxResultHandler(cache)
+
+	for i, vt := range vector.Txs {
+		step := fmt.Sprintf("tx #%d %s", i, vt.Data)
+
+		tx := &xycommon.RpcTransaction{
+			BlockNumber: new(big.Int).SetUint64(vt.Block),
+			TxIndex:     big.NewInt(int64(i)),
+			Hash:        vt.Hash,
+			From:        vt.From,
+			To:          vt.To,
+			Input:       vt.Input,
+			Gas:         big.NewInt(0),
+			GasPrice:    big.NewInt(0),
+			Events:      vt.Events,
+			ToContract:  vt.ToContract,
+		}
+		if tx.Hash == "" {
+			tx.Hash = fmt.Sprintf("0x%064x", i+1)
+		}
+		if vt.Data != "" {
+			tx.Input = testutil.InputOf(vt.Data)
+		}
+		if vt.Value != "" {
+			tx.Value, _ = new(big.Int).SetString(vt.Value, 10)
+		}
+		block := &xycommon.RpcBlock{Number: tx.BlockNumber, Time: vt.Block}
+
+		md, _ := protocol.ParseMetaData(chain, tx)
+		pt, pmd := protocol.GetProtocol(cfg, tx)
+		if vt.Ignored {
+			assert.True(t, md == nil || pt == nil, step)
+			continue
+		}
+		if !assert.NotNil(t, md, step) || !assert.NotNil(t, pt, step) {
+			return
+		}
+
+		results, err := pt.Parse(block, tx, pmd)
+		code := 0
+		if err != nil {
+			code = testutil.CauseCode(err)
+		}
+		assert.Equal(t, vt.Code, code, "%s, err:%v", step, err)
+
+		actual := make([]*vectorResult, 0, len(results))
+		for _, r := range results {
+			handler.UpdateCache(r)
+			handler.BuildModel(r)
+			actual = append(actual, newVectorResult(r))
+		}
+
+		if vt.Results != nil {
+			for _, r := range vt.Results {
+				r.normalize()
+			}
+			assert.Equal(t, vt.Results, actual, step)
+		}
+	}
+
+	for _, b := range vector.Balances {
+		overall, available := decimal.Zero, decimal.Zero
+		if ok, balance := cache.Balance.Get(b.Protocol, b.Tick, b.Address); ok {
+			overall, available = balance.Overall, balance.Available
+		}
+
+		if b.Available == "" {
+			b.Available = b.Overall
+		}
+		assert.Equal(t, amountOf(b.Overall), overall.String(), "balance overall %s-%s %s", b.Protocol, b.Tick, b.Address)
+		assert.Equal(t, amountOf(b.Available), available.String(), "balance available %s-%s %s", b.Protocol, b.Tick, b.Address)
+	}
+
+	for _, s := range vector.Stats {
+		ok, stats := cache.InscriptionStats.Get(s.Protocol, s.Tick)
+		if !assert.True(t, ok, "stats %s-%s", s.Protocol, s.Tick) {
+			continue
+		}
+
+		assert.Equal(t, amountOf(s.Minted), stats.Minted.String(), "stats minted %s-%s", s.Protocol, s.Tick)
+		assert.Equal(t, amountOf(s.Burned), stats.Burned.String(), "stats burned %s-%s", s.Protocol, s.Tick)
+		assert.Equal(t, amountOf(s.Fees), stats.Fees.String(), "stats fees %s-%s", s.Protocol, s.Tick)
+		assert.Equal(t, s.Holders, stats.Holders, "stats holders %s-%s", s.Protocol, s.Tick)
+		if s.TxCnt > 0 {
+			assert.Equal(t, s.TxCnt, stats.TxCnt, "stats tx_cnt %s-%s", s.Protocol, s.Tick)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cia20_test
+
+import (
+	"bytes"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func bech32Address(t *testing.T, hrp string, b byte) string {
+	data, err := bech32.ConvertBits(bytes.Repeat([]byte{b}, 20), 8, 5, true)
+	assert.Nil(t, err)
+	address, err := bech32.Encode(hrp, data)
+	assert.Nil(t, err)
+	return address
+}
+
+// memoTx cosmos tx of the memo from -> to
+func memoTx(from, to, memo string) *xycommon.RpcTransaction {
+	return &xycommon.RpcTransaction{From: from, To: to, Memo: memo, Value: big.NewInt(0)}
+}
+
+func TestCosmosMemoInscriptions(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainCelestia, ChainGroup: model.CosmosChainGroup}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		alice = bech32Address(t, "celestia", 0xa1)
+		bob   = bech32Address(t, "celestia", 0xb2)
+		other = bech32Address(t, "cosmos", 0xc3)
+	)
+
+	// calldata is not read on cosmos chains
+	_, md := protocol.GetProtocol(cfg, &xycommon.RpcTransaction{Input: testutil.InputOf(`data:,{"p":"cia-20","op":"mint","tick":"cias","amt":"1"}`)})
+	assert.Nil(t, md)
+
+	_, _, err := h.Handle(memoTx(alice, alice, `data:,{"p":"cia-20","op":"deploy","tick":"cias","max":"1000","lim":"100"}`))
+	assert.Nil(t, err)
+
+	// mints are self sends
+	_, _, err = h.Handle(memoTx(alice, bob, `data:,{"p":"cia-20","op":"mint","tick":"cias","amt":"100"}`))
+	assert.Equal(t, -23, testutil.CauseCode(err))
+	_, _, err = h.Handle(memoTx(alice, alice, ` data:,{"p":"cia-20","op":"mint","tick":"cias","amt":"100"}`))
+	assert.Nil(t, err)
+
+	_, results, err := h.Handle(memoTx(alice, bob, `data:,{"p":"cia-20","op":"transfer","tick":"cias","amt":"10"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, bob, results[0].Transfer.Receives[0].Address)
+
+	// batch receivers share the bech32 prefix of the sender, normalized to lower case
+	_, _, err = h.Handle(memoTx(alice, alice, `data:,{"p":"cia-20","op":"transfer","tick":"cias","to":[{"to":"`+other+`","amt":"1"}]}`))
+	assert.Equal(t, -18, testutil.CauseCode(err))
+	_, _, err = h.Handle(memoTx(alice, alice, `data:,{"p":"cia-20","op":"transfer","tick":"cias","to":[{"to":"celestia1invalid","amt":"1"}]}`))
+	assert.Equal(t, -18, testutil.CauseCode(err))
+	_, results, err = h.Handle(memoTx(alice, alice, `data:,{"p":"cia-20","op":"transfer","tick":"cias","to":[{"to":"`+strings.ToUpper(bob)+`","amt":"20"}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, bob, results[0].Transfer.Receives[0].Address)
+
+	for addr, amount := range map[string]string{alice: "70", bob: "30"} {
+		_, balance := h.Cache.Balance.Get(types.CIA20Protocol, "cias", addr)
+		assert.Equal(t, amount, balance.Overall.String())
+	}
+
+	// other memo protocol ids handled by the cosmos fallback
+	_, _, err = h.Handle(memoTx(bob, bob, `data:,{"p":"inj-20","op":"deploy","tick":"ninj","max":"1000","lim":"100"}`))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package cia20
+
+import (
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
+	"strings"
+)
+
+func init() {
+	// memo inscriptions of other cosmos protocol ids share the implementation
+	types.Register(&types.Registration{
+		ChainGroup: model.CosmosChainGroup,
+		Protocol:   types.CIA20Protocol,
+		Fallback:   true,
+		Operates:   []string{devents.OperateDeploy, devents.OperateMint, devents.OperateTransfer, devents.OperateBurn, devents.OperateLock, devents.OperateVest},
+		FastCheck:  FastCheckMemo,
+		New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+			return NewProtocol(cache, rules)
+		},
+	})
+}
+
+type Protocol struct {
+	*common.Protocol
+}
+
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
+	return &Protocol{
+		Protocol: common.NewProtocol(cache, rules),
+	}
+}
+
+// FastCheckMemo memo data uri prefix checking
+func FastCheckMemo(tx *xycommon.RpcTransaction) bool {
+	return strings.HasPrefix(strings.TrimSpace(tx.Memo), "data:")
+}
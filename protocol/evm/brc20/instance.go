@@ -24,15 +24,36 @@ package brc20
 
 import (
 	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
 )
 
+func init() {
+	// brc-20 forks share the implementation & differ in rule profiles
+	// forks are opt-in per chain, handled by the brc-20 fallback with its rules if not enabled
+	for _, id := range []string{types.BRC20Protocol, types.BSC20Protocol, types.PRC20Protocol, types.IERC20Protocol} {
+		types.Register(&types.Registration{
+			ChainGroup: model.EvmChainGroup,
+			Protocol:   id,
+			Fallback:   id == types.BRC20Protocol,
+			OptIn:      id != types.BRC20Protocol,
+			Operates:   []string{devents.OperateDeploy, devents.OperateMint, devents.OperateTransfer, devents.OperateBurn, devents.OperateExchange, devents.OperateWrap, devents.OperateLock, devents.OperateVest},
+			FastCheck:  common.FastCheckDataPrefix,
+			New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+				return NewProtocol(cache, rules)
+			},
+		})
+	}
+}
+
 type Protocol struct {
 	*common.Protocol
 }
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
 	return &Protocol{
-		Protocol: common.NewProtocol(cache),
+		Protocol: common.NewProtocol(cache, rules),
 	}
 }
@@ -0,0 +1,112 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscription_test
+
+import (
+	"encoding/base64"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/xylog"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func TestEthscriptions(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: "eth", Protocols: []string{"brc-20", "ethscriptions"}}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		alice = "0x00000000000000000000000000000000000000a1"
+		bob   = "0x00000000000000000000000000000000000000b2"
+		carol = "0x00000000000000000000000000000000000000c3"
+	)
+
+	svg := `data:image/svg+xml;base64,` + base64.StdEncoding.EncodeToString([]byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`))
+	md, results, err := h.Handle(testutil.CalldataAt(1, alice, bob, svg))
+	assert.Nil(t, err)
+	assert.Equal(t, "ethscriptions", md.Protocol)
+	assert.Equal(t, devents.OperateCreate, md.Operate)
+	e := results[0].Ethscription
+	assert.Equal(t, "image/svg+xml", e.ContentType)
+	assert.Equal(t, `<svg xmlns="http://www.w3.org/2000/svg"/>`, string(e.Content))
+	svgId := e.Id
+	ok, item := h.Cache.Ethscription.Get(svgId)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), item.SID)
+	assert.Equal(t, alice, item.Creator)
+	assert.Equal(t, bob, item.Owner)
+
+	// duplicate contents, esip6 allowed
+	_, err = h.Inscribe(carol, carol, svg)
+	assert.Equal(t, -41, testutil.CauseCode(err))
+	results, err = h.Inscribe(carol, carol, "data:text/plain;rule=esip6,gm")
+	assert.Nil(t, err)
+	textId := results[0].Ethscription.Id
+	results, err = h.Inscribe(carol, carol, "data:text/plain;rule=esip6,gm")
+	assert.Nil(t, err)
+	assert.True(t, results[0].Ethscription.Esip6)
+
+	// fungible inscriptions share the sequence numbers & are ethscriptions as well
+	md, results, err = h.Handle(testutil.CalldataAt(1, alice, alice, `data:,{"p":"brc-20","op":"deploy","tick":"eths","max":"100","lim":"1"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "brc-20", md.Protocol)
+	assert.Len(t, results, 2)
+	assert.Equal(t, devents.OperateDeploy, results[0].MD.Operate)
+	assert.Equal(t, "ethscriptions", results[1].MD.Protocol)
+	assert.Equal(t, devents.OperateCreate, results[1].MD.Operate)
+	tokenId := results[1].Ethscription.Id
+	_, item = h.Cache.Ethscription.Get(tokenId)
+	assert.Equal(t, uint64(4), item.SID)
+	_, stats := h.Cache.InscriptionStats.Get("brc-20", "eths")
+	assert.Equal(t, uint64(4), stats.LastSN)
+	assert.Equal(t, uint64(4), h.Cache.Ethscription.LastSN())
+
+	// transfers by id, only owned ethscriptions move
+	_, _, err = h.Handle(&xycommon.RpcTransaction{From: alice, To: carol, Input: svgId})
+	assert.Equal(t, -42, testutil.CauseCode(err))
+	md, results, err = h.Handle(&xycommon.RpcTransaction{From: bob, To: carol, Input: svgId})
+	assert.Nil(t, err)
+	assert.Equal(t, devents.OperateTransfer, md.Operate)
+	assert.Equal(t, bob, results[0].Ethscription.Previous)
+	_, item = h.Cache.Ethscription.Get(svgId)
+	assert.Equal(t, carol, item.Owner)
+
+	// ESIP-5 bulk transfer
+	_, results, err = h.Handle(&xycommon.RpcTransaction{From: carol, To: alice, Input: svgId + textId[2:]})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	_, item = h.Cache.Ethscription.Get(textId)
+	assert.Equal(t, alice, item.Owner)
+
+	// opt-in protocol
+	h.Reload(&config.Config{Chain: config.ChainConfig{ChainName: "eth"}})
+	md, _, _ = h.Handle(testutil.CalldataAt(1, alice, bob, "data:text/plain,hello"))
+	assert.Nil(t, md)
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscription
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"strings"
+)
+
+const (
+	// IdSize ethscription id, the creation tx hash
+	IdSize = 32
+
+	// RuleESIP6 data uri parameter allows duplicate contents
+	RuleESIP6 = "esip6"
+
+	// DefaultContentType media type of data uri without one, RFC 2397
+	DefaultContentType = "text/plain"
+)
+
+func init() {
+	types.Register(&types.Registration{
+		ChainGroup: model.EvmChainGroup,
+		Protocol:   types.EthscriptionsProtocol,
+		OptIn:      true,
+		Operates:   []string{devents.OperateCreate, devents.OperateTransfer},
+		FastCheck:  FastCheck,
+		New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+			return NewProtocol(cache, rules)
+		},
+	})
+}
+
+type Protocol struct {
+	common *common.Protocol
+	cache  *dcache.Manager
+}
+
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
+	return &Protocol{
+		common: common.NewProtocol(cache, rules),
+		cache:  cache,
+	}
+}
+
+func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	p.common.ResolveRules(block, md)
+	switch md.Operate {
+	case devents.OperateCreate:
+		return p.Create(block, tx, md)
+	case devents.OperateTransfer:
+		return p.Transfer(block, tx, md)
+	}
+	return nil, nil
+}
+
+// Create
+/***************************************
+ * ethscribe data uri, creator is the sender & initial owner is the recipient
+ * duplicate contents are invalid unless the data uri declares rule=esip6
+ ***************************************/
+func (p *Protocol) Create(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	uri, err := utils.ParseDataURI(md.Data, 0)
+	if err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(xyerrors.NewInsError(-13, fmt.Sprintf("data uri decode err:%v", err)))
+	}
+
+	contentHash := ContentHash(md.Data)
+	esip6 := strings.EqualFold(uri.Param("rule"), RuleESIP6)
+	if !esip6 && p.cache.Ethscription.ContentExists(contentHash) {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(xyerrors.NewInsError(-41, fmt.Sprintf("duplicate content[%s]", contentHash)))
+	}
+
+	contentType := uri.MediaType
+	if contentType == "" {
+		contentType = DefaultContentType
+	}
+
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Ethscription: &devents.Ethscription{
+			Id:          strings.ToLower(tx.Hash),
+			Creator:     tx.From,
+			Owner:       tx.To,
+			ContentType: contentType,
+			ContentHash: contentHash,
+			Content:     uri.Data,
+			Esip6:       esip6,
+		},
+	}
+	return []*devents.TxResult{result}, nil
+}
+
+// Transfer
+/***************************************
+ * transfer ethscriptions by ids in calldata, ESIP-5 bulk transfers
+ * ids not owned by the sender are ignored
+ ***************************************/
+func (p *Protocol) Transfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	ids := ParseIds(md.Data)
+	items := make([]*devents.TxResult, 0, len(ids))
+	transferred := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := transferred[id]; ok {
+			continue
+		}
+
+		ok, item := p.cache.Ethscription.Get(id)
+		if !ok || !strings.EqualFold(item.Owner, tx.From) {
+			continue
+		}
+		transferred[id] = struct{}{}
+
+		items = append(items, &devents.TxResult{
+			MD:    md,
+			Block: block,
+			Tx:    tx,
+			Ethscription: &devents.Ethscription{
+				Id:       id,
+				Creator:  item.Creator,
+				Owner:    tx.To,
+				Previous: item.Owner,
+			},
+		})
+	}
+
+	if len(items) <= 0 {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(xyerrors.NewInsError(-42, fmt.Sprintf("no ethscription of sender[%s] transferred", tx.From)))
+	}
+	return items, nil
+}
+
+// ContentHash sha256 of the data uri
+func ContentHash(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// ParseIds split transfer calldata into ethscription ids
+func ParseIds(input string) []string {
+	data := strings.ToLower(strings.TrimPrefix(input, "0x"))
+	ids := make([]string, 0, len(data)/(IdSize*2))
+	for i := 0; i+IdSize*2 <= len(data); i += IdSize * 2 {
+		ids = append(ids, "0x"+data[i:i+IdSize*2])
+	}
+	return ids
+}
+
+// FastCheck data uri creations & ethscription id transfers
+func FastCheck(tx *xycommon.RpcTransaction) bool {
+	return common.FastCheckDataPrefix(tx) || isTransferInput(tx.Input)
+}
+
+func isTransferInput(input string) bool {
+	if !strings.HasPrefix(input, "0x") {
+		return false
+	}
+
+	data := input[2:]
+	if len(data) <= 0 || len(data)%(IdSize*2) != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(data)
+	return err == nil
+}
+
+// ParseMetaData
+/***************************************
+ * parse calldata into ethscription creation or transfer, nil if neither
+ ***************************************/
+func ParseMetaData(chain, input string, maxSize int) *devents.MetaData {
+	// valid data uris are creations, even if 32 bytes long
+	if uri, ok := parseCreation(input, maxSize); ok {
+		return &devents.MetaData{
+			Chain:    chain,
+			Protocol: types.EthscriptionsProtocol,
+			Operate:  devents.OperateCreate,
+			Data:     uri,
+		}
+	}
+
+	if isTransferInput(input) {
+		return &devents.MetaData{
+			Chain:    chain,
+			Protocol: types.EthscriptionsProtocol,
+			Operate:  devents.OperateTransfer,
+			Data:     input,
+		}
+	}
+	return nil
+}
+
+// parseCreation decode calldata into data uri of creation
+func parseCreation(input string, maxSize int) (string, bool) {
+	if !strings.HasPrefix(input, "0x") {
+		return "", false
+	}
+
+	data, err := hex.DecodeString(input[2:])
+	if err != nil || len(data) > maxSize {
+		return "", false
+	}
+
+	// ESIP-7 gzip compressed calldata
+	if utils.IsGzipped(data) {
+		data, err = utils.Gunzip(data, maxSize)
+		if err != nil {
+			return "", false
+		}
+	}
+
+	// creations must be data uri with the scheme
+	uri := string(data)
+	if !strings.HasPrefix(strings.ToLower(uri), "data:") {
+		return "", false
+	}
+
+	if _, err = utils.ParseDataURI(uri, maxSize); err != nil {
+		return "", false
+	}
+	return uri, true
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package nameservice
+
+import (
+	"encoding/json"
+	"fmt"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxNameLength max characters of a name, suffix included
+	MaxNameLength = 64
+
+	// MaxRecordLength max characters of avatar & text records
+	MaxRecordLength = 512
+)
+
+func init() {
+	types.Register(&types.Registration{
+		ChainGroup: model.EvmChainGroup,
+		Protocol:   types.NameServiceProtocol,
+		OptIn:      true,
+		Operates:   []string{devents.OperateRegister, devents.OperateTransfer, devents.OperateUpdate},
+		FastCheck:  common.FastCheckDataPrefix,
+		New: func(cache *dcache.Manager, rules types.RuleSchedule) types.IProtocol {
+			return NewProtocol(cache, rules)
+		},
+	})
+}
+
+type Protocol struct {
+	common *common.Protocol
+	cache  *dcache.Manager
+}
+
+func NewProtocol(cache *dcache.Manager, rules types.RuleSchedule) *Protocol {
+	return &Protocol{
+		common: common.NewProtocol(cache, rules),
+		cache:  cache,
+	}
+}
+
+// Payload name service inscription, records are set only if present
+type Payload struct {
+	Name    string  `json:"name"`
+	To      string  `json:"to"`
+	Address *string `json:"address"`
+	Avatar  *string `json:"avatar"`
+	Text    *string `json:"text"`
+}
+
+func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	p.common.ResolveRules(block, md)
+
+	payload := &Payload{}
+	if err := json.Unmarshal([]byte(md.Data), payload); err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(xyerrors.NewInsError(-13, fmt.Sprintf("json decode err:%v, data[%s]", err, md.Data)))
+	}
+
+	payload.Name = NormalizeName(payload.Name)
+	if !ValidName(payload.Name) {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(xyerrors.NewInsError(-52, fmt.Sprintf("invalid name[%s]", payload.Name)))
+	}
+
+	var (
+		name *devents.Name
+		err  *xyerrors.InsError
+	)
+	switch md.Operate {
+	case devents.OperateRegister:
+		name, err = p.Register(tx, payload)
+	case devents.OperateTransfer:
+		name, err = p.Transfer(tx, payload)
+	case devents.OperateUpdate:
+		name, err = p.Update(tx, payload)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
+	}
+
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Name:  name,
+	}
+	return []*devents.TxResult{result}, nil
+}
+
+// Register
+/***************************************
+ * first come registration, txs are handled by position in block
+ * the sender owns the name & the name resolves to the owner unless an address record set
+ ***************************************/
+func (p *Protocol) Register(tx *xycommon.RpcTransaction, payload *Payload) (*devents.Name, *xyerrors.InsError) {
+	if ok, _ := p.cache.Names.Get(payload.Name); ok {
+		return nil, xyerrors.NewInsError(-53, fmt.Sprintf("name[%s] registered", payload.Name))
+	}
+
+	name := &devents.Name{
+		Name:    payload.Name,
+		Owner:   strings.ToLower(tx.From),
+		Address: strings.ToLower(tx.From),
+	}
+	if err := setRecords(name, payload); err != nil {
+		return nil, err
+	}
+	return name, nil
+}
+
+// Transfer
+/***************************************
+ * transfer name to the recipient of payload, tx recipient if not set
+ * records of the previous owner are cleared
+ ***************************************/
+func (p *Protocol) Transfer(tx *xycommon.RpcTransaction, payload *Payload) (*devents.Name, *xyerrors.InsError) {
+	item, err := p.owned(tx, payload.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	to := payload.To
+	if to == "" {
+		to = tx.To
+	}
+	if !ethcommon.IsHexAddress(to) || strings.EqualFold(to, item.Owner) {
+		return nil, xyerrors.NewInsError(-56, fmt.Sprintf("invalid recipient[%s]", to))
+	}
+
+	return &devents.Name{
+		Name:     payload.Name,
+		Owner:    strings.ToLower(to),
+		Previous: item.Owner,
+		Address:  strings.ToLower(to),
+	}, nil
+}
+
+// Update
+/***************************************
+ * update records present in payload, empty address record resolves to the owner
+ ***************************************/
+func (p *Protocol) Update(tx *xycommon.RpcTransaction, payload *Payload) (*devents.Name, *xyerrors.InsError) {
+	item, err := p.owned(tx, payload.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Address == nil && payload.Avatar == nil && payload.Text == nil {
+		return nil, xyerrors.NewInsError(-57, fmt.Sprintf("no records of name[%s] updated", payload.Name))
+	}
+
+	name := &devents.Name{
+		Name:    payload.Name,
+		Owner:   item.Owner,
+		Address: item.Address,
+		Avatar:  item.Avatar,
+		Text:    item.Text,
+	}
+	if err = setRecords(name, payload); err != nil {
+		return nil, err
+	}
+	return name, nil
+}
+
+// owned registered name owned by the sender
+func (p *Protocol) owned(tx *xycommon.RpcTransaction, name string) (*dcache.NameItem, *xyerrors.InsError) {
+	ok, item := p.cache.Names.Get(name)
+	if !ok {
+		return nil, xyerrors.NewInsError(-54, fmt.Sprintf("name[%s] not registered", name))
+	}
+
+	if !strings.EqualFold(item.Owner, tx.From) {
+		return nil, xyerrors.NewInsError(-55, fmt.Sprintf("name[%s] not owned by sender[%s]", name, tx.From))
+	}
+	return item, nil
+}
+
+// setRecords set records of payload to the name
+func setRecords(name *devents.Name, payload *Payload) *xyerrors.InsError {
+	if payload.Address != nil {
+		address := strings.TrimSpace(*payload.Address)
+		switch {
+		case address == "":
+			name.Address = name.Owner
+		case ethcommon.IsHexAddress(address):
+			name.Address = strings.ToLower(address)
+		default:
+			return xyerrors.NewInsError(-56, fmt.Sprintf("invalid address record[%s]", address))
+		}
+	}
+
+	if payload.Avatar != nil {
+		if utf8.RuneCountInString(*payload.Avatar) > MaxRecordLength {
+			return xyerrors.NewInsError(-58, fmt.Sprintf("avatar record length > %d", MaxRecordLength))
+		}
+		name.Avatar = *payload.Avatar
+	}
+
+	if payload.Text != nil {
+		if utf8.RuneCountInString(*payload.Text) > MaxRecordLength {
+			return xyerrors.NewInsError(-58, fmt.Sprintf("text record length > %d", MaxRecordLength))
+		}
+		name.Text = *payload.Text
+	}
+	return nil
+}
+
+// NormalizeName canonical & lower case name
+func NormalizeName(name string) string {
+	return utils.NormalizeTick(name, false)
+}
+
+// ValidName
+/***************************************
+ * names are dot separated labels with a suffix, e.g. alice.avax
+ * labels are letters, digits & hyphens, letters of a label in one script against spoofing
+ ***************************************/
+func ValidName(name string) bool {
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return false
+	}
+
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" {
+			return false
+		}
+
+		script := ""
+		for _, r := range label {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+				return false
+			}
+			if !unicode.IsLetter(r) {
+				continue
+			}
+
+			// mixed scripts label, e.g. latin & cyrillic
+			if s := scriptOf(r); script == "" {
+				script = s
+			} else if s != script {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// scriptOf unicode script of the letter, japanese kana & han letters are mixed in one script
+func scriptOf(r rune) string {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+		return "Japanese"
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package nameservice_test
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/evm/nameservice"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/xylog"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func TestNameService(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX, Protocols: []string{"asc-20", "ans"}}}
+	h := testutil.NewHarness(t, cfg)
+
+	var (
+		alice = "0x00000000000000000000000000000000000000a1"
+		bob   = "0x00000000000000000000000000000000000000b2"
+		carol = "0x00000000000000000000000000000000000000c3"
+	)
+
+	// first come registration, names are case insensitive
+	md, results, err := h.Handle(testutil.CalldataAt(1, alice, alice, `data:,{"p":"ans","op":"reg","name":"Alice.avax"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "ans", md.Protocol)
+	assert.Equal(t, devents.OperateRegister, md.Operate)
+	assert.Equal(t, "alice.avax", results[0].Name.Name)
+	ok, item := h.Cache.Names.Get("alice.avax")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), item.SID)
+	assert.Equal(t, alice, item.Owner)
+	assert.Equal(t, alice, item.Address)
+
+	_, err = h.Inscribe(bob, bob, `data:,{"p":"ans","op":"reg","name":"alice.avax"}`)
+	assert.Equal(t, -53, testutil.CauseCode(err))
+	_, err = h.Inscribe(bob, bob, `data:,{"p":"ans","op":"reg","name":"bob"}`)
+	assert.Equal(t, -52, testutil.CauseCode(err))
+	_, err = h.Inscribe(bob, bob, `data:,{"p":"ans","op":"reg","name":"bob .avax"}`)
+	assert.Equal(t, -52, testutil.CauseCode(err))
+
+	// labels of mixed scripts spoof other names, cyrillic а
+	_, err = h.Inscribe(bob, bob, `data:,{"p":"ans","op":"reg","name":"аlice.avax"}`)
+	assert.Equal(t, -52, testutil.CauseCode(err))
+	assert.True(t, nameservice.ValidName("пример.avax"))
+	assert.True(t, nameservice.ValidName("ひらがな漢字.avax"))
+
+	// records updated by the owner only
+	_, err = h.Inscribe(bob, bob, `data:,{"p":"ans","op":"update","name":"alice.avax","avatar":"ipfs://avatar"}`)
+	assert.Equal(t, -55, testutil.CauseCode(err))
+	_, err = h.Inscribe(alice, alice, `data:,{"p":"ans","op":"update","name":"alice.avax"}`)
+	assert.Equal(t, -57, testutil.CauseCode(err))
+	_, err = h.Inscribe(alice, alice, `data:,{"p":"ans","op":"update","name":"alice.avax","address":"bob"}`)
+	assert.Equal(t, -56, testutil.CauseCode(err))
+	results, err = h.Inscribe(alice, alice, `data:,{"p":"ans","op":"update","name":"alice.avax","address":"0x00000000000000000000000000000000000000C3","avatar":"ipfs://avatar","text":"gm"}`)
+	assert.Nil(t, err)
+	_, item = h.Cache.Names.Get("alice.avax")
+	assert.Equal(t, carol, item.Address)
+	assert.Equal(t, "ipfs://avatar", item.Avatar)
+	assert.Equal(t, "gm", item.Text)
+
+	// records not present are kept, empty address record resolves to the owner
+	_, err = h.Inscribe(alice, alice, `data:,{"p":"ans","op":"update","name":"alice.avax","address":""}`)
+	assert.Nil(t, err)
+	_, item = h.Cache.Names.Get("alice.avax")
+	assert.Equal(t, alice, item.Address)
+	assert.Equal(t, "gm", item.Text)
+
+	// transfers to the payload recipient, tx recipient if not set & records cleared
+	_, err = h.Inscribe(bob, carol, `data:,{"p":"ans","op":"transfer","name":"alice.avax"}`)
+	assert.Equal(t, -55, testutil.CauseCode(err))
+	_, err = h.Inscribe(alice, alice, `data:,{"p":"ans","op":"transfer","name":"alice.avax"}`)
+	assert.Equal(t, -56, testutil.CauseCode(err))
+	results, err = h.Inscribe(alice, carol, `data:,{"p":"ans","op":"transfer","name":"alice.avax","to":"`+bob+`"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, alice, results[0].Name.Previous)
+	_, item = h.Cache.Names.Get("alice.avax")
+	assert.Equal(t, bob, item.Owner)
+	assert.Equal(t, bob, item.Address)
+	assert.Equal(t, "", item.Text)
+	_, err = h.Inscribe(bob, carol, `data:,{"p":"ans","op":"transfer","name":"alice.avax"}`)
+	assert.Nil(t, err)
+	_, item = h.Cache.Names.Get("alice.avax")
+	assert.Equal(t, carol, item.Owner)
+
+	_, err = h.Inscribe(carol, carol, `data:,{"p":"ans","op":"update","name":"carol.avax","text":"gm"}`)
+	assert.Equal(t, -54, testutil.CauseCode(err))
+
+	// db models, registration created & later txs update the record
+	results, _ = h.Inscribe(bob, bob, `data:,{"p":"ans","op":"reg","name":"bob.avax","avatar":"ipfs://bob"}`)
+	created := h.Handler.BuildName(results[0])[devents.DBActionCreate]
+	assert.Equal(t, uint64(2), created.SID)
+	assert.Equal(t, "bob.avax", created.Name)
+	assert.Equal(t, "ipfs://bob", created.Avatar)
+
+	results, _ = h.Inscribe(bob, alice, `data:,{"p":"ans","op":"transfer","name":"bob.avax"}`)
+	updated := h.Handler.BuildName(results[0])[devents.DBActionUpdate]
+	assert.Equal(t, uint64(2), updated.SID)
+	assert.Equal(t, alice, updated.Owner)
+	assert.Equal(t, "", updated.Avatar)
+
+	// opt-in protocol
+	h.Reload(&config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}})
+	md, _, _ = h.Handle(testutil.CalldataAt(1, alice, alice, `data:,{"p":"ans","op":"reg","name":"gm.avax"}`))
+	assert.Nil(t, md)
+}
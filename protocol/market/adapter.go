@@ -0,0 +1,257 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package market
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// Order filled order decoded from marketplace event
+type Order struct {
+	Market   string
+	Seller   string
+	Buyer    string
+	Tick     string
+	TickHash string
+	Amount   decimal.Decimal
+	Price    decimal.Decimal
+	Escrow   bool
+	ListId   string // listing id, empty if not mapped
+	Operate  string // exchange | delist
+}
+
+func (o *Order) String() string {
+	return fmt.Sprintf("market[%s] %s list[%s] seller[%s] buyer[%s] tick[%s%s] amount[%v] price[%v]", o.Market, o.Operate, o.ListId, o.Seller, o.Buyer, o.Tick, o.TickHash, o.Amount, o.Price)
+}
+
+// Adapter
+/*****************************************************
+ * decode filled order events of a marketplace contract by config
+ ****************************************************/
+type Adapter struct {
+	cfg      *config.MarketplaceConfig
+	contract common.Address
+	event    abi.Event
+}
+
+func NewAdapter(cfg *config.MarketplaceConfig) (*Adapter, error) {
+	if !common.IsHexAddress(cfg.Contract) {
+		return nil, fmt.Errorf("marketplace[%s] contract[%s] invalid", cfg.Name, cfg.Contract)
+	}
+
+	fd, err := os.Open(cfg.AbiFile)
+	if err != nil {
+		return nil, fmt.Errorf("marketplace[%s] abi file open err:%v", cfg.Name, err)
+	}
+	defer fd.Close()
+
+	parsed, err := abi.JSON(fd)
+	if err != nil {
+		return nil, fmt.Errorf("marketplace[%s] abi decode err:%v", cfg.Name, err)
+	}
+	return newAdapter(cfg, parsed)
+}
+
+func newAdapter(cfg *config.MarketplaceConfig, parsed abi.ABI) (*Adapter, error) {
+	event, ok := parsed.Events[cfg.Event]
+	if !ok {
+		return nil, fmt.Errorf("marketplace[%s] event[%s] not found in abi", cfg.Name, cfg.Event)
+	}
+
+	switch cfg.Operate {
+	case "":
+		cfg.Operate = devents.OperateExchange
+	case devents.OperateExchange, devents.OperateDelist:
+	default:
+		return nil, fmt.Errorf("marketplace[%s] operate[%s] invalid", cfg.Name, cfg.Operate)
+	}
+
+	// orders of listings take seller, tick & amount from listing records
+	f := cfg.Fields
+	required := []string{f.Seller, f.Amount}
+	optional := []string{f.Tick, f.TickHash, f.Price, f.ListId}
+	if f.ListId != "" {
+		required, optional = nil, append(optional, f.Seller, f.Amount)
+	} else if f.Tick == "" && f.TickHash == "" {
+		return nil, fmt.Errorf("marketplace[%s] tick / tick_hash field required", cfg.Name)
+	}
+
+	if cfg.Operate == devents.OperateDelist {
+		if f.ListId == "" && f.Seller == "" {
+			return nil, fmt.Errorf("marketplace[%s] list_id / seller field required by delist", cfg.Name)
+		}
+	} else {
+		required = append(required, f.Buyer)
+	}
+
+	inputs := make(map[string]struct{}, len(event.Inputs))
+	for _, arg := range event.Inputs {
+		inputs[arg.Name] = struct{}{}
+	}
+	for _, name := range required {
+		if _, ok := inputs[name]; !ok {
+			return nil, fmt.Errorf("marketplace[%s] field[%s] not found in event[%s]", cfg.Name, name, cfg.Event)
+		}
+	}
+
+	for _, name := range optional {
+		if _, ok := inputs[name]; name != "" && !ok {
+			return nil, fmt.Errorf("marketplace[%s] field[%s] not found in event[%s]", cfg.Name, name, cfg.Event)
+		}
+	}
+
+	return &Adapter{
+		cfg:      cfg,
+		contract: common.HexToAddress(cfg.Contract),
+		event:    event,
+	}, nil
+}
+
+// Protocol protocol of the traded ticks
+func (a *Adapter) Protocol() string {
+	return strings.ToLower(a.cfg.Protocol)
+}
+
+// Topic event topic hash
+func (a *Adapter) Topic() string {
+	return a.event.ID.String()
+}
+
+// Match reports whether the log is emitted by the marketplace's filled order event
+func (a *Adapter) Match(log *xycommon.RpcLog) bool {
+	if len(log.Topics) < 1 || log.Address != a.contract {
+		return false
+	}
+	return log.Topics[0] == a.event.ID
+}
+
+// Decode
+/***************************************
+ * decode event log into order by fields mapping
+ ***************************************/
+func (a *Adapter) Decode(log *xycommon.RpcLog) (*Order, error) {
+	values := make(map[string]interface{}, len(a.event.Inputs))
+	if err := a.event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return nil, fmt.Errorf("event data unpack err:%v", err)
+	}
+
+	indexed := make(abi.Arguments, 0, len(a.event.Inputs))
+	for _, arg := range a.event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(indexed) > 0 {
+		if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+			return nil, fmt.Errorf("event topics parse err:%v", err)
+		}
+	}
+
+	var err error
+	f := a.cfg.Fields
+	order := &Order{
+		Market:  a.contract.String(),
+		Escrow:  a.cfg.Escrow,
+		Operate: a.cfg.Operate,
+	}
+	if f.ListId != "" {
+		if order.ListId, err = hashValue(values, f.ListId); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.Seller != "" {
+		if order.Seller, err = addressValue(values, f.Seller); err != nil {
+			return nil, err
+		}
+	}
+
+	// delisted tokens return to seller
+	if a.cfg.Operate == devents.OperateDelist {
+		order.Buyer = order.Seller
+	} else if order.Buyer, err = addressValue(values, f.Buyer); err != nil {
+		return nil, err
+	}
+
+	if f.Amount != "" {
+		amount, err := numberValue(values, f.Amount)
+		if err != nil {
+			return nil, err
+		}
+		order.Amount = amount.Shift(-a.cfg.AmountDecimals)
+	}
+
+	if f.Price != "" {
+		if order.Price, err = numberValue(values, f.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.Tick != "" {
+		tick, ok := values[f.Tick].(string)
+		if !ok {
+			return nil, fmt.Errorf("field[%s] is not a string", f.Tick)
+		}
+		order.Tick = tick
+	} else if f.TickHash != "" {
+		if order.TickHash, err = hashValue(values, f.TickHash); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func addressValue(values map[string]interface{}, name string) (string, error) {
+	v, ok := values[name].(common.Address)
+	if !ok {
+		return "", fmt.Errorf("field[%s] is not an address", name)
+	}
+	return v.String(), nil
+}
+
+func numberValue(values map[string]interface{}, name string) (decimal.Decimal, error) {
+	v, ok := values[name].(*big.Int)
+	if !ok || v == nil {
+		return decimal.Zero, fmt.Errorf("field[%s] is not an integer", name)
+	}
+	return decimal.NewFromBigInt(v, 0), nil
+}
+
+func hashValue(values map[string]interface{}, name string) (string, error) {
+	switch v := values[name].(type) {
+	case common.Hash:
+		return v.String(), nil
+	case [32]byte:
+		return common.BytesToHash(v[:]).String(), nil
+	}
+	return "", fmt.Errorf("field[%s] is not a bytes32 hash", name)
+}
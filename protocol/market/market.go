@@ -0,0 +1,125 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package market
+
+import (
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/xylog"
+	"sort"
+	"strings"
+)
+
+// adapters enabled marketplace adapters of the indexing chain
+var adapters []*Adapter
+
+// Init
+/***************************************
+ * build adapters of configured marketplaces, replace the enabled ones
+ ***************************************/
+func Init(cfgs []*config.MarketplaceConfig) error {
+	items := make([]*Adapter, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		a, err := NewAdapter(cfg)
+		if err != nil {
+			return err
+		}
+		items = append(items, a)
+		xylog.Logger.Infof("marketplace[%s] adapter enabled, contract[%s], protocol[%s]", cfg.Name, cfg.Contract, a.Protocol())
+	}
+	adapters = items
+	return nil
+}
+
+// EventTopics returns filled order event topics of enabled adapters
+func EventTopics() []string {
+	exists := make(map[string]struct{}, len(adapters))
+	topics := make([]string, 0, len(adapters))
+	for _, a := range adapters {
+		topic := strings.ToLower(a.Topic())
+		if _, ok := exists[topic]; ok {
+			continue
+		}
+		exists[topic] = struct{}{}
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// FastCheck reports whether the tx carries filled order events of any marketplace
+func FastCheck(tx *xycommon.RpcTransaction) bool {
+	return match(tx) != nil
+}
+
+func match(tx *xycommon.RpcTransaction) *Adapter {
+	for i := range tx.Events {
+		for _, a := range adapters {
+			if a.Match(&tx.Events[i]) {
+				return a
+			}
+		}
+	}
+	return nil
+}
+
+// ParseMetaData
+/***************************************
+ * exchange metadata of the first matched marketplace event, nil if not matched
+ ***************************************/
+func ParseMetaData(chain string, tx *xycommon.RpcTransaction) *devents.MetaData {
+	a := match(tx)
+	if a == nil {
+		return nil
+	}
+
+	return &devents.MetaData{
+		Chain:    chain,
+		Protocol: a.Protocol(),
+		Operate:  devents.OperateExchange,
+	}
+}
+
+// ExtractOrders
+/***************************************
+ * decode filled orders of the protocol's marketplaces in tx events
+ ***************************************/
+func ExtractOrders(protocol string, tx *xycommon.RpcTransaction) []*Order {
+	orders := make([]*Order, 0, len(tx.Events))
+	for i := range tx.Events {
+		for _, a := range adapters {
+			if a.Protocol() != protocol || !a.Match(&tx.Events[i]) {
+				continue
+			}
+
+			order, err := a.Decode(&tx.Events[i])
+			if err != nil {
+				xylog.Logger.Infof("tx[%s] - marketplace[%s] order decode err:%v", tx.Hash, a.cfg.Name, err)
+				continue
+			}
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package market_test
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+const testMarketABI = `[
+	{"type":"event","name":"OrderFilled","anonymous":false,"inputs":[
+		{"name":"seller","type":"address","indexed":true},
+		{"name":"buyer","type":"address","indexed":true},
+		{"name":"tick","type":"string","indexed":false},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"price","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Sold","anonymous":false,"inputs":[
+		{"name":"tickHash","type":"bytes32","indexed":true},
+		{"name":"from","type":"address","indexed":false},
+		{"name":"to","type":"address","indexed":false},
+		{"name":"qty","type":"uint256","indexed":false}]}
+]`
+
+func TestMarketplaceAdapters(t *testing.T) {
+	abiFile := filepath.Join(t.TempDir(), "market.json")
+	assert.Nil(t, os.WriteFile(abiFile, []byte(testMarketABI), 0644))
+	parsed, err := abi.JSON(strings.NewReader(testMarketABI))
+	assert.Nil(t, err)
+
+	var (
+		seller  = ethcommon.HexToAddress("0x00000000000000000000000000000000000000a1")
+		buyer   = ethcommon.HexToAddress("0x00000000000000000000000000000000000000b2")
+		market1 = ethcommon.HexToAddress("0x00000000000000000000000000000000000000c3")
+		market2 = ethcommon.HexToAddress("0x00000000000000000000000000000000000000d4")
+	)
+	cfg := &config.Config{Chain: config.ChainConfig{
+		ChainName: "eth",
+		Marketplaces: []*config.MarketplaceConfig{
+			{
+				Name: "m1", Protocol: "brc-20", Contract: market1.String(), AbiFile: abiFile, Event: "OrderFilled",
+				Fields: config.MarketplaceFields{Seller: "seller", Buyer: "buyer", Tick: "tick", Amount: "amount", Price: "price"},
+			},
+			{
+				Name: "m2", Protocol: "brc-20", Contract: market2.String(), AbiFile: abiFile, Event: "Sold", Escrow: true, AmountDecimals: 2,
+				Fields: config.MarketplaceFields{Seller: "from", Buyer: "to", TickHash: "tickHash", Amount: "qty"},
+			},
+		},
+	}}
+	h := testutil.NewHarness(t, cfg)
+	assert.Contains(t, protocol.EventTopics(), strings.ToLower(parsed.Events["Sold"].ID.String()))
+
+	for _, from := range []string{seller.String(), market2.String()} {
+		_, err := h.Inscribe(from, from, `data:,{"p":"brc-20","op":"deploy","tick":"ordi","max":"1000","lim":"100"}`)
+		if from == seller.String() {
+			assert.Nil(t, err)
+		}
+		_, err = h.Inscribe(from, from, `data:,{"p":"brc-20","op":"mint","tick":"ordi","amt":"100"}`)
+		assert.Nil(t, err)
+	}
+
+	data, err := parsed.Events["OrderFilled"].Inputs.NonIndexed().Pack("ORDI", big.NewInt(30), big.NewInt(3e17))
+	assert.Nil(t, err)
+	filled := xycommon.RpcLog{
+		Address: market1,
+		Topics:  []ethcommon.Hash{parsed.Events["OrderFilled"].ID, ethcommon.BytesToHash(seller.Bytes()), ethcommon.BytesToHash(buyer.Bytes())},
+		Data:    data,
+	}
+
+	data, err = parsed.Events["Sold"].Inputs.NonIndexed().Pack(seller, buyer, big.NewInt(1250))
+	assert.Nil(t, err)
+	sold := xycommon.RpcLog{
+		Address: market2,
+		Topics:  []ethcommon.Hash{parsed.Events["Sold"].ID, ethcommon.HexToHash(utils.Keccak256("ordi"))},
+		Data:    data,
+	}
+
+	// events of unknown contract are ignored
+	other := filled
+	other.Address = buyer
+
+	_, results, err1 := h.Handle(&xycommon.RpcTransaction{Hash: "0x01", Events: []xycommon.RpcLog{filled, sold, other}})
+	assert.Nil(t, err1)
+	assert.Len(t, results, 2)
+	assert.Equal(t, devents.OperateExchange, results[0].MD.Operate)
+
+	for addr, amount := range map[string]string{seller.String(): "70", market2.String(): "87.5", buyer.String(): "42.5"} {
+		_, balance := h.Cache.Balance.Get("brc-20", "ordi", addr)
+		assert.Equal(t, amount, balance.Overall.String())
+	}
+
+	// filled orders recorded as trades, unpriced order keeps the prices
+	assert.Equal(t, "0.3", results[0].Trade.Total.String())
+	assert.Equal(t, "0.01", results[0].Trade.UnitPrice.String())
+	assert.True(t, results[0].Trade.Init)
+	assert.False(t, results[1].Trade.Init)
+	_, stats := h.Cache.Market.Get("brc-20", "ordi")
+	assert.Equal(t, uint64(2), stats.TradeCnt)
+	assert.Equal(t, "0.3", stats.Volume.String())
+	assert.Equal(t, "0.01", stats.LastPrice.String())
+	assert.True(t, stats.FloorPrice.IsZero())
+
+	// trades out of the 24h window leave the rolling stats
+	stats, _ = h.Cache.Market.Trade("brc-20", "ordi", dcache.MarketWindow+10, decimal.RequireFromString("0.02"), decimal.RequireFromString("1"))
+	assert.Equal(t, uint64(3), stats.TradeCnt)
+	assert.Equal(t, "1.3", stats.Volume.String())
+	assert.Equal(t, uint64(1), stats.TradeCnt24h)
+	assert.Equal(t, "1", stats.Volume24h.String())
+
+	// windows refreshed by each block, stats of ticks changed only
+	assert.Len(t, h.Handler.BuildMarketRefresh(h.Cfg.Chain.ChainName, dcache.MarketWindow+10), 0)
+	refreshed := h.Handler.BuildMarketRefresh(h.Cfg.Chain.ChainName, 2*dcache.MarketWindow+10)
+	assert.Len(t, refreshed, 1)
+	item := refreshed[0].MarketStats[devents.DBActionUpdate]
+	assert.Equal(t, stats.SID, item.SID)
+	assert.Equal(t, "brc-20", item.Protocol)
+	assert.Equal(t, uint64(0), item.TradeCnt24h)
+	assert.True(t, item.Volume24h.IsZero())
+	assert.Equal(t, "1.3", item.Volume.String())
+	assert.Len(t, h.Handler.BuildMarketRefresh(h.Cfg.Chain.ChainName, 2*dcache.MarketWindow+20), 0)
+
+	// window pruned by the block time without trades
+	stats, _ = h.Cache.Market.Floor("brc-20", "ordi", 2*dcache.MarketWindow+10, decimal.Zero)
+	assert.Equal(t, uint64(0), stats.TradeCnt24h)
+	assert.True(t, stats.Volume24h.IsZero())
+	assert.Equal(t, "1.3", stats.Volume.String())
+
+	// seller balance not enough
+	data, _ = parsed.Events["OrderFilled"].Inputs.NonIndexed().Pack("ordi", big.NewInt(71), big.NewInt(5))
+	filled.Data = data
+	_, results, _ = h.Handle(&xycommon.RpcTransaction{Hash: "0x02", Events: []xycommon.RpcLog{filled}})
+	assert.Len(t, results, 0)
+}
+
+func TestOrderBatchTicks(t *testing.T) {
+	abiFile := filepath.Join(t.TempDir(), "market.json")
+	assert.Nil(t, os.WriteFile(abiFile, []byte(testMarketABI), 0644))
+	parsed, err := abi.JSON(strings.NewReader(testMarketABI))
+	assert.Nil(t, err)
+
+	var (
+		seller = ethcommon.HexToAddress("0x00000000000000000000000000000000000000a1")
+		buyer  = ethcommon.HexToAddress("0x00000000000000000000000000000000000000b2")
+		mkt    = ethcommon.HexToAddress("0x00000000000000000000000000000000000000c3")
+	)
+	h := testutil.NewHarness(t, &config.Config{Chain: config.ChainConfig{
+		ChainName: "eth",
+		Marketplaces: []*config.MarketplaceConfig{
+			{
+				Name: "m1", Protocol: "brc-20", Contract: mkt.String(), AbiFile: abiFile, Event: "OrderFilled",
+				Fields: config.MarketplaceFields{Seller: "seller", Buyer: "buyer", Tick: "tick", Amount: "amount", Price: "price"},
+			},
+		},
+	}})
+
+	for _, tick := range []string{"ordi", "sats"} {
+		_, err = h.Inscribe(seller.String(), seller.String(), `data:,{"p":"brc-20","op":"deploy","tick":"`+tick+`","max":"1000","lim":"100"}`)
+		assert.Nil(t, err)
+		_, err = h.Inscribe(seller.String(), seller.String(), `data:,{"p":"brc-20","op":"mint","tick":"`+tick+`","amt":"100"}`)
+		assert.Nil(t, err)
+	}
+
+	filled := func(tick string, amount int64) xycommon.RpcLog {
+		data, err := parsed.Events["OrderFilled"].Inputs.NonIndexed().Pack(tick, big.NewInt(amount), big.NewInt(1e17))
+		assert.Nil(t, err)
+		return xycommon.RpcLog{
+			Address: mkt,
+			Topics:  []ethcommon.Hash{parsed.Events["OrderFilled"].ID, ethcommon.BytesToHash(seller.Bytes()), ethcommon.BytesToHash(buyer.Bytes())},
+			Data:    data,
+		}
+	}
+
+	// orders of a batch debit the balance of their own tick
+	_, results, _ := h.Handle(&xycommon.RpcTransaction{Events: []xycommon.RpcLog{filled("ordi", 80), filled("sats", 80), filled("ordi", 30)}})
+	assert.Len(t, results, 2)
+	for _, tick := range []string{"ordi", "sats"} {
+		_, balance := h.Cache.Balance.Get("brc-20", tick, buyer.String())
+		assert.Equal(t, "80", balance.Overall.String(), tick)
+	}
+}
@@ -29,10 +29,18 @@ import (
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
-	"github.com/uxuycom/indexer/protocol/avax/asc20"
+	"github.com/uxuycom/indexer/protocol/bridge"
+	"github.com/uxuycom/indexer/protocol/evm/ethscription"
+	"github.com/uxuycom/indexer/protocol/market"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"math/big"
 	"strings"
 )
 
+// MaxDataURISize max data uri size of calldata before the protocol is known
+const MaxDataURISize = 128 * 1024
+
 var EVMValidContentTypes = map[string]struct{}{
 	"":                 {},
 	"text/plain":       {},
@@ -45,14 +53,42 @@ func ParseMetaData(chainName string, tx *xycommon.RpcTransaction) (*devents.Meta
 		return ParseBTCMetaData(chainName, tx)
 	}
 
-	// MethodID: 0xd9b3d6d0
-	if chainName == model.ChainAVAX && len(tx.Events) > 0 {
-		return asc20.ParseMetaDataByEventLogs(chainName, tx)
+	// memo inscriptions of cosmos chains
+	if chainGroup == model.CosmosChainGroup {
+		return ParseCosmosMetaData(chainName, tx)
+	}
+
+	md, err := parseEVMMetaData(chainName, tx.Input, tx.BlockNumber)
+	if md != nil {
+		return md, nil
+	}
+
+	// filled orders of configured marketplaces, the ticks resolved by the orders
+	if md := market.ParseMetaData(chainName, tx); md != nil {
+		return md, nil
+	}
+
+	// non-token data uri & transfers by ethscription ids
+	if _, ok := protocols[types.EthscriptionsProtocol]; ok {
+		if emd := ethscription.ParseMetaData(chainName, tx.Input, MaxDataURISize); emd != nil {
+			return emd, nil
+		}
+	}
+
+	// wrapped token movements of configured bridges, appended after the transfers by GetProtocols
+	if items := bridge.ParseMetaData(chainName, tx); len(items) > 0 {
+		return items[0], nil
 	}
-	return ParseEVMMetaData(chainName, tx.Input)
+	return nil, err
 }
 
+// ParseEVMMetaData parse input data by the latest rules of protocols
 func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error) {
+	return parseEVMMetaData(chain, inputData, nil)
+}
+
+// parseEVMMetaData parse input data by the rules of protocols as of the block height, latest rules if nil
+func parseEVMMetaData(chain string, inputData string, height *big.Int) (*devents.MetaData, error) {
 	// 0x prefix checking
 	if !strings.HasPrefix(inputData, "0x") {
 		return nil, fmt.Errorf("input 0x prefix checking failed")
@@ -62,30 +98,36 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 	if err != nil {
 		return nil, fmt.Errorf("input hex data decode err:%v", err)
 	}
+	return parseDataMetaData(chain, bytes, height)
+}
 
-	// try json format data
-	input := string(bytes)
-	dataPrefixIdx := strings.Index(input, ",")
-	if dataPrefixIdx == -1 {
-		return nil, fmt.Errorf("data seprator index failed")
+// parseDataMetaData parse data uri inscriptions of calldata & memos
+func parseDataMetaData(chain string, bytes []byte, height *big.Int) (*devents.MetaData, error) {
+	var err error
+	if len(bytes) > MaxDataURISize {
+		return nil, fmt.Errorf("input size[%d] > %d", len(bytes), MaxDataURISize)
 	}
 
-	// max length limit
-	if len(input) > 256 {
-		return nil, fmt.Errorf("data character size[%d] > 256", len(input))
+	// ESIP-7 gzip compressed calldata
+	if utils.IsGzipped(bytes) {
+		bytes, err = utils.Gunzip(bytes, MaxDataURISize)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	//set parse content types
-	contentType := ""
-	if dataPrefixIdx > 5 {
-		contentType = input[5:dataPrefixIdx]
+	uri, err := utils.ParseDataURI(string(bytes), MaxDataURISize)
+	if err != nil {
+		return nil, err
 	}
-	contentType = strings.ToLower(contentType)
-	if _, ok := EVMValidContentTypes[contentType]; !ok {
-		return nil, fmt.Errorf("tx content-type invalid & filtered, ct:%s", contentType)
+
+	//set parse content types
+	if _, ok := EVMValidContentTypes[uri.MediaType]; !ok {
+		return nil, fmt.Errorf("tx content-type invalid & filtered, ct:%s", uri.MediaType)
 	}
 
-	data := input[dataPrefixIdx+1:]
+	// try json format data
+	data := string(uri.Data)
 	proto := &devents.MetaData{}
 	if err := json.Unmarshal([]byte(data), proto); err != nil {
 		return nil, fmt.Errorf("tx input data parsed failed, data[%s], err[%v]", data, err)
@@ -94,10 +136,21 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 	// trim prefix / suffix spaces & case insensitive
 	proto.Protocol = strings.ToLower(strings.TrimSpace(proto.Protocol))
 	proto.Operate = strings.ToLower(strings.TrimSpace(proto.Operate))
-	proto.Tick = strings.ToLower(strings.TrimSpace(proto.Tick))
+	proto.Tick = strings.TrimSpace(proto.Tick)
+
+	// max payload size limit of protocol
+	rules := Rules(proto.Protocol)
+	if height != nil {
+		rules = RulesAt(proto.Protocol, height.Uint64())
+	}
+	if rules.MaxDataSize > 0 && len(data) > rules.MaxDataSize {
+		return nil, fmt.Errorf("data character size[%d] > %d", len(data), rules.MaxDataSize)
+	}
+
+	proto.Tick = utils.NormalizeTick(proto.Tick, rules.CaseSensitive)
 
-	// data checking
-	if proto.Protocol == "" || proto.Tick == "" {
+	// data checking, name service inscriptions are keyed by names instead of ticks
+	if proto.Protocol == "" || (proto.Tick == "" && !nameServiceEnabled(proto.Protocol)) {
 		return nil, fmt.Errorf("tx input data protocol / tick empty, data[%s]", data)
 	}
 	proto.Chain = chain
@@ -105,6 +158,37 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 	return proto, nil
 }
 
+// nameServiceEnabled reports whether the protocol is the enabled name service
+func nameServiceEnabled(protocol string) bool {
+	if protocol != types.NameServiceProtocol {
+		return false
+	}
+	_, ok := protocols[protocol]
+	return ok
+}
+
+// btcMetaDataParser protocols parsing metadata from raw btc txs
+type btcMetaDataParser interface {
+	ParseMetaData(chain string, tx *xycommon.RpcTransaction) *devents.MetaData
+}
+
 func ParseBTCMetaData(chain string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
+	// runestones & transfers of runes held by spent outputs
+	if ins, ok := protocols[types.RunesProtocol]; ok {
+		if parser, ok := ins.protocol.(btcMetaDataParser); ok {
+			if md := parser.ParseMetaData(chain, tx); md != nil {
+				return md, nil
+			}
+		}
+	}
 	return nil, nil
 }
+
+// ParseCosmosMetaData parse data uri inscriptions of cosmos tx memos
+func ParseCosmosMetaData(chain string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
+	memo := strings.TrimSpace(tx.Memo)
+	if !strings.HasPrefix(memo, "data:") {
+		return nil, fmt.Errorf("memo data prefix checking failed")
+	}
+	return parseDataMetaData(chain, []byte(memo), tx.BlockNumber)
+}
@@ -23,10 +23,14 @@
 package protocol
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/hex"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +41,23 @@ func TestParseEVMMetaData(t *testing.T) {
 	}
 
 	inputData := hex.EncodeToString([]byte(",{\"p\":\"asc-20\",\"op\":\"deploy\",\"tick\":\"Tduck\",\"max\":\"210000000\",\"lim\":\"1000\"}"))
+
+	data := "{\"p\":\"asc-20\",\"op\":\"deploy\",\"tick\":\"Tduck\",\"max\":\"210000000\",\"lim\":\"1000\"}"
+	want := &devents.MetaData{
+		Chain:    model.ChainAVAX,
+		Operate:  "deploy",
+		Protocol: "asc-20",
+		Tick:     "tduck",
+		Data:     data,
+	}
+	encode := func(input string) string {
+		return "0x" + hex.EncodeToString([]byte(input))
+	}
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write([]byte("data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(data))))
+	_ = w.Close()
 	tests := []struct {
 		name    string
 		args    args
@@ -74,6 +95,72 @@ func TestParseEVMMetaData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "base64 payload",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(data)))},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name:    "esip6 rule & charset parameters",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:text/plain;charset=utf-8;rule=esip6," + data)},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name:    "percent encoded payload",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:," + strings.ReplaceAll(data, "\"", "%22"))},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "valid json payload kept as is",
+			args: args{chain: model.ChainAVAX, inputData: encode("data:," + strings.TrimSuffix(data, "}") + ",\"memo\":\"100%25\"}")},
+			want: &devents.MetaData{
+				Chain:    model.ChainAVAX,
+				Operate:  "deploy",
+				Protocol: "asc-20",
+				Tick:     "tduck",
+				Data:     strings.TrimSuffix(data, "}") + ",\"memo\":\"100%25\"}",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "percent encoded base64 payload",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:application/json;base64,%65" + base64.StdEncoding.EncodeToString([]byte(data))[1:])},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name:    "gzip magic without deflate method",
+			args:    args{chain: model.ChainAVAX, inputData: "0x1f8b00" + hex.EncodeToString(gz.Bytes()[3:])},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "esip7 gzip compressed calldata",
+			args:    args{chain: model.ChainAVAX, inputData: "0x" + hex.EncodeToString(gz.Bytes())},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name:    "content type filtered",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(data)))},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "base64 not the last parameter",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:application/json;base64;rule=esip6," + base64.StdEncoding.EncodeToString([]byte(data)))},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "protocol max data size exceeded",
+			args:    args{chain: model.ChainAVAX, inputData: encode("data:," + strings.TrimSuffix(data, "}") + ",\"memo\":\"" + strings.Repeat("x", 256) + "\"}")},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
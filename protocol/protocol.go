@@ -28,24 +28,147 @@ import (
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
-	"github.com/uxuycom/indexer/protocol/avax/asc20"
-	btcBrc20 "github.com/uxuycom/indexer/protocol/btc/brc20"
-	"github.com/uxuycom/indexer/protocol/evm/brc20"
+	_ "github.com/uxuycom/indexer/protocol/avax/asc20"
+	"github.com/uxuycom/indexer/protocol/bridge"
+	_ "github.com/uxuycom/indexer/protocol/btc/brc20"
+	_ "github.com/uxuycom/indexer/protocol/btc/runes"
+	_ "github.com/uxuycom/indexer/protocol/cosmos/cia20"
+	_ "github.com/uxuycom/indexer/protocol/evm/brc20"
+	"github.com/uxuycom/indexer/protocol/evm/ethscription"
+	_ "github.com/uxuycom/indexer/protocol/evm/nameservice"
+	"github.com/uxuycom/indexer/protocol/market"
+	"github.com/uxuycom/indexer/protocol/smartaccount"
 	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/storage"
+	"github.com/uxuycom/indexer/utils"
 	"github.com/uxuycom/indexer/xylog"
+	"sort"
+	"strings"
 )
 
+type instance struct {
+	*types.Registration
+	protocol types.IProtocol
+	rules    types.RuleSchedule
+}
+
 var (
-	BTCBrc20Protocol *btcBrc20.Protocol
-	EvmAsc20Protocol *asc20.Protocol
-	EvmBrc20Protocol *brc20.Protocol
+	// enabled protocol instances of the indexing chain, protocol id -> instance
+	protocols  = make(map[string]*instance)
+	fallback   *instance
+	chainGroup = model.EvmChainGroup
 )
 
-func InitProtocols(cache *dcache.Manager) {
-	BTCBrc20Protocol = btcBrc20.NewProtocol(cache)
-	EvmBrc20Protocol = brc20.NewProtocol(cache)
-	EvmAsc20Protocol = asc20.NewProtocol(cache)
+// InitProtocols
+/***************************************
+ * create registered protocols of the chain which enabled by config
+ ***************************************/
+func InitProtocols(cfg *config.Config, cache *dcache.Manager) {
+	protocols = make(map[string]*instance)
+	fallback = nil
+
+	group := ChainGroup(cfg)
+	chainGroup = group
+	for _, r := range types.Registrations() {
+		if !r.Match(group, cfg.Chain.ChainName) || !protocolConfigured(cfg, r) {
+			continue
+		}
+
+		// chain specified registration has higher priority
+		if exist, ok := protocols[r.Protocol]; ok && exist.Chain != "" {
+			continue
+		}
+
+		name := r.RulesName()
+		if v, ok := cfg.Chain.Rules[r.Protocol]; ok && v != "" {
+			name = v
+		}
+
+		rules := ruleSchedule(cfg, r.Protocol, name)
+		protocols[r.Protocol] = &instance{Registration: r, protocol: r.New(cache, rules), rules: rules}
+	}
+
+	for id, ins := range protocols {
+		if ins.Fallback {
+			fallback = ins
+		}
+		xylog.Logger.Infof("protocol[%s] enabled, group[%s], chain[%s]", id, group, cfg.Chain.ChainName)
+	}
+
+	if err := market.Init(cfg.Chain.Marketplaces); err != nil {
+		xylog.Logger.Fatalf("marketplace adapters init err:%v", err)
+	}
+
+	if err := bridge.Init(cfg.Chain.Bridges); err != nil {
+		xylog.Logger.Fatalf("bridge wrappers init err:%v", err)
+	}
+
+	if err := smartaccount.Init(cfg.Chain.EntryPoints); err != nil {
+		xylog.Logger.Fatalf("smart account entry points init err:%v", err)
+	}
+
+	// inscriptions held by bridges are reported as wrapped supply
+	if cache != nil && cache.Wrapped != nil {
+		for _, w := range bridge.Wrappers() {
+			tick := utils.NormalizeTick(w.Tick(), Rules(w.Protocol()).CaseSensitive)
+			cache.Wrapped.AddBridge(w.Protocol(), tick, w.Bridge())
+		}
+	}
+}
+
+// ruleSchedule
+/***************************************
+ * build rule schedule of the protocol, the rule profile since genesis & forks of config
+ ***************************************/
+func ruleSchedule(cfg *config.Config, protocol, name string) types.RuleSchedule {
+	rules, ok := types.LookupRules(cfg, name)
+	if !ok {
+		xylog.Logger.Fatalf("protocol[%s] rule profile[%s] not found", protocol, name)
+	}
+
+	forks := []*types.RuleFork{{Height: 0, Version: name, Rules: rules}}
+	for _, f := range cfg.Chain.Forks[protocol] {
+		rules, ok = types.LookupRules(cfg, f.Profile)
+		if !ok {
+			xylog.Logger.Fatalf("protocol[%s] fork[%d] rule profile[%s] not found", protocol, f.Height, f.Profile)
+		}
+
+		version := f.Version
+		if version == "" {
+			version = f.Profile
+		}
+		forks = append(forks, &types.RuleFork{Height: f.Height, Version: version, Rules: rules})
+		xylog.Logger.Infof("protocol[%s] rule fork[%s] activated at block[%d]", protocol, version, f.Height)
+	}
+	return types.NewRuleSchedule(forks...)
+}
+
+func protocolConfigured(cfg *config.Config, r *types.Registration) bool {
+	if len(cfg.Chain.Protocols) <= 0 {
+		return !r.OptIn
+	}
+
+	for _, v := range cfg.Chain.Protocols {
+		if strings.EqualFold(v, r.Protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainGroup returns chain group of config, evm by default
+func ChainGroup(cfg *config.Config) model.ChainGroup {
+	if cfg.Chain.ChainGroup == "" {
+		return model.EvmChainGroup
+	}
+	return cfg.Chain.ChainGroup
+}
+
+func lookup(protocol string) *instance {
+	if ins, ok := protocols[protocol]; ok {
+		return ins
+	}
+	return fallback
 }
 
 func GetProtocol(cfg *config.Config, tx *xycommon.RpcTransaction) (types.IProtocol, *devents.MetaData) {
@@ -55,22 +178,141 @@ func GetProtocol(cfg *config.Config, tx *xycommon.RpcTransaction) (types.IProtoc
 		return nil, nil
 	}
 
-	// btc types protocols
-	if cfg.Chain.ChainGroup == model.BtcChainGroup {
-		switch md.Protocol {
-		case types.BRC20Protocol:
-			return BTCBrc20Protocol, md
-		}
+	ins := lookup(md.Protocol)
+	if ins == nil {
 		return nil, nil
 	}
+	return ins.protocol, md
+}
+
+// Part metadata of data carried by a tx & the protocol handling it
+type Part struct {
+	Protocol types.IProtocol
+	MD       *devents.MetaData
+}
+
+// GetProtocols
+/***************************************
+ * protocols of all data carried by the tx, the metadata of GetProtocol first
+ * token data uris are ethscription creations too if ethscriptions enabled
+ * wrapped token movements of bridges last, after the calldata & event transfers backing them
+ ***************************************/
+func GetProtocols(cfg *config.Config, tx *xycommon.RpcTransaction) []*Part {
+	var parts []*Part
+	pt, md := GetProtocol(cfg, tx)
+	if pt != nil {
+		parts = append(parts, &Part{Protocol: pt, MD: md})
+		if ins, ok := protocols[types.EthscriptionsProtocol]; ok && md.Protocol != types.EthscriptionsProtocol {
+			if emd := ethscription.ParseMetaData(cfg.Chain.ChainName, tx.Input, MaxDataURISize); emd != nil && emd.Operate == devents.OperateCreate {
+				parts = append(parts, &Part{Protocol: ins.protocol, MD: emd})
+			}
+		}
+	}
+
+	for _, wmd := range bridge.ParseMetaData(cfg.Chain.ChainName, tx) {
+		if pt != nil && md.Operate == devents.OperateWrap && md.Protocol == wmd.Protocol {
+			continue
+		}
+
+		if ins := lookup(wmd.Protocol); ins != nil {
+			parts = append(parts, &Part{Protocol: ins.protocol, MD: wmd})
+		}
+	}
+	return parts
+}
+
+// Rules returns the latest rule profile of the protocol, default rules if not enabled
+func Rules(protocol string) *config.ProtocolRules {
+	ins := lookup(protocol)
+	if ins == nil {
+		return types.DefaultRules
+	}
+	return ins.rules.Latest().Rules
+}
+
+// RulesAt returns the rule profile of the protocol active as of the block height
+func RulesAt(protocol string, height uint64) *config.ProtocolRules {
+	ins := lookup(protocol)
+	if ins == nil {
+		return types.DefaultRules
+	}
+	return ins.rules.At(height).Rules
+}
+
+// ContractCheckRequired reports whether any enabled protocol ignores calldata sent to contracts
+func ContractCheckRequired() bool {
+	for _, ins := range protocols {
+		for _, fork := range ins.rules {
+			if !fork.Rules.ContractCalldata {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OperateSupported reports whether the md operate declared by its protocol
+func OperateSupported(md *devents.MetaData) bool {
+	ins := lookup(md.Protocol)
+	if ins == nil {
+		return false
+	}
+	return ins.Supports(md.Operate)
+}
+
+// FastCheck reports whether the tx may carry data of any enabled protocol
+func FastCheck(tx *xycommon.RpcTransaction) bool {
+	if market.FastCheck(tx) || bridge.FastCheck(tx) {
+		return true
+	}
+
+	for _, ins := range protocols {
+		if ins.FastCheck != nil && ins.FastCheck(tx) {
+			return true
+		}
+		if checker, ok := ins.protocol.(types.IFastChecker); ok && checker.FastCheck(tx) {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTopics returns event topics of all enabled protocols
+func EventTopics() []string {
+	// filled order events of configured marketplaces & events of bridge wrappers
+	items := append(market.EventTopics(), bridge.EventTopics()...)
+	if chainGroup == model.EvmChainGroup {
+		// executions of user operations & safe txs checked by unwrapping
+		items = append(items, smartaccount.EventTopics()...)
+	}
+	for _, ins := range protocols {
+		items = append(items, ins.EventTopics...)
+	}
+
+	exists := make(map[string]struct{}, len(items))
+	topics := make([]string, 0, len(items))
+	for _, topic := range items {
+		topic = strings.ToLower(topic)
+		if _, ok := exists[topic]; ok {
+			continue
+		}
+		exists[topic] = struct{}{}
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
 
-	// default protocols: evm
-	switch md.Protocol {
-	case types.ASC20Protocol:
-		return EvmAsc20Protocol, md
-	default:
-		return EvmBrc20Protocol, md
+// UnwrapAccountCalls
+/***************************************
+ * unwrap payloads of smart account bundles & safe txs, a tx of each executed op attributed to its account
+ * evm chains only, returns nil if the tx is not unwrapped
+ ***************************************/
+func UnwrapAccountCalls(tx *xycommon.RpcTransaction) []*xycommon.RpcTransaction {
+	if chainGroup != model.EvmChainGroup {
+		return nil
 	}
+	return smartaccount.Unwrap(tx)
 }
 
 func GetOperateByTxInput(chain, inputData string, db *storage.DBClient) *devents.MetaData {
@@ -0,0 +1,212 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package protocol_test
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/avax/asc20"
+	"github.com/uxuycom/indexer/protocol/evm/brc20"
+	"github.com/uxuycom/indexer/protocol/smartaccount"
+	"github.com/uxuycom/indexer/protocol/testutil"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func TestGetProtocol(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}}
+	protocol.InitProtocols(cfg, dcache.NewManager(nil, model.ChainAVAX))
+
+	pt, md := protocol.GetProtocol(cfg, &xycommon.RpcTransaction{Input: testutil.InputOf(`data:,{"p":"asc-20","op":"mint","tick":"avav","amt":"1"}`)})
+	assert.IsType(t, &asc20.Protocol{}, pt)
+	assert.True(t, protocol.OperateSupported(md))
+
+	// unregistered protocol ids fall back to brc-20 rules
+	pt, md = protocol.GetProtocol(cfg, &xycommon.RpcTransaction{Input: testutil.InputOf(`data:,{"p":"xyz-20","op":"mint","tick":"avav","amt":"1"}`)})
+	assert.IsType(t, &brc20.Protocol{}, pt)
+	assert.True(t, protocol.OperateSupported(md))
+
+	// list is declared by asc-20 only
+	_, md = protocol.GetProtocol(cfg, &xycommon.RpcTransaction{Input: testutil.InputOf(`data:,{"p":"xyz-20","op":"list","tick":"avav","amt":"1"}`)})
+	assert.False(t, protocol.OperateSupported(md))
+
+	assert.True(t, protocol.FastCheck(&xycommon.RpcTransaction{Input: testutil.InputOf(`data:,{}`)}))
+	assert.False(t, protocol.FastCheck(&xycommon.RpcTransaction{Input: "0xa9059cbb"}))
+	// asc-20 marketplace events are indexed by configured adapters only, execution events of smart accounts on evm chains
+	assert.ElementsMatch(t, smartaccount.EventTopics(), protocol.EventTopics())
+}
+
+func TestInitProtocolsByConfig(t *testing.T) {
+	cfg := &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX, Protocols: []string{"asc-20"}}}
+	protocol.InitProtocols(cfg, dcache.NewManager(nil, model.ChainAVAX))
+
+	pt, _ := protocol.GetProtocol(cfg, &xycommon.RpcTransaction{Input: testutil.InputOf(`data:,{"p":"brc-20","op":"mint","tick":"ordi","amt":"1"}`)})
+	assert.Nil(t, pt)
+
+	// btc group
+	cfg = &config.Config{Chain: config.ChainConfig{ChainName: model.ChainBTC, ChainGroup: model.BtcChainGroup}}
+	protocol.InitProtocols(cfg, dcache.NewManager(nil, model.ChainBTC))
+	assert.False(t, protocol.FastCheck(&xycommon.RpcTransaction{Input: "0x"}))
+	assert.Len(t, protocol.EventTopics(), 0)
+}
+
+func TestRuleProfiles(t *testing.T) {
+	cfg := &config.Config{
+		Chain: config.ChainConfig{
+			ChainName: "polygon",
+			Protocols: []string{"brc-20", "bsc-20", "prc-20"},
+			Rules:     map[string]string{"brc-20": "brc-20-cs"},
+		},
+		RuleProfiles: map[string]*config.ProtocolRules{
+			"brc-20-cs": {MaxDecimals: 8, ContractCalldata: true, CaseSensitive: true},
+		},
+	}
+	h := testutil.NewHarness(t, cfg)
+
+	// prc-20 ticks are 4 characters
+	_, err := h.Inscribe("", "", `data:,{"p":"prc-20","op":"deploy","tick":"abcde","max":"100","lim":"1"}`)
+	assert.Equal(t, -22, testutil.CauseCode(err))
+
+	// bsc-20 mints are self inscriptions
+	_, err = h.Inscribe("", "", `data:,{"p":"bsc-20","op":"deploy","tick":"bnbs","max":"100","lim":"1"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe("0x01", "0x02", `data:,{"p":"bsc-20","op":"mint","tick":"bnbs","amt":"1"}`)
+	assert.Equal(t, -23, testutil.CauseCode(err))
+
+	// bsc-20 ignores calldata sent to contracts
+	tx := testutil.CalldataAt(1, "0x01", "0x01", `data:,{"p":"bsc-20","op":"mint","tick":"bnbs","amt":"1"}`)
+	tx.ToContract = true
+	_, _, err = h.Handle(tx)
+	assert.Equal(t, -21, testutil.CauseCode(err))
+
+	// custom profile: case sensitive ticks & max 8 decimals
+	md, _, err := h.Handle(testutil.CalldataAt(1, "", "", `data:,{"p":"brc-20","op":"deploy","tick":"PePe","max":"100","lim":"1","dec":"9"}`))
+	assert.Equal(t, "PePe", md.Tick)
+	assert.Equal(t, -18, testutil.CauseCode(err))
+
+	// forks are opt-in, handled by the brc-20 fallback with its rules if not enabled
+	h = testutil.NewHarness(t, &config.Config{Chain: config.ChainConfig{ChainName: "polygon"}})
+	_, err = h.Inscribe("", "", `data:,{"p":"bsc-20","op":"deploy","tick":"bnbs","max":"100","lim":"1"}`)
+	assert.Nil(t, err)
+	_, err = h.Inscribe("0x01", "0x02", `data:,{"p":"bsc-20","op":"mint","tick":"bnbs","amt":"1"}`)
+	assert.Nil(t, err)
+}
+
+func TestRuleForks(t *testing.T) {
+	cfg := &config.Config{
+		Chain: config.ChainConfig{
+			ChainName: model.ChainAVAX,
+			Forks: map[string][]*config.RuleForkConfig{
+				"asc-20": {{Height: 100, Profile: "asc-20-v2", Version: "v2"}},
+			},
+		},
+		RuleProfiles: map[string]*config.ProtocolRules{
+			"asc-20-v2": {MaxDecimals: 8, ContractCalldata: true},
+		},
+	}
+	h := testutil.NewHarness(t, cfg)
+
+	deploy := func(height int64, tick, dec string) (*devents.MetaData, *xyerrors.InsError) {
+		md, _, err := h.Handle(testutil.CalldataAt(height, "", "", `data:,{"p":"asc-20","op":"deploy","tick":"`+tick+`","max":"100","lim":"1","dec":"`+dec+`"}`))
+		return md, err
+	}
+
+	// rules before the fork
+	md, err := deploy(99, "old1", "18")
+	assert.Nil(t, err)
+	assert.Equal(t, "asc-20", md.RuleVersion)
+
+	// max 8 decimals from the fork height
+	md, err = deploy(100, "new1", "18")
+	assert.Equal(t, -18, testutil.CauseCode(err))
+	assert.Equal(t, "v2", md.RuleVersion)
+	_, err = deploy(101, "new2", "8")
+	assert.Nil(t, err)
+
+	assert.Equal(t, int64(18), protocol.RulesAt("asc-20", 0).MaxDecimals)
+	assert.Equal(t, int64(8), protocol.Rules("asc-20").MaxDecimals)
+}
+
+func TestTickNormalization(t *testing.T) {
+	cfg := &config.Config{
+		Chain: config.ChainConfig{
+			ChainName: model.ChainAVAX,
+			Rules:     map[string]string{"brc-20": "brc-20-emoji", "asc-20": "asc-20-confusables"},
+		},
+		RuleProfiles: map[string]*config.ProtocolRules{
+			"brc-20-emoji":       {TickMaxLength: 8, TickLengthUnit: utils.TickLengthByte, MaxDecimals: 18, ContractCalldata: true, Confusables: types.ConfusableFlag},
+			"asc-20-confusables": {MaxDecimals: 18, ContractCalldata: true, Confusables: types.ConfusableReject},
+		},
+	}
+	h := testutil.NewHarness(t, cfg)
+
+	deploy := func(id, tick string) ([]*devents.TxResult, *xyerrors.InsError) {
+		return h.Inscribe("", "", `data:,{"p":"`+id+`","op":"deploy","tick":"`+tick+`","max":"100","lim":"1"}`)
+	}
+
+	// canonical composition: decomposed & composed ticks are the same
+	_, err := deploy("asc-20", "cafe\u0301")
+	assert.Nil(t, err)
+	_, err = deploy("asc-20", "CAFÉ")
+	assert.Equal(t, -15, testutil.CauseCode(err))
+	ok, _ := h.Cache.Inscription.Get("asc-20", "café")
+	assert.True(t, ok)
+
+	// homoglyph & compatibility variants rejected
+	_, err = deploy("asc-20", "pepe")
+	assert.Nil(t, err)
+	_, err = deploy("asc-20", "реpe")
+	assert.Equal(t, -40, testutil.CauseCode(err))
+	_, err = deploy("asc-20", "ｐｅｐｅ")
+	assert.Equal(t, -40, testutil.CauseCode(err))
+
+	// byte length rule & flagged confusable deploys
+	_, err = deploy("brc-20", "\U0001F525\U0001F525\U0001F525")
+	assert.Equal(t, -22, testutil.CauseCode(err))
+	_, err = deploy("brc-20", "❤")
+	assert.Nil(t, err)
+	results, err := deploy("brc-20", "❤️")
+	assert.Nil(t, err)
+	assert.Equal(t, "❤", results[0].Deploy.Confusable)
+
+	// confusable checking is opt-in by profiles
+	h = testutil.NewHarness(t, &config.Config{Chain: config.ChainConfig{ChainName: model.ChainAVAX}})
+	_, err = deploy("asc-20", "pepe")
+	assert.Nil(t, err)
+	results, err = deploy("asc-20", "реpe")
+	assert.Nil(t, err)
+	assert.Equal(t, "", results[0].Deploy.Confusable)
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package smartaccount
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/utils"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+const (
+	// WrapperERC4337 calldata of user operations bundled by EntryPoint.handleOps
+	WrapperERC4337 = "erc4337"
+
+	// WrapperSafe calldata of multisig execTransaction
+	WrapperSafe = "safe"
+
+	// MaxDepth max nested wrappers unwrapped, e.g. a safe executed by a user operation
+	MaxDepth = 3
+)
+
+// methodsABI
+/***************************************
+ * EntryPoint v0.6 & v0.7 bundles, safe multisig txs & execute calls of common smart accounts
+ ***************************************/
+const methodsABI = `[
+	{"type":"function","name":"handleOps","inputs":[
+		{"name":"ops","type":"tuple[]","components":[
+			{"name":"sender","type":"address"},
+			{"name":"nonce","type":"uint256"},
+			{"name":"initCode","type":"bytes"},
+			{"name":"callData","type":"bytes"},
+			{"name":"callGasLimit","type":"uint256"},
+			{"name":"verificationGasLimit","type":"uint256"},
+			{"name":"preVerificationGas","type":"uint256"},
+			{"name":"maxFeePerGas","type":"uint256"},
+			{"name":"maxPriorityFeePerGas","type":"uint256"},
+			{"name":"paymasterAndData","type":"bytes"},
+			{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}]},
+	{"type":"function","name":"handlePackedOps","inputs":[
+		{"name":"ops","type":"tuple[]","components":[
+			{"name":"sender","type":"address"},
+			{"name":"nonce","type":"uint256"},
+			{"name":"initCode","type":"bytes"},
+			{"name":"callData","type":"bytes"},
+			{"name":"accountGasLimits","type":"bytes32"},
+			{"name":"preVerificationGas","type":"uint256"},
+			{"name":"gasFees","type":"bytes32"},
+			{"name":"paymasterAndData","type":"bytes"},
+			{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}]},
+	{"type":"function","name":"execTransaction","inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},
+		{"name":"safeTxGas","type":"uint256"},
+		{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},
+		{"name":"gasToken","type":"address"},
+		{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}]},
+	{"type":"function","name":"execute","inputs":[
+		{"name":"dest","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"func","type":"bytes"}]},
+	{"type":"function","name":"executeOperation","inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"}]},
+	{"type":"function","name":"executeUserOp","inputs":[
+		{"name":"to","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"}]},
+	{"type":"function","name":"executeBatch","inputs":[
+		{"name":"dest","type":"address[]"},
+		{"name":"func","type":"bytes[]"}]},
+	{"type":"function","name":"executeValueBatch","inputs":[
+		{"name":"dest","type":"address[]"},
+		{"name":"value","type":"uint256[]"},
+		{"name":"func","type":"bytes[]"}]}
+]`
+
+// eventsABI
+/***************************************
+ * executions of user operations by EntryPoint & safe txs by the safe
+ ***************************************/
+const eventsABI = `[
+	{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[
+		{"name":"userOpHash","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"paymaster","type":"address","indexed":true},
+		{"name":"nonce","type":"uint256","indexed":false},
+		{"name":"success","type":"bool","indexed":false},
+		{"name":"actualGasCost","type":"uint256","indexed":false},
+		{"name":"actualGasUsed","type":"uint256","indexed":false}]},
+	{"type":"event","name":"ExecutionSuccess","anonymous":false,"inputs":[
+		{"name":"txHash","type":"bytes32","indexed":false},
+		{"name":"payment","type":"uint256","indexed":false}]},
+	{"type":"event","name":"ExecutionFailure","anonymous":false,"inputs":[
+		{"name":"txHash","type":"bytes32","indexed":false},
+		{"name":"payment","type":"uint256","indexed":false}]}
+]`
+
+// DefaultEntryPoints EntryPoint v0.6 & v0.7 contracts
+var DefaultEntryPoints = []string{
+	"0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789",
+	"0x0000000071727De22E5E9d8BAf0edAc6f37da032",
+}
+
+// Call inner call of a wrapper, the sender is the smart account
+type Call struct {
+	From  string
+	To    string
+	Value *big.Int
+	Data  []byte
+}
+
+var (
+	methods = mustParseABI(methodsABI)
+	events  = mustParseABI(eventsABI)
+
+	// bundles of other contracts are not unwrapped, the ops are not executed by them
+	entryPoints = addressSet(DefaultEntryPoints)
+
+	// packed user operations share the method name with v0.6 bundles
+	handlePackedOps = withName(methods.Methods["handlePackedOps"], "handleOps")
+
+	// kernel execute(address,uint256,bytes,uint8) shares the method name with execute(address,uint256,bytes)
+	executeOperation = withName(methods.Methods["executeOperation"], "execute")
+
+	// simple account v0.7 executeBatch(address[],uint256[],bytes[])
+	executeValueBatch = withName(methods.Methods["executeValueBatch"], "executeBatch")
+)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("smart account abi decode err:%v", err))
+	}
+	return parsed
+}
+
+func addressSet(items []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		set[strings.ToLower(item)] = struct{}{}
+	}
+	return set
+}
+
+// Init sets EntryPoint contracts of unwrapped bundles, DefaultEntryPoints if empty
+func Init(items []string) error {
+	if len(items) == 0 {
+		items = DefaultEntryPoints
+	}
+
+	for _, item := range items {
+		if !ethcommon.IsHexAddress(item) {
+			return fmt.Errorf("entry point[%s] invalid", item)
+		}
+	}
+	entryPoints = addressSet(items)
+	return nil
+}
+
+// EventTopics returns topics of the execution events checked by Unwrap
+func EventTopics() []string {
+	return []string{
+		strings.ToLower(events.Events["UserOperationEvent"].ID.Hex()),
+		strings.ToLower(events.Events["ExecutionSuccess"].ID.Hex()),
+		strings.ToLower(events.Events["ExecutionFailure"].ID.Hex()),
+	}
+}
+
+// withName method of another name & the same inputs, selectors are derived from names
+func withName(m abi.Method, name string) abi.Method {
+	return abi.NewMethod(name, name, m.Type, m.StateMutability, m.Constant, m.Payable, m.Inputs, m.Outputs)
+}
+
+// Unwrap
+/***************************************
+ * unwrap account abstraction bundles & safe txs carrying data payloads
+ * a tx of each payload call, attributed to the smart account & the inner call, the first payload call of each op
+ * bundles are unwrapped if sent to an EntryPoint, only ops of successful UserOperationEvent logs
+ * safe txs are unwrapped only if the safe emitted ExecutionSuccess & no ExecutionFailure
+ * returns nil if the tx is not a known wrapper of executed payloads, the tx itself is not changed
+ ***************************************/
+func Unwrap(tx *xycommon.RpcTransaction) []*xycommon.RpcTransaction {
+	if !strings.HasPrefix(tx.Input, "0x") || len(tx.Input) < 10 {
+		return nil
+	}
+
+	input, err := hex.DecodeString(tx.Input[2:])
+	if err != nil {
+		return nil
+	}
+
+	wrapper, calls := unwrap(tx.To, input, tx.Events, 0)
+	if len(calls) == 0 {
+		return nil
+	}
+
+	items := make([]*xycommon.RpcTransaction, 0, len(calls))
+	for _, call := range calls {
+		item := *tx
+		item.Wrapper = wrapper
+		item.From = call.From
+		item.To = call.To
+		item.Value = call.Value
+		item.Input = "0x" + hex.EncodeToString(call.Data)
+		items = append(items, &item)
+	}
+	return items
+}
+
+// unwrap wrapper calls to the contract by selector, nested wrappers unwrapped up to MaxDepth
+func unwrap(contract string, input []byte, logs []xycommon.RpcLog, depth int) (string, []*Call) {
+	if depth >= MaxDepth || len(input) < 4 {
+		return "", nil
+	}
+
+	var (
+		wrapper string
+		ops     [][]*Call
+	)
+	selector := input[:4]
+	switch {
+	case bytes.Equal(selector, methods.Methods["handleOps"].ID), bytes.Equal(selector, handlePackedOps.ID):
+		if _, ok := entryPoints[strings.ToLower(contract)]; !ok {
+			return "", nil
+		}
+		wrapper = WrapperERC4337
+		ops = userOperations(contract, input, logs)
+	case bytes.Equal(selector, methods.Methods["execTransaction"].ID):
+		if !safeExecuted(contract, logs) {
+			return "", nil
+		}
+		wrapper = WrapperSafe
+		ops = [][]*Call{safeTransaction(contract, input)}
+	default:
+		return "", nil
+	}
+
+	var payloads []*Call
+	for _, calls := range ops {
+		payloads = append(payloads, firstPayload(calls, logs, depth)...)
+	}
+	if len(payloads) == 0 {
+		return "", nil
+	}
+	return wrapper, payloads
+}
+
+// firstPayload the first payload call of an op, payloads of wrappers called by the op if nested
+func firstPayload(calls []*Call, logs []xycommon.RpcLog, depth int) []*Call {
+	for _, call := range calls {
+		if isPayload(call.Data) {
+			return []*Call{call}
+		}
+
+		// safes executed by user operations & the reverse
+		if _, inner := unwrap(call.To, call.Data, logs, depth+1); len(inner) > 0 {
+			return inner
+		}
+	}
+	return nil
+}
+
+// userOperations inner calls of the bundled user operations executed successfully, by op
+func userOperations(entryPoint string, input []byte, logs []xycommon.RpcLog) [][]*Call {
+	var method abi.Method
+	if bytes.Equal(input[:4], handlePackedOps.ID) {
+		method = handlePackedOps
+	} else {
+		method = methods.Methods["handleOps"]
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil || len(args) < 1 {
+		return nil
+	}
+
+	ops := reflect.ValueOf(args[0])
+	if ops.Kind() != reflect.Slice {
+		return nil
+	}
+
+	items := make([][]*Call, 0, ops.Len())
+	for i := 0; i < ops.Len(); i++ {
+		op := ops.Index(i)
+		sender, ok1 := op.FieldByName("Sender").Interface().(ethcommon.Address)
+		nonce, ok2 := op.FieldByName("Nonce").Interface().(*big.Int)
+		callData, ok3 := op.FieldByName("CallData").Interface().([]byte)
+		if !ok1 || !ok2 || !ok3 || !opSucceeded(entryPoint, sender, nonce, logs) {
+			continue
+		}
+		items = append(items, accountCalls(sender, callData))
+	}
+	return items
+}
+
+// opSucceeded reports whether the EntryPoint emitted a successful UserOperationEvent of the op
+func opSucceeded(entryPoint string, sender ethcommon.Address, nonce *big.Int, logs []xycommon.RpcLog) bool {
+	event := events.Events["UserOperationEvent"]
+	for _, log := range logs {
+		if !strings.EqualFold(log.Address.Hex(), entryPoint) || len(log.Topics) != 4 || log.Topics[0] != event.ID {
+			continue
+		}
+
+		if ethcommon.BytesToAddress(log.Topics[2].Bytes()) != sender {
+			continue
+		}
+
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil || len(values) < 2 {
+			continue
+		}
+
+		if n, ok := values[0].(*big.Int); ok && n.Cmp(nonce) == 0 {
+			success, _ := values[1].(bool)
+			return success
+		}
+	}
+	return false
+}
+
+// safeExecuted reports whether the safe emitted ExecutionSuccess & no ExecutionFailure
+func safeExecuted(safe string, logs []xycommon.RpcLog) bool {
+	executed := false
+	for _, log := range logs {
+		if !strings.EqualFold(log.Address.Hex(), safe) || len(log.Topics) == 0 {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case events.Events["ExecutionSuccess"].ID:
+			executed = true
+		case events.Events["ExecutionFailure"].ID:
+			return false
+		}
+	}
+	return executed
+}
+
+// accountCalls
+/***************************************
+ * calls of the smart account by user operation calldata
+ * calldata of the account's own payload is a self call
+ ***************************************/
+func accountCalls(sender ethcommon.Address, callData []byte) []*Call {
+	from := strings.ToLower(sender.Hex())
+	if isPayload(callData) {
+		return []*Call{{From: from, To: from, Value: big.NewInt(0), Data: callData}}
+	}
+
+	if len(callData) < 4 {
+		return nil
+	}
+
+	selector := callData[:4]
+	switch {
+	case bytes.Equal(selector, methods.Methods["execute"].ID):
+		args, err := methods.Methods["execute"].Inputs.Unpack(callData[4:])
+		if err != nil {
+			return nil
+		}
+		return []*Call{newCall(from, args[0], args[1], args[2])}
+	case bytes.Equal(selector, executeOperation.ID), bytes.Equal(selector, methods.Methods["executeUserOp"].ID):
+		method := executeOperation
+		if bytes.Equal(selector, methods.Methods["executeUserOp"].ID) {
+			method = methods.Methods["executeUserOp"]
+		}
+
+		// delegate calls run code of the target in the account, not calls
+		args, err := method.Inputs.Unpack(callData[4:])
+		if err != nil || args[3].(uint8) != 0 {
+			return nil
+		}
+		return []*Call{newCall(from, args[0], args[1], args[2])}
+	case bytes.Equal(selector, methods.Methods["executeBatch"].ID):
+		args, err := methods.Methods["executeBatch"].Inputs.Unpack(callData[4:])
+		if err != nil {
+			return nil
+		}
+		return batchCalls(from, args[0].([]ethcommon.Address), nil, args[1].([][]byte))
+	case bytes.Equal(selector, executeValueBatch.ID):
+		args, err := executeValueBatch.Inputs.Unpack(callData[4:])
+		if err != nil {
+			return nil
+		}
+		return batchCalls(from, args[0].([]ethcommon.Address), args[1].([]*big.Int), args[2].([][]byte))
+	}
+	return nil
+}
+
+func batchCalls(from string, dests []ethcommon.Address, values []*big.Int, data [][]byte) []*Call {
+	if len(dests) != len(data) || (values != nil && len(values) != len(data)) {
+		return nil
+	}
+
+	calls := make([]*Call, 0, len(dests))
+	for i := range dests {
+		value := big.NewInt(0)
+		if values != nil {
+			value = values[i]
+		}
+		calls = append(calls, newCall(from, dests[i], value, data[i]))
+	}
+	return calls
+}
+
+// safeTransaction inner call of execTransaction, the safe is the sender
+func safeTransaction(safe string, input []byte) []*Call {
+	if !ethcommon.IsHexAddress(safe) {
+		return nil
+	}
+
+	args, err := methods.Methods["execTransaction"].Inputs.Unpack(input[4:])
+	if err != nil || args[3].(uint8) != 0 {
+		return nil
+	}
+	return []*Call{newCall(strings.ToLower(safe), args[0], args[1], args[2])}
+}
+
+func newCall(from string, to, value, data interface{}) *Call {
+	return &Call{
+		From:  from,
+		To:    strings.ToLower(to.(ethcommon.Address).Hex()),
+		Value: value.(*big.Int),
+		Data:  data.([]byte),
+	}
+}
+
+// isPayload data uri & ESIP-7 gzip compressed payloads
+func isPayload(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("data:")) || utils.IsGzipped(data)
+}
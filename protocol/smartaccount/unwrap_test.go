@@ -0,0 +1,289 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package smartaccount
+
+import (
+	"encoding/hex"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"math/big"
+	"testing"
+)
+
+type userOperation struct {
+	Sender               ethcommon.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+type packedUserOperation struct {
+	Sender             ethcommon.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+var (
+	bundler    = ethcommon.HexToAddress("0x00000000000000000000000000000000000000b1")
+	entryPoint = "0x5ff137d4b0fdcd49dca30c7cf57e578a026d2789"
+	account    = ethcommon.HexToAddress("0x00000000000000000000000000000000000000a1")
+	safe       = ethcommon.HexToAddress("0x00000000000000000000000000000000000000c1")
+	receiver   = ethcommon.HexToAddress("0x00000000000000000000000000000000000000d1")
+	payload    = []byte(`data:,{"p":"asc-20","op":"mint","tick":"avav","amt":"1"}`)
+)
+
+func pack(t *testing.T, method string, args ...interface{}) []byte {
+	data, err := methods.Pack(method, args...)
+	assert.Nil(t, err)
+	return data
+}
+
+func newOp(callData []byte) userOperation {
+	return userOperation{
+		Sender:               account,
+		Nonce:                big.NewInt(0),
+		CallData:             callData,
+		CallGasLimit:         big.NewInt(0),
+		VerificationGasLimit: big.NewInt(0),
+		PreVerificationGas:   big.NewInt(0),
+		MaxFeePerGas:         big.NewInt(0),
+		MaxPriorityFeePerGas: big.NewInt(0),
+	}
+}
+
+// bundle handleOps tx to the EntryPoint, all ops executed successfully
+func bundle(t *testing.T, ops ...userOperation) *xycommon.RpcTransaction {
+	tx := &xycommon.RpcTransaction{
+		From:  bundler.Hex(),
+		To:    entryPoint,
+		Input: "0x" + hex.EncodeToString(pack(t, "handleOps", ops, bundler)),
+	}
+	for _, op := range ops {
+		tx.Events = append(tx.Events, opEvent(t, op.Sender, op.Nonce, true))
+	}
+	return tx
+}
+
+func opEvent(t *testing.T, sender ethcommon.Address, nonce *big.Int, success bool) xycommon.RpcLog {
+	event := events.Events["UserOperationEvent"]
+	data, err := event.Inputs.NonIndexed().Pack(nonce, success, big.NewInt(0), big.NewInt(0))
+	assert.Nil(t, err)
+	return xycommon.RpcLog{
+		Address: ethcommon.HexToAddress(entryPoint),
+		Topics:  []ethcommon.Hash{event.ID, {}, ethcommon.BytesToHash(sender.Bytes()), {}},
+		Data:    data,
+	}
+}
+
+func safeEvent(t *testing.T, name string) xycommon.RpcLog {
+	event := events.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack([32]byte{}, big.NewInt(0))
+	assert.Nil(t, err)
+	return xycommon.RpcLog{Address: safe, Topics: []ethcommon.Hash{event.ID}, Data: data}
+}
+
+func safeTx(t *testing.T, to ethcommon.Address, data []byte, operation uint8) []byte {
+	return pack(t, "execTransaction", to, big.NewInt(0), data, operation, big.NewInt(0), big.NewInt(0), big.NewInt(0), ethcommon.Address{}, ethcommon.Address{}, []byte{})
+}
+
+func TestSelectors(t *testing.T) {
+	assert.Equal(t, "1fad948c", hex.EncodeToString(methods.Methods["handleOps"].ID))
+	assert.Equal(t, "765e827f", hex.EncodeToString(handlePackedOps.ID))
+	assert.Equal(t, "6a761202", hex.EncodeToString(methods.Methods["execTransaction"].ID))
+	assert.Equal(t, "b61d27f6", hex.EncodeToString(methods.Methods["execute"].ID))
+	assert.Equal(t, "51945447", hex.EncodeToString(executeOperation.ID))
+	assert.Equal(t, "7bb37428", hex.EncodeToString(methods.Methods["executeUserOp"].ID))
+	assert.Equal(t, "18dfb3c7", hex.EncodeToString(methods.Methods["executeBatch"].ID))
+	assert.Equal(t, "47e1da2a", hex.EncodeToString(executeValueBatch.ID))
+}
+
+func TestUnwrapUserOperations(t *testing.T) {
+	self := hex.EncodeToString(payload)
+	accountHex := "0x00000000000000000000000000000000000000a1"
+	receiverHex := "0x00000000000000000000000000000000000000d1"
+
+	// execute(dest, value, func)
+	tx := bundle(t, newOp(pack(t, "execute", account, big.NewInt(7), payload)))
+	items := Unwrap(tx)
+	assert.Len(t, items, 1)
+	assert.Equal(t, WrapperERC4337, items[0].Wrapper)
+	assert.Equal(t, accountHex, items[0].From)
+	assert.Equal(t, accountHex, items[0].To)
+	assert.Equal(t, int64(7), items[0].Value.Int64())
+	assert.Equal(t, "0x"+self, items[0].Input)
+
+	// the bundle tx itself is kept
+	assert.Equal(t, bundler.Hex(), tx.From)
+	assert.Equal(t, entryPoint, tx.To)
+	assert.Empty(t, tx.Wrapper)
+
+	// payload as the account calldata is a self call
+	items = Unwrap(bundle(t, newOp(payload)))
+	assert.Len(t, items, 1)
+	assert.Equal(t, accountHex, items[0].To)
+
+	// the first payload call of batches, ops without payloads skipped
+	batch := pack(t, "executeBatch", []ethcommon.Address{receiver, receiver}, [][]byte{{0x01}, payload})
+	items = Unwrap(bundle(t, newOp(pack(t, "execute", receiver, big.NewInt(0), []byte{0x01})), newOp(batch)))
+	assert.Len(t, items, 1)
+	assert.Equal(t, receiverHex, items[0].To)
+	assert.Equal(t, "0x"+self, items[0].Input)
+
+	// kernel & safe module executions, delegate calls are not unwrapped
+	kernel := append(append([]byte{}, executeOperation.ID...), pack(t, "executeOperation", receiver, big.NewInt(0), payload, uint8(0))[4:]...)
+	items = Unwrap(bundle(t, newOp(kernel)))
+	assert.Len(t, items, 1)
+	assert.Equal(t, receiverHex, items[0].To)
+	assert.Empty(t, Unwrap(bundle(t, newOp(pack(t, "executeUserOp", receiver, big.NewInt(0), payload, uint8(1))))))
+
+	// v0.7 packed user operations
+	packed := packedUserOperation{
+		Sender:             account,
+		Nonce:              big.NewInt(0),
+		CallData:           pack(t, "execute", account, big.NewInt(0), payload),
+		PreVerificationGas: big.NewInt(0),
+	}
+	data := pack(t, "handlePackedOps", []packedUserOperation{packed}, bundler)
+	tx = &xycommon.RpcTransaction{From: bundler.Hex(), To: entryPoint, Input: "0x" + hex.EncodeToString(append(handlePackedOps.ID, data[4:]...))}
+	tx.Events = []xycommon.RpcLog{opEvent(t, account, big.NewInt(0), true)}
+	items = Unwrap(tx)
+	assert.Len(t, items, 1)
+	assert.Equal(t, accountHex, items[0].From)
+
+	// no payload calls
+	assert.Empty(t, Unwrap(bundle(t, newOp(pack(t, "execute", receiver, big.NewInt(1), []byte{})))))
+
+	// forged bundles to other contracts
+	tx = bundle(t, newOp(payload))
+	tx.To = receiverHex
+	tx.Events[0].Address = receiver
+	assert.Empty(t, Unwrap(tx))
+
+	// bundles without execution events
+	tx = bundle(t, newOp(payload))
+	tx.Events = nil
+	assert.Empty(t, Unwrap(tx))
+
+	// reverted ops are skipped, the executed ops unwrapped
+	reverted := newOp(pack(t, "execute", receiver, big.NewInt(0), payload))
+	executed := newOp(payload)
+	executed.Nonce = big.NewInt(1)
+	tx = bundle(t, reverted, executed)
+	tx.Events[0] = opEvent(t, account, reverted.Nonce, false)
+	items = Unwrap(tx)
+	assert.Len(t, items, 1)
+	assert.Equal(t, accountHex, items[0].To)
+
+	tx = bundle(t, reverted)
+	tx.Events[0] = opEvent(t, account, reverted.Nonce, false)
+	assert.Empty(t, Unwrap(tx))
+}
+
+func TestUnwrapBundleAccounts(t *testing.T) {
+	other := ethcommon.HexToAddress("0x00000000000000000000000000000000000000a2")
+	second := []byte(`data:,{"p":"asc-20","op":"mint","tick":"avav","amt":"2"}`)
+
+	// ops of two accounts, a tx of each executed op attributed to its sender
+	op1 := newOp(pack(t, "execute", receiver, big.NewInt(0), payload))
+	op2 := newOp(second)
+	op2.Sender = other
+	tx := bundle(t, op1, op2)
+	tx.Hash = "0x01"
+	items := Unwrap(tx)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "0x00000000000000000000000000000000000000a1", items[0].From)
+	assert.Equal(t, "0x00000000000000000000000000000000000000d1", items[0].To)
+	assert.Equal(t, "0x"+hex.EncodeToString(payload), items[0].Input)
+	assert.Equal(t, "0x00000000000000000000000000000000000000a2", items[1].From)
+	assert.Equal(t, "0x00000000000000000000000000000000000000a2", items[1].To)
+	assert.Equal(t, "0x"+hex.EncodeToString(second), items[1].Input)
+	for _, item := range items {
+		assert.Equal(t, WrapperERC4337, item.Wrapper)
+		assert.Equal(t, "0x01", item.Hash)
+	}
+
+	// ops of the reverted account skipped
+	tx.Events[1] = opEvent(t, other, op2.Nonce, false)
+	items = Unwrap(tx)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "0x00000000000000000000000000000000000000a1", items[0].From)
+}
+
+func TestUnwrapSafe(t *testing.T) {
+	safeHex := "0x00000000000000000000000000000000000000c1"
+	tx := &xycommon.RpcTransaction{
+		From:   bundler.Hex(),
+		To:     safe.Hex(),
+		Input:  "0x" + hex.EncodeToString(safeTx(t, safe, payload, 0)),
+		Events: []xycommon.RpcLog{safeEvent(t, "ExecutionSuccess")},
+	}
+	items := Unwrap(tx)
+	assert.Len(t, items, 1)
+	assert.Equal(t, WrapperSafe, items[0].Wrapper)
+	assert.Equal(t, safeHex, items[0].From)
+	assert.Equal(t, safeHex, items[0].To)
+	assert.Equal(t, "0x"+hex.EncodeToString(payload), items[0].Input)
+
+	// delegate calls
+	tx.Input = "0x" + hex.EncodeToString(safeTx(t, safe, payload, 1))
+	assert.Empty(t, Unwrap(tx))
+
+	// forged safe txs without execution events & reverted safe txs
+	input := "0x" + hex.EncodeToString(safeTx(t, safe, payload, 0))
+	tx = &xycommon.RpcTransaction{From: bundler.Hex(), To: safe.Hex(), Input: input}
+	assert.Empty(t, Unwrap(tx))
+	tx.Events = []xycommon.RpcLog{safeEvent(t, "ExecutionFailure")}
+	assert.Empty(t, Unwrap(tx))
+	tx.Events = []xycommon.RpcLog{safeEvent(t, "ExecutionSuccess")}
+	tx.Events[0].Address = receiver
+	assert.Empty(t, Unwrap(tx))
+
+	// safe executed by a user operation, attributed to the safe
+	inner := safeTx(t, receiver, payload, 0)
+	tx = bundle(t, newOp(pack(t, "execute", safe, big.NewInt(0), inner)))
+	assert.Empty(t, Unwrap(tx))
+	tx.Events = append(tx.Events, safeEvent(t, "ExecutionSuccess"))
+	items = Unwrap(tx)
+	assert.Len(t, items, 1)
+	assert.Equal(t, WrapperERC4337, items[0].Wrapper)
+	assert.Equal(t, safeHex, items[0].From)
+	assert.Equal(t, "0x00000000000000000000000000000000000000d1", items[0].To)
+
+	// plain calldata
+	tx = &xycommon.RpcTransaction{From: bundler.Hex(), To: safe.Hex(), Input: "0x" + hex.EncodeToString(payload)}
+	assert.Empty(t, Unwrap(tx))
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package testutil
+
+import (
+	"fmt"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/xyerrors"
+	"math/big"
+	"testing"
+)
+
+// Harness
+/*****************************************************
+ * protocols enabled by the config over an in-memory cache, txs handled as the explorer does
+ ****************************************************/
+type Harness struct {
+	Cfg     *config.Config
+	Cache   *dcache.Manager
+	Handler *devents.TxResultHandler
+
+	nonce int
+}
+
+// NewHarness
+/***************************************
+ * init protocols of the config over a fresh cache, protocols reset after the test
+ ***************************************/
+func NewHarness(t *testing.T, cfg *config.Config) *Harness {
+	cache := NewCache(cfg.Chain.ChainName)
+	protocol.InitProtocols(cfg, cache)
+	t.Cleanup(func() {
+		protocol.InitProtocols(&config.Config{}, NewCache(""))
+	})
+	return &Harness{Cfg: cfg, Cache: cache, Handler: devents.NewTxResultHandler(cache)}
+}
+
+// Reload re-init protocols by the config over the same cache
+func (h *Harness) Reload(cfg *config.Config) {
+	h.Cfg = cfg
+	protocol.InitProtocols(cfg, h.Cache)
+}
+
+// Inscribe handle the data uri calldata from -> to at block 1
+func (h *Harness) Inscribe(from, to, data string) ([]*devents.TxResult, *xyerrors.InsError) {
+	return h.InscribeAt(1, from, to, data)
+}
+
+// InscribeAt handle the data uri calldata from -> to at the height
+func (h *Harness) InscribeAt(height int64, from, to, data string) ([]*devents.TxResult, *xyerrors.InsError) {
+	_, results, err := h.Handle(CalldataAt(height, from, to, data))
+	return results, err
+}
+
+// CalldataAt tx of the data uri calldata from -> to at the height
+func CalldataAt(height int64, from, to, data string) *xycommon.RpcTransaction {
+	return &xycommon.RpcTransaction{BlockNumber: big.NewInt(height), From: from, To: to, Input: InputOf(data)}
+}
+
+// Handle
+/***************************************
+ * fast check the tx, parse all its parts & update the cache by the results in order
+ * the block of the tx height, 1 if not set, tx hash assigned if empty
+ * returns metadata of the first part, nil if not an inscription, & the first parse error
+ ***************************************/
+func (h *Harness) Handle(tx *xycommon.RpcTransaction) (*devents.MetaData, []*devents.TxResult, *xyerrors.InsError) {
+	if tx.Hash == "" {
+		h.nonce++
+		tx.Hash = fmt.Sprintf("0x%064x", h.nonce)
+	}
+
+	block := &xycommon.RpcBlock{Number: big.NewInt(1)}
+	if tx.BlockNumber != nil {
+		block.Number = tx.BlockNumber
+	}
+
+	if !protocol.FastCheck(tx) {
+		return nil, nil, nil
+	}
+	parts := protocol.GetProtocols(h.Cfg, tx)
+	if len(parts) <= 0 {
+		return nil, nil, nil
+	}
+
+	var (
+		all   []*devents.TxResult
+		first *xyerrors.InsError
+	)
+	for _, part := range parts {
+		results, err := part.Protocol.Parse(block, tx, part.MD)
+		if first == nil {
+			first = err
+		}
+		for _, r := range results {
+			h.Handler.UpdateCache(r)
+		}
+		all = append(all, results...)
+	}
+	return parts[0].MD, all, first
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+// Package testutil shared helpers of protocol tests
+package testutil
+
+import (
+	"encoding/hex"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/xyerrors"
+)
+
+// NewCache
+/***************************************
+ * in-memory cache manager of all caches, no db loading
+ ***************************************/
+func NewCache(chain string) *dcache.Manager {
+	cache := dcache.NewManager(nil, chain)
+	cache.Balance = dcache.NewBalance()
+	cache.UTXO = dcache.NewUTXO()
+	cache.Inscription = dcache.NewInscription()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	cache.AddressMint = dcache.NewAddressMint()
+	cache.Listing = dcache.NewListing()
+	cache.Ethscription = dcache.NewEthscription()
+	cache.Names = dcache.NewNames()
+	cache.Wrapped = dcache.NewWrapped()
+	cache.Locks = dcache.NewLocks()
+	cache.Market = dcache.NewMarket()
+	return cache
+}
+
+// InputOf hex input of the data
+func InputOf(data string) string {
+	return "0x" + hex.EncodeToString([]byte(data))
+}
+
+// CauseCode code of the wrapped cause error, the error code if not wrapped
+func CauseCode(err *xyerrors.InsError) int {
+	if cause, ok := err.Cause(nil).(*xyerrors.InsError); ok {
+		return cause.Code()
+	}
+	return err.Code()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"github.com/uxuycom/indexer/config"
+	"sort"
+)
+
+// RuleFork rule profile of a protocol activated from the block height
+type RuleFork struct {
+	Height  uint64
+	Version string
+	Rules   *config.ProtocolRules
+}
+
+// RuleSchedule rule forks of a protocol, ascending by activation height
+type RuleSchedule []*RuleFork
+
+// NewRuleSchedule
+/***************************************
+ * build rule schedule by forks, the lowest fork applies to all earlier blocks
+ ***************************************/
+func NewRuleSchedule(forks ...*RuleFork) RuleSchedule {
+	schedule := make(RuleSchedule, 0, len(forks))
+	for _, fork := range forks {
+		if fork != nil && fork.Rules != nil {
+			schedule = append(schedule, fork)
+		}
+	}
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].Height < schedule[j].Height
+	})
+
+	if len(schedule) <= 0 {
+		schedule = append(schedule, &RuleFork{Rules: DefaultRules})
+	}
+	return schedule
+}
+
+// At returns the fork active as of the block height
+func (s RuleSchedule) At(height uint64) *RuleFork {
+	idx := sort.Search(len(s), func(i int) bool {
+		return s[i].Height > height
+	})
+	if idx <= 0 {
+		return s[0]
+	}
+	return s[idx-1]
+}
+
+// Latest returns the fork with the highest activation height
+func (s RuleSchedule) Latest() *RuleFork {
+	return s[len(s)-1]
+}
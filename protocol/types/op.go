@@ -32,9 +32,29 @@ type IProtocol interface {
 	Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError)
 }
 
+// IFastChecker protocols checking raw txs against indexed states, e.g. outputs holding tokens
+type IFastChecker interface {
+	FastCheck(tx *xycommon.RpcTransaction) bool
+}
+
 const (
 	BRC20Protocol = "brc-20"
 	ASC20Protocol = "asc-20"
 	BSC20Protocol = "bsc-20"
 	PRC20Protocol = "prc-20"
+
+	// IERC20Protocol mints are proof of work, hashes meet the deploy difficulty
+	IERC20Protocol = "ierc-20"
+
+	// EthscriptionsProtocol non-fungible data uri inscriptions
+	EthscriptionsProtocol = "ethscriptions"
+
+	// RunesProtocol bitcoin runestones, balances held by utxos
+	RunesProtocol = "runes"
+
+	// CIA20Protocol memo inscriptions of celestia & other cosmos chains
+	CIA20Protocol = "cia-20"
+
+	// NameServiceProtocol name registrations & resolution records
+	NameServiceProtocol = "ans"
 )
@@ -0,0 +1,126 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"fmt"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/model"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registration describes a protocol implementation and the chains it serves.
+// Protocol packages register themselves in init, the indexer enables them per chain.
+type Registration struct {
+	ChainGroup model.ChainGroup
+	Chain      string // empty: all chains in the group
+	Protocol   string
+
+	// Fallback handles protocol ids that have no registration of their own
+	Fallback bool
+
+	// OptIn enabled only if listed in the chain protocols of config
+	OptIn bool
+
+	// Operates supported by the protocol, others are filtered before parsing
+	Operates []string
+
+	// EventTopics the protocol needs to be fetched along with blocks
+	EventTopics []string
+
+	// FastCheck reports whether a raw tx may carry this protocol's data
+	FastCheck func(tx *xycommon.RpcTransaction) bool
+
+	// Rules default rule profile name, protocol id if empty
+	Rules string
+
+	// New creates the protocol instance
+	New func(cache *dcache.Manager, rules RuleSchedule) IProtocol
+}
+
+func (r *Registration) key() string {
+	return fmt.Sprintf("%s_%s_%s", r.ChainGroup, strings.ToLower(r.Chain), strings.ToLower(r.Protocol))
+}
+
+// Match reports whether the registration applies to the chain
+func (r *Registration) Match(group model.ChainGroup, chain string) bool {
+	if r.ChainGroup != group {
+		return false
+	}
+	return r.Chain == "" || strings.EqualFold(r.Chain, chain)
+}
+
+// RulesName returns the default rule profile name
+func (r *Registration) RulesName() string {
+	if r.Rules != "" {
+		return r.Rules
+	}
+	return r.Protocol
+}
+
+// Supports reports whether the operate is declared by the protocol
+func (r *Registration) Supports(operate string) bool {
+	for _, op := range r.Operates {
+		if op == operate {
+			return true
+		}
+	}
+	return false
+}
+
+var registry = &sync.Map{}
+
+// Register
+/***************************************
+ * register protocol, panic on duplicated (chain group, chain, protocol) key
+ ***************************************/
+func Register(r *Registration) {
+	if r == nil || r.New == nil || r.Protocol == "" {
+		panic("protocol registration invalid")
+	}
+
+	if r.ChainGroup == "" {
+		r.ChainGroup = model.EvmChainGroup
+	}
+
+	if _, loaded := registry.LoadOrStore(r.key(), r); loaded {
+		panic(fmt.Sprintf("protocol[%s] registered twice, group[%s], chain[%s]", r.Protocol, r.ChainGroup, r.Chain))
+	}
+}
+
+// Registrations returns all registered protocols
+func Registrations() []*Registration {
+	items := make([]*Registration, 0, 8)
+	registry.Range(func(key, value any) bool {
+		items = append(items, value.(*Registration))
+		return true
+	})
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].key() < items[j].key()
+	})
+	return items
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"github.com/uxuycom/indexer/config"
+)
+
+// confusable tick deploys handling of rule profiles
+const (
+	ConfusableReject = "reject" // deploy rejected
+	ConfusableFlag   = "flag"   // deploy accepted & flagged with the tick it resembles
+)
+
+// proof of work hash of mints
+const (
+	PowHashTx       = "tx"       // tx hash
+	PowHashCalldata = "calldata" // keccak256(sender ++ calldata), work can't be copied by other senders
+)
+
+// DefaultMaxDataSize max payload size of brc-20 like json inscriptions
+const DefaultMaxDataSize = 256
+
+// StrictProfile built-in strict spec-compliance profile of brc-20 like protocols
+const StrictProfile = "strict"
+
+// DefaultRules the rules of brc-20 like protocols without a profile of their own
+var DefaultRules = &config.ProtocolRules{
+	MaxDecimals:      18,
+	ContractCalldata: true,
+	MaxDataSize:      DefaultMaxDataSize,
+}
+
+// RuleProfiles built-in rule profiles, profile name -> rules
+var RuleProfiles = map[string]*config.ProtocolRules{
+	BRC20Protocol: DefaultRules,
+	ASC20Protocol: DefaultRules,
+
+	// bsc-20 mints are self inscriptions
+	BSC20Protocol: {
+		MaxDecimals: 18,
+		SelfMint:    true,
+		MaxDataSize: DefaultMaxDataSize,
+	},
+
+	// official brc-20 indexers grammar, activated by forks
+	StrictProfile: {
+		MaxDecimals:      18,
+		ContractCalldata: true,
+		MaxDataSize:      DefaultMaxDataSize,
+		Strict:           true,
+	},
+
+	// non-fungible data uri inscriptions have no ticks
+	EthscriptionsProtocol: {
+		ContractCalldata: true,
+	},
+
+	// rune names are upper case letters, spec rules are built in
+	RunesProtocol: {
+		CaseSensitive: true,
+	},
+
+	// cia-20 mints are self sends, bech32 chains have no burn addresses
+	CIA20Protocol: {
+		MaxDecimals: 18,
+		SelfMint:    true,
+		MaxDataSize: DefaultMaxDataSize,
+	},
+
+	// ierc-20 mints are proof of work by tx hashes
+	IERC20Protocol: {
+		MaxDecimals:      18,
+		ContractCalldata: true,
+		MaxDataSize:      DefaultMaxDataSize,
+		PowHash:          PowHashTx,
+	},
+
+	// names have no ticks, records of names carry urls & texts
+	NameServiceProtocol: {
+		ContractCalldata: true,
+		MaxDataSize:      4 * DefaultMaxDataSize,
+	},
+
+	// prc-20 ticks are 4 characters & mints are self inscriptions
+	PRC20Protocol: {
+		TickMinLength: 4,
+		TickMaxLength: 4,
+		MaxDecimals:   18,
+		SelfMint:      true,
+		MaxDataSize:   DefaultMaxDataSize,
+	},
+}
+
+// LookupRules
+/***************************************
+ * find rule profile by name, custom profiles of config first
+ ***************************************/
+func LookupRules(cfg *config.Config, name string) (*config.ProtocolRules, bool) {
+	if rules, ok := cfg.RuleProfiles[name]; ok && rules != nil {
+		return rules, true
+	}
+
+	rules, ok := RuleProfiles[name]
+	return rules, ok
+}
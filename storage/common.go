@@ -27,11 +27,13 @@ import (
 	"fmt"
 	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/utils"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"math/big"
 	"reflect"
 	"strings"
+	"time"
 )
 
 const (
@@ -206,8 +208,12 @@ func (conn *DBClient) BatchUpdateInscriptionStats(dbTx *gorm.DB, chain string, i
 
 	fields := map[string]string{
 		"minted":  "%s",
+		"burned":  "%s",
+		"wrapped": "%s",
 		"holders": "%d",
 		"tx_cnt":  "%d",
+		"last_sn": "%d",
+		"fees":    "%s",
 	}
 
 	vals := make([]map[string]interface{}, 0, len(items))
@@ -215,8 +221,12 @@ func (conn *DBClient) BatchUpdateInscriptionStats(dbTx *gorm.DB, chain string, i
 		vals = append(vals, map[string]interface{}{
 			"sid":     item.SID,
 			"minted":  item.Minted,
+			"burned":  item.Burned,
+			"wrapped": item.Wrapped,
 			"holders": item.Holders,
 			"tx_cnt":  item.TxCnt,
+			"last_sn": item.LastSN,
+			"fees":    item.Fees,
 		})
 	}
 	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.InscriptionsStats{}.TableName(), fields, vals)
@@ -267,16 +277,18 @@ func (conn *DBClient) BatchUpdateBalances(dbTx *gorm.DB, chain string, items []*
 	}
 
 	fields := map[string]string{
-		"available": "%s",
-		"balance":   "%s",
+		"available":  "%s",
+		"balance":    "%s",
+		"parse_mode": "%s",
 	}
 
 	vals := make([]map[string]interface{}, 0, len(items))
 	for _, item := range items {
 		vals = append(vals, map[string]interface{}{
-			"sid":       item.SID,
-			"available": item.Available,
-			"balance":   item.Balance,
+			"sid":        item.SID,
+			"available":  item.Available,
+			"balance":    item.Balance,
+			"parse_mode": item.ParseMode,
 		})
 	}
 	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.Balances{}.TableName(), fields, vals)
@@ -286,12 +298,285 @@ func (conn *DBClient) BatchUpdateBalances(dbTx *gorm.DB, chain string, items []*
 	return nil
 }
 
+func (conn *DBClient) BatchAddAddressMints(dbTx *gorm.DB, items []*model.AddressMints) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateAddressMints(dbTx *gorm.DB, chain string, items []*model.AddressMints) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"minted":   "%s",
+		"mint_cnt": "%d",
+		"claimed":  "%s",
+	}
+
+	vals := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, map[string]interface{}{
+			"sid":      item.SID,
+			"minted":   item.Minted,
+			"mint_cnt": item.MintCnt,
+			"claimed":  item.Claimed,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.AddressMints{}.TableName(), fields, vals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddListings(dbTx *gorm.DB, items []*model.Listings) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateListings(dbTx *gorm.DB, chain string, items []*model.Listings) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"buyer":   "%s",
+		"status":  "%d",
+		"tx_hash": "%s",
+	}
+
+	vals := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, map[string]interface{}{
+			"sid":     item.SID,
+			"buyer":   item.Buyer,
+			"status":  item.Status,
+			"tx_hash": item.TxHash,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.Listings{}.TableName(), fields, vals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddEthscriptions(dbTx *gorm.DB, items []*model.Ethscriptions) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateEthscriptions(dbTx *gorm.DB, chain string, items []*model.Ethscriptions) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"owner":   "%s",
+		"tx_hash": "%s",
+	}
+
+	vals := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, map[string]interface{}{
+			"sid":     item.SID,
+			"owner":   item.Owner,
+			"tx_hash": item.TxHash,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.Ethscriptions{}.TableName(), fields, vals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddNames(dbTx *gorm.DB, items []*model.Names) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+// BatchUpdateNames
+/***************************************
+ * update owners & records of names, records are user contents & bound as parameters
+ ***************************************/
+func (conn *DBClient) BatchUpdateNames(dbTx *gorm.DB, chain string, items []*model.Names) error {
+	for _, item := range items {
+		err := dbTx.Model(&model.Names{}).Where("chain = ? AND sid = ?", chain, item.SID).Updates(map[string]interface{}{
+			"owner":   item.Owner,
+			"address": item.Address,
+			"avatar":  item.Avatar,
+			"text":    item.Text,
+			"tx_hash": item.TxHash,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddWrappedBalances(dbTx *gorm.DB, items []*model.WrappedBalances) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateWrappedBalances(dbTx *gorm.DB, chain string, items []*model.WrappedBalances) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"amount": "%s",
+	}
+
+	vals := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, map[string]interface{}{
+			"sid":    item.SID,
+			"amount": item.Amount,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.WrappedBalances{}.TableName(), fields, vals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddLocks(dbTx *gorm.DB, items []*model.Locks) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateLocks(dbTx *gorm.DB, chain string, items []*model.Locks) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"released": "%s",
+	}
+
+	vals := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, map[string]interface{}{
+			"sid":      item.SID,
+			"released": item.Released,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.Locks{}.TableName(), fields, vals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddMintNonces(dbTx *gorm.DB, items []*model.MintNonces) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchAddTrades(dbTx *gorm.DB, items []*model.Trades) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchAddMarketStats(dbTx *gorm.DB, items []*model.MarketStats) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateMarketStats(dbTx *gorm.DB, chain string, items []*model.MarketStats) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"volume":          "%s",
+		"trade_cnt":       "%d",
+		"last_price":      "%s",
+		"floor_price":     "%s",
+		"volume_24h":      "%s",
+		"trade_cnt_24h":   "%d",
+		"last_trade_time": "%d",
+	}
+
+	vals := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		vals = append(vals, map[string]interface{}{
+			"sid":             item.SID,
+			"volume":          item.Volume,
+			"trade_cnt":       item.TradeCnt,
+			"last_price":      item.LastPrice,
+			"floor_price":     item.FloorPrice,
+			"volume_24h":      item.Volume24h,
+			"trade_cnt_24h":   item.TradeCnt24h,
+			"last_trade_time": item.LastTradeTime,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.MarketStats{}.TableName(), fields, vals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddUTXOs(dbTx *gorm.DB, items []*model.UTXO) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+// BatchSpendUTXOs mark balances held by the outpoints spent
+func (conn *DBClient) BatchSpendUTXOs(dbTx *gorm.DB, chain string, items []*model.UTXO) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	outpoints := make(map[string][]string, 1)
+	for _, item := range items {
+		outpoints[item.Protocol] = append(outpoints[item.Protocol], item.Outpoint)
+	}
+
+	for protocol, values := range outpoints {
+		err := dbTx.Model(&model.UTXO{}).
+			Where("chain = ? and protocol = ? and outpoint in ?", chain, protocol, values).
+			Update("status", model.UTXOStatusSpent).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (conn *DBClient) UpdateInscriptionsStatsBySID(dbTx *gorm.DB, chain string, id uint32, updates map[string]interface{}) error {
 	return dbTx.Table(model.InscriptionsStats{}.TableName()).Where("chain = ?", chain).Where("sid = ?", id).Updates(updates).Error
 }
 
 // FindInscriptionByTick find token by tick
 func (conn *DBClient) FindInscriptionByTick(chain, protocol, tick string) (*model.Inscriptions, error) {
+	tick = utils.CanonicalTick(tick)
 	inscriptionBaseInfo := &model.Inscriptions{}
 	err := conn.SqlDB.First(inscriptionBaseInfo, "chain = ? AND protocol = ? AND tick = ?", chain, protocol, tick).Error
 	if err != nil {
@@ -321,6 +606,7 @@ func (conn *DBClient) FindInscriptionStatsInfoByBaseId(insId uint32) (*model.Ins
 }
 
 func (conn *DBClient) FindUserBalanceByTick(chain, protocol, tick, addr string) (*model.Balances, error) {
+	tick = utils.CanonicalTick(tick)
 	balance := &model.Balances{}
 	err := conn.SqlDB.First(balance, "chain = ? AND protocol = ? AND tick = ? AND address = ?", chain, protocol, tick, addr).Error
 	if err != nil {
@@ -346,6 +632,7 @@ func (conn *DBClient) FindTransaction(chain string, hash string) (*model.Transac
 
 func (conn *DBClient) GetInscriptions(limit, offset int, chain, protocol, tick, deployBy string, sort int, sortMode int) (
 	[]*model.InscriptionOverView, int64, error) {
+	tick = utils.CanonicalTick(tick)
 
 	var data []*model.InscriptionOverView
 	var total int64
@@ -430,6 +717,7 @@ func (conn *DBClient) GetInscriptionsByAddress(limit, offset int, address string
 
 func (conn *DBClient) GetTransactionsByAddress(limit, offset int, address, chain, protocol, tick, key string, event int8) (
 	[]*model.AddressTransaction, int64, error) {
+	tick = utils.CanonicalTick(tick)
 
 	var data []*model.AddressTransaction
 	var total int64
@@ -464,6 +752,7 @@ func (conn *DBClient) GetTransactionsByAddress(limit, offset int, address, chain
 }
 
 func (conn *DBClient) GetAddressTxs(limit, offset int, address, chain, protocol, tick string, event int8) ([]*model.AddressTransaction, int64, error) {
+	tick = utils.CanonicalTick(tick)
 	var data []*model.AddressTransaction
 	var total int64
 
@@ -504,6 +793,7 @@ func (conn *DBClient) GetTxsByHashes(chain string, hashes []string) ([]*model.Tr
 
 func (conn *DBClient) GetAddressInscriptions(limit, offset int, address, chain, protocol, tick string, sort int) (
 	[]*model.BalanceInscription, int64, error) {
+	tick = utils.CanonicalTick(tick)
 
 	var data []*model.BalanceInscription
 	var total int64
@@ -539,6 +829,7 @@ func (conn *DBClient) GetAddressInscriptions(limit, offset int, address, chain,
 
 func (conn *DBClient) GetBalancesByAddress(limit, offset int, address, chain, protocol, tick string) (
 	[]*model.Balances, int64, error) {
+	tick = utils.CanonicalTick(tick)
 
 	var balances []*model.Balances
 	var total int64
@@ -562,6 +853,7 @@ func (conn *DBClient) GetBalancesByAddress(limit, offset int, address, chain, pr
 }
 
 func (conn *DBClient) GetHoldersByTick(limit, offset int, chain, protocol, tick string, sortMode int) ([]*model.Balances, int64, error) {
+	tick = utils.CanonicalTick(tick)
 	var holders []*model.Balances
 	var total int64
 	query := conn.SqlDB.Model(&model.Balances{}).
@@ -580,6 +872,7 @@ func (conn *DBClient) GetHoldersByTick(limit, offset int, chain, protocol, tick
 }
 
 func (conn *DBClient) GetUTXOCount(address, chain, protocol, tick string) (int64, error) {
+	tick = utils.CanonicalTick(tick)
 	var count int64
 	query := conn.SqlDB.Model(&model.UTXO{}).
 		Where("address = ? and chain = ? and protocol = ? and tick = ? and status = ?", address, chain, protocol, tick, model.UTXOStatusUnspent)
@@ -599,6 +892,228 @@ func (conn *DBClient) GetBalancesByIdLimit(chain string, start uint64, limit int
 	return balances, nil
 }
 
+func (conn *DBClient) GetAddressMintsByIdLimit(chain string, start uint64, limit int) ([]model.AddressMints, error) {
+	items := make([]model.AddressMints, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (conn *DBClient) GetMintNoncesByIdLimit(chain string, start uint64, limit int) ([]model.MintNonces, error) {
+	items := make([]model.MintNonces, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (conn *DBClient) GetListingsByIdLimit(chain string, start uint64, limit int) ([]model.Listings, error) {
+	items := make([]model.Listings, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (conn *DBClient) GetMarketStatsByIdLimit(chain string, start uint64, limit int) ([]model.MarketStats, error) {
+	items := make([]model.MarketStats, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetTradesSince trades of the chain since block time, used by market window cache loading
+func (conn *DBClient) GetTradesSince(chain string, since time.Time) ([]model.Trades, error) {
+	items := make([]model.Trades, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("block_time >= ?", since).Order("id asc").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetTrades trade history of tick, latest first
+func (conn *DBClient) GetTrades(limit, offset int, chain, protocol, tick string) ([]*model.Trades, int64, error) {
+	tick = utils.CanonicalTick(tick)
+	var trades []*model.Trades
+	var total int64
+	query := conn.SqlDB.Model(&model.Trades{}).
+		Where("chain = ? and protocol = ? and tick = ?", chain, protocol, tick)
+	query = query.Count(&total)
+
+	result := query.Order("id desc").Limit(limit).Offset(offset).Find(&trades)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return trades, total, nil
+}
+
+// FindMarketStats market stats of tick, nil if never traded
+func (conn *DBClient) FindMarketStats(chain, protocol, tick string) (*model.MarketStats, error) {
+	tick = utils.CanonicalTick(tick)
+	item := &model.MarketStats{}
+	err := conn.SqlDB.First(item, "chain = ? AND protocol = ? AND tick = ?", chain, protocol, tick).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetEthscriptionsByIdLimit ethscriptions without contents, used by cache loading
+func (conn *DBClient) GetEthscriptionsByIdLimit(chain string, start uint64, limit int) ([]model.Ethscriptions, error) {
+	items := make([]model.Ethscriptions, 0)
+	err := conn.SqlDB.Omit("content").Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindEthscription find ethscription by id (creation tx hash) or sequence number
+func (conn *DBClient) FindEthscription(chain, id string, sn uint64) (*model.Ethscriptions, error) {
+	query := conn.SqlDB.Where("chain = ?", chain)
+	if id != "" {
+		query = query.Where("ethscription_id = ?", id)
+	} else {
+		query = query.Where("sid = ?", sn)
+	}
+
+	item := &model.Ethscriptions{}
+	err := query.First(item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetNamesByIdLimit names used by cache loading
+func (conn *DBClient) GetNamesByIdLimit(chain string, start uint64, limit int) ([]model.Names, error) {
+	items := make([]model.Names, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindName forward resolution, find name by the name
+func (conn *DBClient) FindName(chain, name string) (*model.Names, error) {
+	item := &model.Names{}
+	err := conn.SqlDB.Where("chain = ? AND name = ?", chain, name).First(item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// FindNamesByAddress reverse resolution, names resolved to & owned by the address in registration order
+func (conn *DBClient) FindNamesByAddress(chain, address string, limit, offset int) ([]*model.Names, int64, error) {
+	var total int64
+	query := conn.SqlDB.Model(&model.Names{}).Where("chain = ? AND address = ? AND owner = ?", chain, address, address)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*model.Names, 0)
+	err := query.Order("sid asc").Limit(limit).Offset(offset).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// GetWrappedBalancesByIdLimit wrapped balances used by cache loading
+func (conn *DBClient) GetWrappedBalancesByIdLimit(chain string, start uint64, limit int) ([]model.WrappedBalances, error) {
+	items := make([]model.WrappedBalances, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindWrappedBalances wrapped balances of the address held in wrapper contracts of the tick
+func (conn *DBClient) FindWrappedBalances(chain, protocol, tick, address string) ([]*model.WrappedBalances, error) {
+	tick = utils.CanonicalTick(tick)
+	items := make([]*model.WrappedBalances, 0)
+	err := conn.SqlDB.Where("chain = ? AND protocol = ? AND tick = ? AND address = ? AND amount > 0", chain, protocol, tick, address).
+		Order("sid asc").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetLocksByIdLimit locks used by cache loading
+func (conn *DBClient) GetLocksByIdLimit(chain string, start uint64, limit int) ([]model.Locks, error) {
+	items := make([]model.Locks, 0)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindLocksByAddress locks of the address, fully released locks excluded if not all
+func (conn *DBClient) FindLocksByAddress(chain, protocol, tick, address string, all bool, limit, offset int) ([]*model.Locks, int64, error) {
+	query := conn.SqlDB.Model(&model.Locks{}).Where("chain = ? AND address = ?", chain, address)
+	if protocol != "" {
+		query = query.Where("protocol = ?", protocol)
+	}
+	if tick != "" {
+		query = query.Where("tick = ?", utils.CanonicalTick(tick))
+	}
+	if !all {
+		query = query.Where("released < amount")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*model.Locks, 0)
+	err := query.Order("sid asc").Limit(limit).Offset(offset).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// GetLastSN returns the last inscription sequence number of the chain
+func (conn *DBClient) GetLastSN(chain string) (uint64, error) {
+	var sn, lastSN uint64
+	err := conn.SqlDB.Model(&model.Ethscriptions{}).Where("chain = ?", chain).Select("COALESCE(MAX(sid), 0)").Scan(&sn).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = conn.SqlDB.Model(&model.InscriptionsStats{}).Where("chain = ?", chain).Select("COALESCE(MAX(last_sn), 0)").Scan(&lastSN).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if lastSN > sn {
+		sn = lastSN
+	}
+	return sn, nil
+}
+
 func (conn *DBClient) GetUTXOsByIdLimit(start uint64, limit int) ([]model.UTXO, error) {
 	utxos := make([]model.UTXO, 0, limit)
 	err := conn.SqlDB.Where("id > ? ", start).Where("status = ? ", model.UTXOStatusUnspent).Order("id asc").Limit(limit).Find(&utxos).Error
@@ -609,6 +1124,7 @@ func (conn *DBClient) GetUTXOsByIdLimit(start uint64, limit int) ([]model.UTXO,
 }
 
 func (conn *DBClient) GetUtxosByAddress(address, chain, protocol, tick string) ([]*model.UTXO, error) {
+	tick = utils.CanonicalTick(tick)
 	var utxos []*model.UTXO
 	query := conn.SqlDB.Model(&model.UTXO{}).
 		Where("address = ? and chain = ? and protocol = ? and tick = ? and status = ?", address, chain, protocol, tick, model.UTXOStatusUnspent)
@@ -651,6 +1167,7 @@ func (conn *DBClient) GetInscriptionsByChain(chain string, hashes []string) ([]*
 }
 
 func (conn *DBClient) FindInscriptionsStatsByTick(chain string, protocol string, tick string) (*model.InscriptionsStats, error) {
+	tick = utils.CanonicalTick(tick)
 	inscriptionStats := &model.InscriptionsStats{}
 	err := conn.SqlDB.First(inscriptionStats, "chain = ? AND protocol = ? AND tick = ?", chain, protocol, tick).Error
 	if err != nil {
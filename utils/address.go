@@ -0,0 +1,56 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"fmt"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"strings"
+)
+
+// DecodeBech32Address
+/***************************************
+ * decode bech32 addresses of cosmos chains, returns the lower case human readable prefix & address bytes
+ ***************************************/
+func DecodeBech32Address(address string) (string, []byte, error) {
+	hrp, data, err := bech32.Decode(address)
+	if err != nil {
+		return "", nil, err
+	}
+
+	bytes, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(bytes) <= 0 {
+		return "", nil, fmt.Errorf("bech32 address[%s] empty", address)
+	}
+	return strings.ToLower(hrp), bytes, nil
+}
+
+// IsBech32Address reports whether the address is a valid bech32 address
+func IsBech32Address(address string) bool {
+	_, _, err := DecodeBech32Address(address)
+	return err == nil
+}
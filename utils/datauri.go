@@ -0,0 +1,221 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const dataURIScheme = "data:"
+
+// GzipMagic gzip compressed data header of deflate method, ESIP-7 calldata compression
+var GzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// DataURI RFC 2397 data URI
+// data:[<mediatype>][;<attribute>=<value>]*[;base64],<data>
+type DataURI struct {
+	MediaType string            // lower case type/subtype, empty if omitted
+	Params    map[string]string // lower case attribute -> value, e.g. rule=esip6
+	Base64    bool
+	Data      []byte // decoded data
+}
+
+// Param get the value of a media type parameter
+func (d *DataURI) Param(name string) string {
+	return d.Params[strings.ToLower(name)]
+}
+
+// ParseDataURI
+/***************************************
+ * parse data uri, data larger than maxSize after decoding is rejected, 0: unlimited
+ * the "data:" scheme is optional for compatibility with ",<data>" inputs
+ ***************************************/
+func ParseDataURI(input string, maxSize int) (*DataURI, error) {
+	if len(input) >= len(dataURIScheme) && strings.EqualFold(input[:len(dataURIScheme)], dataURIScheme) {
+		input = input[len(dataURIScheme):]
+	} else if !strings.HasPrefix(input, ",") {
+		return nil, fmt.Errorf("data uri scheme missing")
+	}
+
+	sepIdx := strings.Index(input, ",")
+	if sepIdx == -1 {
+		return nil, fmt.Errorf("data seprator index failed")
+	}
+
+	d := &DataURI{
+		Params: make(map[string]string),
+	}
+	header, payload := input[:sepIdx], input[sepIdx+1:]
+	parts := strings.Split(header, ";")
+	d.MediaType = strings.ToLower(strings.TrimSpace(parts[0]))
+	if d.MediaType != "" && !strings.Contains(d.MediaType, "/") {
+		return nil, fmt.Errorf("media type[%s] invalid", d.MediaType)
+	}
+
+	for i, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if strings.EqualFold(part, "base64") {
+			// base64 extension must be the last one
+			if i != len(parts)-2 {
+				return nil, fmt.Errorf("base64 extension must be the last parameter")
+			}
+			d.Base64 = true
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("media type parameter[%s] invalid", part)
+		}
+		d.Params[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.TrimSpace(kv[1])
+	}
+
+	data, err := decodeDataURIPayload(payload, d.Base64)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize > 0 && len(data) > maxSize {
+		return nil, fmt.Errorf("data size[%d] > %d", len(data), maxSize)
+	}
+	d.Data = data
+	return d, nil
+}
+
+func decodeDataURIPayload(payload string, isBase64 bool) ([]byte, error) {
+	if !isBase64 {
+		// percent-encoded octets, unescaped only if the raw data isn't valid json as is
+		if strings.Contains(payload, "%") && !json.Valid([]byte(payload)) {
+			if unescaped, err := url.PathUnescape(payload); err == nil {
+				payload = unescaped
+			}
+		}
+		return []byte(payload), nil
+	}
+
+	data, err := decodeBase64(payload)
+	if err == nil || !strings.Contains(payload, "%") {
+		return data, err
+	}
+
+	// percent-encoded base64 data, e.g. %3D paddings
+	unescaped, err1 := url.PathUnescape(payload)
+	if err1 != nil {
+		return nil, err
+	}
+	return decodeBase64(unescaped)
+}
+
+func decodeBase64(payload string) ([]byte, error) {
+	payload = strings.TrimSpace(payload)
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		// unpadded base64 data
+		data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "="))
+		if err != nil {
+			return nil, fmt.Errorf("base64 data decode err:%v", err)
+		}
+	}
+	return data, nil
+}
+
+// IsDataURIMarker
+/***************************************
+ * data uri without data, the content carried outside of the input, e.g. blobs of the tx
+ ***************************************/
+func IsDataURIMarker(input string) bool {
+	if strings.EqualFold(input, dataURIScheme) {
+		return true
+	}
+
+	if !strings.HasPrefix(strings.ToLower(input), dataURIScheme) || !strings.HasSuffix(input, ",") || strings.Count(input, ",") != 1 {
+		return false
+	}
+	_, err := ParseDataURI(input, 0)
+	return err == nil
+}
+
+// AttachDataURI
+/***************************************
+ * build data uri of the marker & content, e.g. data:image/png;base64, + raw png bytes
+ * content of bare markers is kept if it is a data uri or gzipped itself
+ * binary content is base64 encoded & text content kept as is
+ ***************************************/
+func AttachDataURI(marker string, content []byte) []byte {
+	header := strings.TrimSuffix(marker[len(dataURIScheme):], ",")
+	isURI := len(content) >= len(dataURIScheme) && strings.EqualFold(string(content[:len(dataURIScheme)]), dataURIScheme)
+	if header == "" && (isURI || IsGzipped(content)) {
+		return content
+	}
+
+	base64Ext := strings.HasSuffix(strings.ToLower(header), ";base64")
+	if !base64Ext && (!utf8.Valid(content) || bytes.Contains(content, []byte("%"))) {
+		header += ";base64"
+		base64Ext = true
+	}
+
+	if base64Ext {
+		return []byte(dataURIScheme + header + "," + base64.StdEncoding.EncodeToString(content))
+	}
+	return append([]byte(dataURIScheme+header+","), content...)
+}
+
+// IsGzipped gzip magic header & deflate method checking
+func IsGzipped(data []byte) bool {
+	return bytes.HasPrefix(data, GzipMagic)
+}
+
+// Gunzip
+/***************************************
+ * decompress gzip data, output larger than maxSize is rejected, 0: unlimited
+ ***************************************/
+func Gunzip(data []byte, maxSize int) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip reader init err:%v", err)
+	}
+	defer r.Close()
+
+	var reader io.Reader = r
+	if maxSize > 0 {
+		reader = io.LimitReader(r, int64(maxSize)+1)
+	}
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("gzip data decompress err:%v", err)
+	}
+
+	if maxSize > 0 && len(out) > maxSize {
+		return nil, fmt.Errorf("gzip decompressed size > %d", maxSize)
+	}
+	return out, nil
+}
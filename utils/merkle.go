@@ -0,0 +1,59 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wealdtech/go-merkletree/keccak256"
+	"math/big"
+)
+
+// MerkleLeaf
+/***************************************
+ * allowlist leaf, keccak256(abi.encodePacked(address[, uint256 allocation]))
+ ***************************************/
+func MerkleLeaf(address common.Address, allocation *big.Int) []byte {
+	data := address.Bytes()
+	if allocation != nil {
+		data = append(data, common.LeftPadBytes(allocation.Bytes(), 32)...)
+	}
+	return keccak256.New().Hash(data)
+}
+
+// VerifyMerkleProof
+/***************************************
+ * keccak256 merkle proof verifying, pairs are hashed in sorted order (OpenZeppelin MerkleProof)
+ ***************************************/
+func VerifyMerkleProof(leaf []byte, proof [][]byte, root []byte) bool {
+	h := keccak256.New()
+	computed := leaf
+	for _, node := range proof {
+		if bytes.Compare(computed, node) <= 0 {
+			computed = h.Hash(append(append([]byte{}, computed...), node...))
+		} else {
+			computed = h.Hash(append(append([]byte{}, node...), computed...))
+		}
+	}
+	return bytes.Equal(computed, root)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// plainDecimal amount grammar of strict mode, digits with optional fraction, no sign / exponent
+var plainDecimal = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// plainInteger integer grammar of strict mode, digits only
+var plainInteger = regexp.MustCompile(`^[0-9]+$`)
+
+// IsPlainDecimal checks the string is a plain decimal number
+func IsPlainDecimal(s string) bool {
+	return plainDecimal.MatchString(s)
+}
+
+// IsPlainInteger checks the string is a plain integer number
+func IsPlainInteger(s string) bool {
+	return plainInteger.MatchString(s)
+}
+
+// VerifyStrictJSON
+/***************************************
+ * strict json grammar checking of inscription data
+ * 1. single json object, duplicate keys of any object are rejected, keys compared case-insensitively
+ * 2. number values are rejected, amounts must be strings
+ * 3. string values of amount keys must be plain decimals, of integer keys plain integers
+ ***************************************/
+func VerifyStrictJSON(data []byte, amountKeys, integerKeys map[string]bool) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("json object required")
+	}
+	if err = verifyStrictObject(dec, amountKeys, integerKeys); err != nil {
+		return err
+	}
+
+	// trailing data
+	if _, err = dec.Token(); err != io.EOF {
+		return fmt.Errorf("trailing data after json object")
+	}
+	return nil
+}
+
+func verifyStrictObject(dec *json.Decoder, amountKeys, integerKeys map[string]bool) error {
+	keys := make(map[string]struct{}, 8)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		// keys are matched case-insensitively by the protocol decoding
+		key := strings.ToLower(tok.(string))
+		if _, ok := keys[key]; ok {
+			return fmt.Errorf("duplicate key[%s]", key)
+		}
+		keys[key] = struct{}{}
+
+		if err = verifyStrictValue(dec, key, amountKeys, integerKeys); err != nil {
+			return err
+		}
+	}
+
+	// closing delimiter
+	_, err := dec.Token()
+	return err
+}
+
+func verifyStrictValue(dec *json.Decoder, key string, amountKeys, integerKeys map[string]bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			return verifyStrictObject(dec, amountKeys, integerKeys)
+		}
+
+		// array items share the key of the array
+		for dec.More() {
+			if err = verifyStrictValue(dec, key, amountKeys, integerKeys); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	case json.Number:
+		return fmt.Errorf("number value of key[%s] not allowed, string required", key)
+	case string:
+		if amountKeys[key] && !IsPlainDecimal(v) {
+			return fmt.Errorf("invalid decimal[%s] of key[%s]", v, key)
+		}
+		if integerKeys[key] && !IsPlainInteger(v) {
+			return fmt.Errorf("invalid integer[%s] of key[%s]", v, key)
+		}
+	}
+	return nil
+}
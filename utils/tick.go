@@ -0,0 +1,100 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	TickLengthRune = "rune" // count unicode characters, default
+	TickLengthByte = "byte" // count utf-8 encoded bytes
+)
+
+// confusables cross-script homoglyphs of latin letters, subset of unicode UTS #39 confusables
+var confusables = map[rune]rune{
+	// cyrillic
+	'а': 'a', 'в': 'b', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ӏ': 'l', 'ј': 'j', 'к': 'k', 'м': 'm',
+	'н': 'h', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'т': 't', 'у': 'y', 'ԝ': 'w', 'х': 'x', 'ү': 'y',
+	// greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't',
+	'υ': 'u', 'χ': 'x', 'γ': 'y', 'ω': 'w',
+	// latin look-alikes
+	'ı': 'i', 'ȷ': 'j', 'ɑ': 'a', 'ɡ': 'g', 'ɩ': 'i', 'ʟ': 'l', 'ℓ': 'l',
+}
+
+// CanonicalTick
+/***************************************
+ * canonical form of tick, trim spaces & unicode NFC composition, case preserved
+ ***************************************/
+func CanonicalTick(tick string) string {
+	return norm.NFC.String(strings.TrimSpace(tick))
+}
+
+// NormalizeTick
+/***************************************
+ * canonical tick & lower case if tick is case-insensitive
+ ***************************************/
+func NormalizeTick(tick string, caseSensitive bool) string {
+	tick = CanonicalTick(tick)
+	if !caseSensitive {
+		tick = strings.ToLower(tick)
+	}
+	return tick
+}
+
+// TickLength
+/***************************************
+ * tick length by unit, bytes or unicode characters
+ ***************************************/
+func TickLength(tick, unit string) int {
+	if unit == TickLengthByte {
+		return len(tick)
+	}
+	return utf8.RuneCountInString(tick)
+}
+
+// TickSkeleton
+/***************************************
+ * visual skeleton of tick, ticks with the same skeleton are confusable
+ * compatibility decomposition, drops marks / invisible chars, folds case & homoglyphs
+ ***************************************/
+func TickSkeleton(tick string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFKD.String(strings.TrimSpace(tick)) {
+		// combining marks, zero width & emoji variation selectors
+		if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Cf, r) || unicode.Is(unicode.Variation_Selector, r) {
+			continue
+		}
+
+		r = unicode.ToLower(r)
+		if c, ok := confusables[r]; ok {
+			r = c
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}